@@ -1,14 +1,21 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 
 	mlltcli "github.com/ajilisiwei/mllt-cli"
 	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/course"
 	"github.com/ajilisiwei/mllt-cli/internal/lang"
 	"github.com/ajilisiwei/mllt-cli/internal/manage"
 	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
 	"github.com/ajilisiwei/mllt-cli/internal/ui"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
@@ -78,12 +85,65 @@ var practiceCmd = &cobra.Command{
 	},
 }
 
+// practiceReverse 表示本次练习是否反向练习（看原文输入翻译），只影响本次运行，不修改配置
+var practiceReverse bool
+
+// reverseForRun 返回本次练习是否反向：--reverse 只作用于本次运行，未指定时按配置的练习方向
+func reverseForRun() bool {
+	return practiceReverse || practice.IsReverse()
+}
+
+// practiceResume 表示是否从上次中途退出的位置继续练习
+var practiceResume bool
+
+// practiceFileArgs 校验练习子命令的参数：最多指定一个文件，--reverse 和 --resume 只作用于指定的文件，
+// 没有指定文件时只列出可用文件，这两个参数不会生效，直接报错提示
+func practiceFileArgs(cmd *cobra.Command, args []string) error {
+	if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+		return err
+	}
+	if len(args) == 0 && (practiceReverse || practiceResume) {
+		return fmt.Errorf("--reverse 和 --resume 需要指定练习文件: %s <file>", cmd.CommandPath())
+	}
+	return nil
+}
+
+// checkLessonUnlocked 资源属于要求按顺序学习的课程且尚未解锁时提示并退出，与练习界面的文件列表保持一致
+func checkLessonUnlocked(resourceType, fileName string) {
+	c, err := course.LoadForFile(resourceType, fileName)
+	if err != nil || c == nil {
+		return
+	}
+	_, lessonName := practice.SplitResourceIdentifier(fileName)
+	if lesson := c.Lesson(lessonName); lesson != nil && lesson.Status == course.LessonLocked {
+		fmt.Printf("%s 尚未解锁，请先完成当前课", lessonName)
+		if current := c.Current(); current != nil {
+			fmt.Printf("：%s", current.Name)
+		}
+		fmt.Println()
+		os.Exit(1)
+	}
+}
+
+// runResumedPractice 在练习界面中从上次中途退出的位置继续练习，没有保存的进度时从头开始
+func runResumedPractice(resourceType, fileName string) {
+	model, ok := ui.NewResumedPracticeModel(resourceType, fileName, ui.SessionOptions{Reverse: practiceReverse})
+	if !ok {
+		fmt.Println("没有可继续的练习进度，将从头开始练习。")
+	}
+	p := tea.NewProgram(model, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Printf("启动练习界面失败: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 // practiceWordsCmd 表示practice words子命令
 var practiceWordsCmd = &cobra.Command{
 	Use:   "words [file]",
 	Short: "单词练习",
 	Long:  `单词练习功能，从指定的单词列表文件中读取单词进行练习。`,
-	Args:  cobra.MaximumNArgs(1),
+	Args:  practiceFileArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 如果没有指定文件，则列出可用的单词列表文件
 		if len(args) == 0 {
@@ -107,7 +167,12 @@ var practiceWordsCmd = &cobra.Command{
 
 		// 指定了文件，进行单词练习
 		fileName := args[0]
-		if err := practice.WordPractice(fileName); err != nil {
+		checkLessonUnlocked(practice.Words, fileName)
+		if practiceResume {
+			runResumedPractice(practice.Words, fileName)
+			return
+		}
+		if err := practice.WordPractice(fileName, reverseForRun()); err != nil {
 			fmt.Println("单词练习失败:", err)
 		}
 	},
@@ -118,7 +183,7 @@ var practicePhrasesCmd = &cobra.Command{
 	Use:   "phrases [file]",
 	Short: "短语练习",
 	Long:  `短语练习功能，从指定的短语列表文件中读取短语进行练习。`,
-	Args:  cobra.MaximumNArgs(1),
+	Args:  practiceFileArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 如果没有指定文件，则列出可用的短语列表文件
 		if len(args) == 0 {
@@ -142,7 +207,12 @@ var practicePhrasesCmd = &cobra.Command{
 
 		// 指定了文件，进行短语练习
 		fileName := args[0]
-		if err := practice.PhrasePractice(fileName); err != nil {
+		checkLessonUnlocked(practice.Phrases, fileName)
+		if practiceResume {
+			runResumedPractice(practice.Phrases, fileName)
+			return
+		}
+		if err := practice.PhrasePractice(fileName, reverseForRun()); err != nil {
 			fmt.Println("短语练习失败:", err)
 		}
 	},
@@ -153,7 +223,7 @@ var practiceSentencesCmd = &cobra.Command{
 	Use:   "sentences [file]",
 	Short: "句子练习",
 	Long:  `句子练习功能，从指定的句子列表文件中读取句子进行练习。`,
-	Args:  cobra.MaximumNArgs(1),
+	Args:  practiceFileArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 如果没有指定文件，则列出可用的句子列表文件
 		if len(args) == 0 {
@@ -177,7 +247,12 @@ var practiceSentencesCmd = &cobra.Command{
 
 		// 指定了文件，进行句子练习
 		fileName := args[0]
-		if err := practice.SentencePractice(fileName); err != nil {
+		checkLessonUnlocked(practice.Sentences, fileName)
+		if practiceResume {
+			runResumedPractice(practice.Sentences, fileName)
+			return
+		}
+		if err := practice.SentencePractice(fileName, reverseForRun()); err != nil {
 			fmt.Println("句子练习失败:", err)
 		}
 	},
@@ -187,8 +262,10 @@ var practiceSentencesCmd = &cobra.Command{
 var practiceArticlesCmd = &cobra.Command{
 	Use:   "articles [file]",
 	Short: "文章练习",
-	Long:  `文章练习功能，从指定的文章文件中读取文章进行练习。`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `文章练习功能，从指定的文章文件中读取文章进行练习。
+中途按 Esc 退出时会保存进度，加 --resume 可在练习界面中从上次退出的行继续，例如：
+  mllt-cli practice articles NCE-3/3-001_A_puma_at_large --resume`,
+	Args: practiceFileArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		// 如果没有指定文件，则列出可用的文章文件
 		if len(args) == 0 {
@@ -212,12 +289,301 @@ var practiceArticlesCmd = &cobra.Command{
 
 		// 指定了文件，进行文章练习
 		fileName := args[0]
+		checkLessonUnlocked(practice.Articles, fileName)
+		if practiceResume {
+			runResumedPractice(practice.Articles, fileName)
+			return
+		}
 		if err := practice.ArticlePractice(fileName); err != nil {
 			fmt.Println("文章练习失败:", err)
 		}
 	},
 }
 
+// practiceChoiceCmd 表示practice choice子命令
+var practiceChoiceCmd = &cobra.Command{
+	Use:   "choice [words|phrases] [file]",
+	Short: "选择题练习",
+	Long: `选择题练习：显示单词或短语，从四个释义中选择正确的一个，按 1-4 作答。
+干扰项取自同一文件中其他条目的释义。练习结果与打字练习一样写入记忆计划和统计数据。`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		resourceType := strings.ToLower(args[0])
+		if resourceType != practice.Words && resourceType != practice.Phrases {
+			fmt.Println("选择题只支持 words 或 phrases")
+			os.Exit(1)
+		}
+		checkLessonUnlocked(resourceType, args[1])
+		p := tea.NewProgram(ui.NewChoiceSession(resourceType, args[1]), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			fmt.Printf("启动练习界面失败: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// reviewCmd 表示review子命令
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "今日复习",
+	Long: `汇总当前语言下所有单词、短语、句子资源中已到期的内容，集中进行一次复习练习。
+复习结果会分别写回各条目所属资源文件的记忆计划，并按自适应算法（SM-2）安排下次复习。
+只有已经建立记忆计划的资源（在 ebbinghaus 顺序下练习过）才会参与复习。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		p := tea.NewProgram(ui.NewReviewSession(), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			fmt.Printf("启动复习界面失败: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// statsCmd 表示stats子命令
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "统计数据模块",
+	Long:  `统计数据模块，用于导出练习统计数据，或合并其他设备导出的统计数据。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+var (
+	statsExportFormat string
+	statsExportFrom   string
+	statsExportTo     string
+	statsExportOutput string
+)
+
+// statsExportCmd 表示stats export子命令
+var statsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出统计数据",
+	Long: `将练习统计数据导出为 CSV 或 JSON，便于在表格软件中分析或迁移到其他设备。
+例如：mllt-cli stats export --format csv --from 2024-01-01 --to 2024-01-31 -o stats.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := statistics.CheckFormat(statsExportFormat); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		from, err := statistics.ParseDate(statsExportFrom)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		to, err := statistics.ParseDate(statsExportTo)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if from != "" && to != "" && from > to {
+			fmt.Println("开始日期不能晚于结束日期")
+			os.Exit(1)
+		}
+
+		if statsExportOutput == "" {
+			if _, err := statistics.Export(os.Stdout, statsExportFormat, from, to); err != nil {
+				fmt.Fprintln(os.Stderr, "导出统计数据失败:", err)
+				os.Exit(1)
+			}
+			return
+		}
+
+		file, err := os.Create(statsExportOutput)
+		if err != nil {
+			fmt.Println("创建导出文件失败:", err)
+			os.Exit(1)
+		}
+
+		count, err := statistics.Export(file, statsExportFormat, from, to)
+		file.Close()
+		if err != nil {
+			os.Remove(statsExportOutput)
+			fmt.Println("导出统计数据失败:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("已导出 %d 条练习记录到 %s\n", count, statsExportOutput)
+	},
+}
+
+// statsImportCmd 表示stats import子命令
+var statsImportCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "导入统计数据",
+	Long: `合并其他设备通过 stats export 导出的统计数据（.csv 或 .json）。
+时间、资源类型和文件名都相同的记录视为重复，不会重复导入。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		records, err := statistics.ReadExport(args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		added, skipped, err := statistics.ImportSessions(records)
+		if err != nil {
+			fmt.Println("导入统计数据失败:", err)
+			os.Exit(1)
+		}
+		fmt.Printf("导入完成：新增 %d 条记录，跳过 %d 条重复记录\n", added, skipped)
+	},
+}
+
+// statsStreakCmd 表示stats streak子命令
+var statsStreakCmd = &cobra.Command{
+	Use:   "streak",
+	Short: "查看连续打卡天数",
+	Long: `查看当前和历史最长的连续打卡天数，以及今天的目标完成情况。
+设置了每日目标时，完成目标的日子才算打卡；未设置目标时，有练习记录即算打卡。`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		goal := config.AppConfig.DailyGoal
+
+		streak, err := statistics.GetStreak(goal, time.Now())
+		if err != nil {
+			fmt.Println("读取统计数据失败:", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("当前连续打卡: %d 天\n", streak.Current)
+		fmt.Printf("最长连续打卡: %d 天\n", streak.Longest)
+		fmt.Printf("累计打卡: %d 天\n", streak.QualifyDays)
+		if streak.LastDate != "" {
+			fmt.Printf("最近打卡: %s\n", streak.LastDate)
+		}
+
+		if !goal.Enabled() {
+			fmt.Println("尚未设置每日目标，可使用 mllt-cli setting goal 设置。")
+			return
+		}
+
+		progress, err := statistics.GetGoalProgress(time.Now().Format("2006-01-02"), goal)
+		if err != nil {
+			fmt.Println("读取今日进度失败:", err)
+			os.Exit(1)
+		}
+		if goal.Items > 0 {
+			fmt.Printf("今日项目: %d/%d\n", progress.DoneItems, goal.Items)
+		}
+		if goal.Minutes > 0 {
+			fmt.Printf("今日时长: %d/%d 分钟\n", int(progress.DoneMinutes), goal.Minutes)
+		}
+		if progress.Reached() {
+			fmt.Println("今日目标已完成！")
+		}
+	},
+}
+
+var statsChartDays int
+
+// statsChartCmd 表示stats chart子命令
+var statsChartCmd = &cobra.Command{
+	Use:   "chart",
+	Short: "查看练习图表",
+	Long:  `在终端中以图表展示最近 30 或 90 天的每日练习量、正确率趋势和各类型练习时长。`,
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if statsChartDays != 30 && statsChartDays != 90 {
+			fmt.Println("统计天数只能为 30 或 90")
+			os.Exit(1)
+		}
+		p := tea.NewProgram(ui.NewStatisticsChartView(statsChartDays), tea.WithAltScreen())
+		if _, err := p.Run(); err != nil {
+			fmt.Printf("启动图表界面失败: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// courseCmd 表示course子命令
+var courseCmd = &cobra.Command{
+	Use:   "course",
+	Short: "课程模块",
+	Long: `课程模块。资源文件夹中有 course.json 课程清单时，文件夹中的资源按课程顺序排列并标记进度：
+完整练习当前课并达到通过标准后进入下一课。清单中 "enforce": true 时锁定之后的课，通过前面的课才能练习；内置的《新概念英语》清单默认锁定。`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Help()
+	},
+}
+
+// courseStatusCmd 表示course status子命令
+var courseStatusCmd = &cobra.Command{
+	Use:   "status [resourceType] [folder]",
+	Short: "查看课程进度",
+	Long: `查看当前语言下各课程的学习进度，包括已完成的课数、当前课和通过标准。
+指定文件夹时列出该课程的每一课及最好成绩，例如：
+  mllt-cli course status
+  mllt-cli course status articles NCE-1`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		resourceTypes := []string{practice.Words, practice.Phrases, practice.Sentences, practice.Articles}
+		if len(args) > 0 {
+			if !manage.ValidateResourceType(args[0]) {
+				fmt.Printf("无效的资源类型: %s\n", args[0])
+				fmt.Println("有效的资源类型: words, phrases, sentences, articles")
+				return
+			}
+			resourceTypes = []string{args[0]}
+		}
+
+		found := false
+		for _, resourceType := range resourceTypes {
+			courses, err := course.LoadAll(resourceType)
+			if err != nil {
+				fmt.Println("读取课程失败:", err)
+				return
+			}
+			for _, c := range courses {
+				if len(args) > 1 && args[1] != c.Folder && args[1] != practice.FolderDisplayName(c.Folder) {
+					continue
+				}
+				found = true
+				printCourseStatus(c, len(args) > 1)
+			}
+		}
+		if !found {
+			if len(args) > 1 {
+				fmt.Printf("文件夹 %s 不是课程（缺少 %s）\n", args[1], course.ManifestFile)
+			} else {
+				fmt.Println("当前语言下没有课程")
+			}
+		}
+	},
+}
+
+// printCourseStatus 输出课程进度，detail 为 true 时列出每一课
+func printCourseStatus(c *course.Course, detail bool) {
+	fmt.Printf("%s（%s/%s）: 已完成 %d/%d 课\n", c.Title, c.ResourceType, c.Folder, c.CompletedCount(), len(c.Lessons))
+	if current := c.Current(); current != nil {
+		fmt.Printf("  当前课: %s\n", current.Name)
+	} else if len(c.Lessons) > 0 {
+		fmt.Println("  课程已全部完成")
+	}
+	fmt.Printf("  通过标准: %s\n", c.Pass)
+	if !detail {
+		return
+	}
+
+	marks := map[string]string{
+		course.LessonCompleted: "✔",
+		course.LessonCurrent:   "▶",
+		course.LessonPending:   "·",
+		course.LessonLocked:    "🔒",
+	}
+	for i, lesson := range c.Lessons {
+		line := fmt.Sprintf("  %s %3d. %s", marks[lesson.Status], i+1, lesson.Name)
+		if lesson.Result.Attempts > 0 {
+			line += fmt.Sprintf("  最高准确率 %.1f%%", lesson.Result.BestAccuracy)
+			if lesson.Result.BestWPM > 0 {
+				line += fmt.Sprintf(" · 最高速度 %.1f WPM", lesson.Result.BestWPM)
+			}
+			line += fmt.Sprintf(" · 完成 %d 次", lesson.Result.Attempts)
+		}
+		fmt.Println(line)
+	}
+}
+
 // manageCmd 表示manage子命令
 var manageCmd = &cobra.Command{
 	Use:   "manage",
@@ -233,8 +599,9 @@ var manageCmd = &cobra.Command{
 var manageDeleteCmd = &cobra.Command{
 	Use:   "delete [resourceType] [file]",
 	Short: "删除资源",
-	Long:  `删除资源，例如：mllt-cli manage delete words daily.txt。`,
-	Args:  cobra.RangeArgs(1, 2),
+	Long: `删除资源，例如：mllt-cli manage delete words daily.txt。
+使用 --folder 指定资源所在的文件夹，--yes 跳过确认，便于在脚本中使用。`,
+	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		// 获取资源类型
 		resourceType := args[0]
@@ -267,38 +634,294 @@ var manageDeleteCmd = &cobra.Command{
 		}
 
 		// 指定了文件，删除资源
-		fileName := args[1]
-		if err := manage.DeleteResource(resourceType, fileName); err != nil {
+		identifier := args[1]
+		if manageFolder != "" {
+			identifier = practice.BuildResourceIdentifier(manageFolder, identifier)
+		}
+		deleted, err := manage.DeleteResource(resourceType, identifier, manageConfirmer())
+		if err != nil {
 			fmt.Printf("删除%s文件失败: %s\n", resourceType, err)
+			os.Exit(1)
+		}
+		if !deleted {
+			fmt.Println("取消删除。")
+			return
 		}
+		fmt.Printf("成功删除 %s\n", practice.FormatResourceDisplayName(identifier))
 	},
 }
 
+// manage import 的导入格式和列映射
+var manageImportOptions manage.ImportOptions
+
+// manage import/delete 的公共参数
+var (
+	manageFolder    string
+	manageYes       bool
+	manageOverwrite bool
+)
+
+// manageConfirmer 返回导入、删除前的确认方式，--yes 时不再询问
+func manageConfirmer() manage.Confirmer {
+	if manageYes {
+		return nil
+	}
+	return manage.ConfirmFromStdin
+}
+
 // manageImportCmd 表示manage import子命令
 var manageImportCmd = &cobra.Command{
-	Use:   "import [resourceType] [file]",
+	Use:   "import [resourceType] [file|dir]",
 	Short: "导入资源",
-	Long:  `导入资源，例如：mllt-cli manage import words /path/to/words.txt。`,
-	Args:  cobra.ExactArgs(2),
+	Long: `导入资源，例如：mllt-cli manage import words /path/to/words.txt。
+除 .txt 资源文件外，还支持 CSV/TSV 表格（如 Google Sheets 导出的文件）和 Anki 导出的纯文本笔记，
+导入时统一转换为" ->> "格式，例如：
+  mllt-cli manage import words vocab.csv --header --term-column 2 --translation-column 3
+  mllt-cli manage import words deck.txt --format anki
+导入目录时会包含所有子目录，子目录中的文件导入到同名的资源文件夹，例如：
+  mllt-cli manage import words ./course --folder course --conflict merge --yes
+合并（--conflict merge，仅单词和短语）按原文去重，原文相同但翻译不同时默认保留本地翻译，
+可用 --merge-policy incoming 使用导入的翻译，或 --merge-policy combine 将两者合并。`,
+	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		// 获取资源类型和文件路径
 		resourceType := args[0]
-		filePath := args[1]
+		sourcePath := args[1]
 
 		// 验证资源类型
 		if !manage.ValidateResourceType(resourceType) {
 			fmt.Printf("无效的资源类型: %s\n", resourceType)
 			fmt.Println("有效的资源类型: words, phrases, sentences, articles")
-			return
+			os.Exit(1)
+		}
+
+		options := manageImportOptions
+		options.Confirm = manageConfirmer()
+		if manageOverwrite {
+			if options.Conflict != "" && options.Conflict != manage.ConflictOverwrite {
+				fmt.Println("--overwrite 不能与其他 --conflict 同时使用")
+				os.Exit(1)
+			}
+			options.Conflict = manage.ConflictOverwrite
 		}
 
-		// 导入资源，默认导入到“默认”文件夹
-		if err := manage.ImportResource(resourceType, practice.DefaultFolderDir, filePath); err != nil {
+		// 未指定文件夹时导入到“默认”文件夹
+		folder := practice.DefaultFolderDir
+		if manageFolder != "" {
+			folder, _ = practice.NormalizeFolderName(manageFolder)
+		}
+
+		var results []manage.ImportResult
+		var err error
+		if info, statErr := os.Stat(sourcePath); statErr == nil && info.IsDir() {
+			results, err = manage.ImportDirectory(resourceType, folder, sourcePath, options)
+		} else {
+			var result *manage.ImportResult
+			if result, err = manage.ImportResource(resourceType, folder, sourcePath, options); result != nil {
+				results = append(results, *result)
+			}
+		}
+		for _, result := range results {
+			printImportResult(resourceType, result)
+		}
+		if err != nil {
 			fmt.Printf("导入%s文件失败: %s\n", resourceType, err)
+			os.Exit(1)
+		}
+	},
+}
+
+// printImportResult 输出一个文件的导入结果
+func printImportResult(resourceType string, result manage.ImportResult) {
+	target := resourceType + "/" + result.DisplayName()
+	source := filepath.Base(result.Source)
+	switch result.Action {
+	case manage.ImportSkipped:
+		fmt.Printf("跳过 %s：%s 已存在\n", source, target)
+	case manage.ImportCancelled:
+		fmt.Printf("取消导入 %s。\n", source)
+	case manage.ImportMerged:
+		fmt.Printf("已合并 %s 到 %s：新增 %d 条，更新 %d 条，未变 %d 条，冲突 %d 条\n",
+			source, target, result.Added, result.Updated, result.Unchanged, len(result.Conflicts))
+		for _, conflict := range result.Conflicts {
+			fmt.Printf("  %s（%s）\n    本地: %s\n    导入: %s\n",
+				conflict.Term, manage.MergePolicyNames[conflict.Resolution], conflict.Local, conflict.Incoming)
+		}
+	default:
+		message := fmt.Sprintf("成功导入 %s 到 %s", source, target)
+		if result.Action == manage.ImportOverwritten {
+			message += "（已覆盖）"
+		}
+		if result.Entries >= 0 {
+			message += fmt.Sprintf("（%s，共 %d 条）", strings.ToUpper(result.Format), result.Entries)
+		}
+		fmt.Println(message)
+	}
+}
+
+// manage export / import-bundle 的参数
+var (
+	manageExportLanguage  string
+	manageExportType      string
+	manageExportOutput    string
+	manageBundleLanguage  string
+	manageBundleOverwrite bool
+)
+
+// manageExportCmd 表示manage export子命令
+var manageExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "导出学习数据包",
+	Long: `将用户导入的资源、收藏和标记列表、复习计划、错题记录和练习统计打包为 zip，
+便于迁移到新设备或分享整理好的课程，例如：mllt-cli manage export --lang english --type words -o bundle.zip。`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		language := manageExportLanguage
+		if language == "" {
+			language = config.AppConfig.CurrentLanguage
+		}
+		var resourceTypes []string
+		if manageExportType != "" {
+			resourceTypes = []string{manageExportType}
+		}
+
+		output := manageExportOutput
+		if output == "" {
+			output = fmt.Sprintf("mllt-%s-%s.zip", language, time.Now().Format("20060102"))
+		}
+		file, err := os.Create(output)
+		if err != nil {
+			fmt.Printf("创建数据包失败: %s\n", err)
+			os.Exit(1)
+		}
+
+		manifest, err := manage.ExportBundle(file, language, resourceTypes)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(output)
+			fmt.Printf("导出数据包失败: %s\n", err)
+			os.Exit(1)
 		}
+		fmt.Printf("已导出 %d 个文件到 %s\n", len(manifest.Files), output)
 	},
 }
 
+// manageImportBundleCmd 表示manage import-bundle子命令
+var manageImportBundleCmd = &cobra.Command{
+	Use:   "import-bundle [file]",
+	Short: "恢复学习数据包",
+	Long: `恢复 mllt-cli manage export 导出的数据包，例如：mllt-cli manage import-bundle bundle.zip。
+已存在的资源、复习计划和错题记录默认跳过（--overwrite 覆盖），收藏和标记列表与本地合并，练习统计去重合并。`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		result, err := manage.ImportBundle(args[0], manage.BundleImportOptions{
+			Language:  manageBundleLanguage,
+			Overwrite: manageBundleOverwrite,
+		})
+		if err != nil {
+			fmt.Printf("恢复数据包失败: %s\n", err)
+			if result == nil {
+				os.Exit(1)
+			}
+		}
+		fmt.Printf("已恢复到语言 %s：写入 %d 个文件，合并 %d 个列表，跳过 %d 个已存在的文件；新增 %d 条练习记录，跳过 %d 条重复记录\n",
+			result.Language, result.Restored, result.Merged, result.Skipped, result.SessionsAdded, result.SessionsSkipped)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+// manage lint 的参数
+var (
+	manageLintFormat string
+	manageLintIgnore []string
+)
+
+// manageLintCmd 表示manage lint子命令
+var manageLintCmd = &cobra.Command{
+	Use:   "lint [resourceType] [file|dir]",
+	Short: "检查资源文件",
+	Long: `按练习时相同的解析规则检查资源文件，报告以下问题：
+  space-split        按空格拆分时把多个单词的原文拆开了
+  duplicate          文件中原文重复
+  empty-translation  条目没有翻译
+  mixed-separators   同一文件混用多种分隔符
+  format-switch      换行分隔格式解析到一半改为逐行解析
+  copy-name          文件名像是误复制的副本
+  duplicate-file     与另一个资源文件内容完全相同
+  header             文件头无效或内容不符合声明的格式
+不指定资源类型时检查当前语言的所有资源；第二个参数可以是资源名称，也可以是磁盘上的文件或目录，例如：
+  mllt-cli manage lint words ./course --format json
+  mllt-cli manage lint articles --ignore space-split,mixed-separators
+发现问题时以非零状态退出，便于在 CI 中检查共享的课程仓库。`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if manageLintFormat != "text" && manageLintFormat != "json" {
+			fmt.Fprintf(os.Stderr, "无效的输出格式: %s（可选: text, json）\n", manageLintFormat)
+			os.Exit(2)
+		}
+
+		var resourceType, target string
+		if len(args) > 0 {
+			resourceType = args[0]
+		}
+		if len(args) > 1 {
+			target = args[1]
+		}
+
+		var report *manage.LintReport
+		var err error
+		if target != "" && !isResourceIdentifier(resourceType, target) {
+			report, err = manage.LintPath(resourceType, target)
+		} else {
+			report, err = manage.LintResources(resourceType, target)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "检查资源失败: %s\n", err)
+			os.Exit(2)
+		}
+		if err := report.Ignore(manageLintIgnore); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+
+		if manageLintFormat == "json" {
+			if report.Issues == nil {
+				report.Issues = []manage.LintIssue{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			encoder.SetEscapeHTML(false)
+			encoder.Encode(report)
+		} else {
+			for _, issue := range report.Issues {
+				fmt.Println(issue)
+			}
+			fmt.Fprintf(os.Stderr, "共检查 %d 个文件，发现 %d 个问题\n", report.Files, len(report.Issues))
+		}
+		if len(report.Issues) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// isResourceIdentifier 判断参数是否为当前语言下已有的资源名称
+func isResourceIdentifier(resourceType, identifier string) bool {
+	identifiers, err := manage.GetResourceFiles(resourceType)
+	if err != nil {
+		return false
+	}
+	for _, existing := range identifiers {
+		if existing == identifier {
+			return true
+		}
+	}
+	return false
+}
+
 // settingCmd 表示setting子命令
 var settingCmd = &cobra.Command{
 	Use:   "setting",
@@ -470,6 +1093,330 @@ var settingTranslationCmd = &cobra.Command{
 	ValidArgs: []string{"show", "hide"},
 }
 
+// settingModeCmd 表示setting mode子命令
+var settingModeCmd = &cobra.Command{
+	Use:   "mode [copy|dictation|cloze]",
+	Short: "设置练习模式",
+	Long: `设置练习模式，可选值：copy（抄写，显示原文）、dictation（默写，只显示翻译或提示）、
+cloze（填空，挖去句子和文章中的单词，只输入空缺的单词；单词和短语练习仍按抄写进行）。
+填空时挖空的单词来源和数量可用 mllt-cli setting cloze 设置。
+练习时也可以输入"> mode"临时切换本次练习的模式。`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			// 显示当前练习模式
+			mode := "copy"
+			if config.AppConfig.PracticeMode == "dictation" || config.AppConfig.PracticeMode == "cloze" {
+				mode = config.AppConfig.PracticeMode
+			}
+			fmt.Printf("当前练习模式: %s\n", mode)
+			fmt.Println("可用的模式:")
+			fmt.Println("  copy      - 抄写，显示原文")
+			fmt.Println("  dictation - 默写，只显示翻译或提示")
+			fmt.Println("  cloze     - 填空，只输入句子中挖空的单词")
+			return
+		}
+
+		mode := args[0]
+		if mode != "copy" && mode != "dictation" && mode != "cloze" {
+			fmt.Printf("无效的模式: %s\n", mode)
+			fmt.Println("可用的模式: copy, dictation, cloze")
+			return
+		}
+
+		config.AppConfig.PracticeMode = mode
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		fmt.Printf("练习模式已设置为: %s\n", mode)
+	},
+	ValidArgs: []string{"copy", "dictation", "cloze"},
+}
+
+// settingClozeCmd 表示setting cloze子命令
+var settingClozeCmd = &cobra.Command{
+	Use:   "cloze [random|word_lists] [blanks]",
+	Short: "设置填空练习",
+	Long: `设置填空模式下挖空的单词：
+  random      随机选择句子中的单词，优先选择较长的单词
+  word_lists  优先选择单词资源中出现的单词，在语境中巩固词汇；句子中没有时随机选择
+第二个参数为每行挖空的单词数（1-10，默认 1），例如：
+  mllt-cli setting cloze word_lists 2`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		cloze := config.AppConfig.Cloze
+		if len(args) == 0 {
+			fmt.Printf("挖空单词来源: %s\n", cloze.BlankSource())
+			fmt.Printf("每行挖空数: %d\n", cloze.BlankCount())
+			fmt.Println("可用的来源: random, word_lists")
+			return
+		}
+
+		source := args[0]
+		if source != config.ClozeSourceRandom && source != config.ClozeSourceWordLists {
+			fmt.Printf("无效的来源: %s\n", source)
+			fmt.Println("可用的来源: random, word_lists")
+			return
+		}
+		cloze.Source = source
+		if len(args) > 1 {
+			blanks, err := strconv.Atoi(args[1])
+			if err != nil || blanks < 1 || blanks > config.MaxClozeBlanks {
+				fmt.Printf("挖空数必须为 1 到 %d 之间的整数: %s\n", config.MaxClozeBlanks, args[1])
+				return
+			}
+			cloze.Blanks = blanks
+		}
+
+		config.AppConfig.Cloze = cloze
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		fmt.Printf("填空练习已设置为: %s，每行挖空 %d 个单词\n", cloze.BlankSource(), cloze.BlankCount())
+	},
+	ValidArgs: []string{config.ClozeSourceRandom, config.ClozeSourceWordLists},
+}
+
+// settingDirectionCmd 表示setting direction子命令
+var settingDirectionCmd = &cobra.Command{
+	Use:   "direction [forward|reverse]",
+	Short: "设置练习方向",
+	Long: `设置单词、短语、句子的练习方向，可选值：forward（看原文输入原文）、reverse（看原文输入翻译）。
+反向练习时，翻译中用"；"或"/"分隔的任一释义都算正确。也可以在练习命令后加 --reverse 临时反向练习。`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			// 显示当前练习方向
+			fmt.Printf("当前练习方向: %s\n", practice.NormalizeDirection(config.AppConfig.PracticeDirection))
+			fmt.Println("可用的方向:")
+			fmt.Println("  forward - 看原文输入原文")
+			fmt.Println("  reverse - 看原文输入翻译")
+			return
+		}
+
+		direction := args[0]
+		if direction != practice.DirectionForward && direction != practice.DirectionReverse {
+			fmt.Printf("无效的方向: %s\n", direction)
+			fmt.Println("可用的方向: forward, reverse")
+			return
+		}
+
+		config.AppConfig.PracticeDirection = direction
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		fmt.Printf("练习方向已设置为: %s\n", direction)
+	},
+	ValidArgs: []string{practice.DirectionForward, practice.DirectionReverse},
+}
+
+// settingArticleLayoutCmd 表示setting article-layout子命令
+var settingArticleLayoutCmd = &cobra.Command{
+	Use:   "article-layout [flow|lines]",
+	Short: "设置文章练习方式",
+	Long: `设置文章的练习方式，可选值：flow（连续输入整篇文章）、lines（逐行输入）。
+连续输入时显示整篇文章，光标逐字前进，输错的字符就地标出且不阻塞输入，可以退格修改；
+行末按空格或 Enter 进入下一行。逐行输入时每行作为一个练习项目，输错后重新输入整行。`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			// 显示当前文章练习方式
+			fmt.Printf("当前文章练习方式: %s\n", config.AppConfig.Articles.ArticleLayout())
+			fmt.Println("可用的练习方式:")
+			fmt.Println("  flow  - 连续输入整篇文章")
+			fmt.Println("  lines - 逐行输入")
+			return
+		}
+
+		layout := args[0]
+		if layout != config.ArticleLayoutFlow && layout != config.ArticleLayoutLines {
+			fmt.Printf("无效的练习方式: %s\n", layout)
+			fmt.Println("可用的练习方式: flow, lines")
+			return
+		}
+
+		config.AppConfig.Articles.Layout = layout
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		fmt.Printf("文章练习方式已设置为: %s\n", layout)
+	},
+	ValidArgs: []string{config.ArticleLayoutFlow, config.ArticleLayoutLines},
+}
+
+// settingProfileCmd 表示setting profile子命令
+var settingProfileCmd = &cobra.Command{
+	Use:   "profile [language|type] [key] [value]",
+	Short: "按语言或资源类型设置练习选项",
+	Long: `按语言或资源类型覆盖全局的练习设置，优先级：资源类型 > 语言 > 全局。
+可设置的项：correctness_match_mode、next_one_order、show_translation，
+按语言还可以设置 ime_hint（练习时显示的输入法提示）、kana_input（日语罗马字转假名输入）
+和 accept_reading（条目写成"食べる（たべる）"时接受只输入汉字写法或假名读音）。
+值为 inherit 表示清除该项，沿用上一级设置。例如：
+  mllt-cli setting profile                                         查看当前语言下各资源类型生效的设置
+  mllt-cli setting profile japanese correctness_match_mode word_match
+  mllt-cli setting profile japanese ime_hint "切换到日语输入法（罗马字）"
+  mllt-cli setting profile japanese kana_input true
+  mllt-cli setting profile sentences show_translation true
+  mllt-cli setting profile sentences show_translation inherit`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 && len(args) != 3 {
+			return fmt.Errorf("需要 0 个或 3 个参数，实际 %d 个", len(args))
+		}
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			printProfileSettings()
+			return
+		}
+
+		if err := config.AppConfig.SetOverride(args[0], args[1], args[2]); err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		fmt.Printf("已设置 %s 的 %s: %s\n", args[0], args[1], args[2])
+	},
+}
+
+// printProfileSettings 输出当前语言下各资源类型实际生效的设置及来源
+func printProfileSettings() {
+	sourceTitles := map[string]string{
+		config.SourceGlobal:   "全局",
+		config.SourceLanguage: "语言",
+		config.SourceType:     "类型",
+	}
+
+	fmt.Printf("当前语言: %s\n", config.AppConfig.CurrentLanguage)
+	if hint := config.AppConfig.CurrentProfile().IMEHint; hint != "" {
+		fmt.Printf("输入法提示: %s\n", hint)
+	}
+	for _, resourceType := range []string{practice.Words, practice.Phrases, practice.Sentences, practice.Articles} {
+		fmt.Printf("%s:\n", resourceType)
+		for _, key := range config.OverrideKeys {
+			setting := config.AppConfig.Resolve(resourceType, key)
+			fmt.Printf("  %-24s %s（%s）\n", key, setting.Value, sourceTitles[setting.Source])
+		}
+	}
+}
+
+// settingGoalCmd 表示setting goal子命令
+var settingGoalCmd = &cobra.Command{
+	Use:   "goal [items|minutes|off] [value]",
+	Short: "设置每日目标",
+	Long: `设置每日练习目标，例如：
+  mllt-cli setting goal items 200    每天答对 200 项
+  mllt-cli setting goal minutes 20   每天练习 20 分钟
+  mllt-cli setting goal off          清除每日目标
+值为 0 表示不设该项目标。`,
+	Args: cobra.MaximumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		goal := &config.AppConfig.DailyGoal
+		if len(args) == 0 {
+			if !goal.Enabled() {
+				fmt.Println("当前未设置每日目标")
+			} else {
+				fmt.Printf("当前每日目标: %d 项, %d 分钟（0 表示不设该项目标）\n", goal.Items, goal.Minutes)
+			}
+			fmt.Println("可用的设置:")
+			fmt.Println("  items <数量>   - 每天答对的项目数")
+			fmt.Println("  minutes <分钟> - 每天的练习分钟数")
+			fmt.Println("  off            - 清除每日目标")
+			return
+		}
+
+		switch args[0] {
+		case "off":
+			goal.Items = 0
+			goal.Minutes = 0
+		case "items", "minutes":
+			if len(args) < 2 {
+				fmt.Printf("请指定%s目标的数值\n", args[0])
+				return
+			}
+			value, err := strconv.Atoi(args[1])
+			if err != nil || value < 0 {
+				fmt.Printf("无效的目标数值: %s\n", args[1])
+				return
+			}
+			if args[0] == "items" {
+				goal.Items = value
+			} else {
+				goal.Minutes = value
+			}
+		default:
+			fmt.Printf("无效的设置: %s\n", args[0])
+			fmt.Println("可用的设置: items, minutes, off")
+			return
+		}
+
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		if !goal.Enabled() {
+			fmt.Println("已清除每日目标")
+			return
+		}
+		fmt.Printf("每日目标已设置为: %d 项, %d 分钟\n", goal.Items, goal.Minutes)
+	},
+	ValidArgs: []string{"items", "minutes", "off"},
+}
+
+// settingGradePromptCmd 表示setting grade-prompt子命令
+var settingGradePromptCmd = &cobra.Command{
+	Use:   "grade-prompt [enable|disable]",
+	Short: "设置复习评分提示",
+	Long:  `设置艾宾浩斯模式下答对后是否提示手动评分（重来/困难/良好/简单），禁用时根据错误次数和输入用时自动评分。`,
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			status := "禁用"
+			if config.AppConfig.SRSGradePrompt {
+				status = "启用"
+			}
+			fmt.Printf("当前复习评分提示设置: %s\n", status)
+			fmt.Println("可用的设置:")
+			fmt.Println("  enable  - 启用，答对后按 1~4 选择评分")
+			fmt.Println("  disable - 禁用，自动评分")
+			return
+		}
+
+		setting := args[0]
+		var enable bool
+		switch setting {
+		case "enable":
+			enable = true
+		case "disable":
+			enable = false
+		default:
+			fmt.Printf("无效的设置: %s\n", setting)
+			fmt.Println("可用的设置: enable, disable")
+			return
+		}
+
+		config.AppConfig.SRSGradePrompt = enable
+		if err := config.SaveConfig(); err != nil {
+			fmt.Printf("保存配置失败: %s\n", err)
+			return
+		}
+		status := "禁用"
+		if enable {
+			status = "启用"
+		}
+		fmt.Printf("复习评分提示已设置为: %s\n", status)
+	},
+	ValidArgs: []string{"enable", "disable"},
+}
+
 func init() {
 	// 确保默认资源与配置已初始化
 	if err := mlltcli.EnsureAssets(); err != nil {
@@ -485,8 +1432,11 @@ func init() {
 	// 添加子命令到根命令
 	rootCmd.AddCommand(langCmd)
 	rootCmd.AddCommand(practiceCmd)
+	rootCmd.AddCommand(reviewCmd)
 	rootCmd.AddCommand(manageCmd)
 	rootCmd.AddCommand(settingCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(courseCmd)
 
 	// 添加lang子命令
 	langCmd.AddCommand(langLsCmd)
@@ -497,16 +1447,65 @@ func init() {
 	practiceCmd.AddCommand(practicePhrasesCmd)
 	practiceCmd.AddCommand(practiceSentencesCmd)
 	practiceCmd.AddCommand(practiceArticlesCmd)
+	practiceCmd.AddCommand(practiceChoiceCmd)
+	for _, cmd := range []*cobra.Command{practiceWordsCmd, practicePhrasesCmd, practiceSentencesCmd} {
+		cmd.Flags().BoolVar(&practiceReverse, "reverse", false, "反向练习：看原文输入翻译（仅本次有效）")
+	}
+	for _, cmd := range []*cobra.Command{practiceWordsCmd, practicePhrasesCmd, practiceSentencesCmd, practiceArticlesCmd} {
+		cmd.Flags().BoolVar(&practiceResume, "resume", false, "在练习界面中从上次中途退出的位置继续")
+	}
 
 	// 添加manage子命令
 	manageCmd.AddCommand(manageDeleteCmd)
 	manageCmd.AddCommand(manageImportCmd)
+	manageCmd.AddCommand(manageExportCmd)
+	manageCmd.AddCommand(manageImportBundleCmd)
+	manageCmd.AddCommand(manageLintCmd)
+	manageLintCmd.Flags().StringSliceVar(&manageLintIgnore, "ignore", nil, "忽略的问题类型，多个用逗号分隔")
+	manageLintCmd.Flags().StringVar(&manageLintFormat, "format", "text", "输出格式：text（文件:行号: 类型: 说明）或 json")
+	manageExportCmd.Flags().StringVar(&manageExportLanguage, "lang", "", "导出的语言，默认为当前语言")
+	manageExportCmd.Flags().StringVar(&manageExportType, "type", "", "只导出一种资源类型：words、phrases、sentences 或 articles")
+	manageExportCmd.Flags().StringVarP(&manageExportOutput, "output", "o", "", "输出文件，默认为 mllt-<语言>-<日期>.zip")
+	manageImportBundleCmd.Flags().StringVar(&manageBundleLanguage, "lang", "", "恢复到的语言，默认为数据包中记录的语言")
+	manageImportBundleCmd.Flags().BoolVar(&manageBundleOverwrite, "overwrite", false, "覆盖已存在的资源、复习计划和错题记录")
+	manageImportCmd.Flags().StringVarP(&manageImportOptions.Format, "format", "f", "auto", "导入格式：auto、txt、csv、tsv 或 anki")
+	manageImportCmd.Flags().IntVar(&manageImportOptions.TermColumn, "term-column", 0, "原文所在的列（从 1 开始），默认第 1 列")
+	manageImportCmd.Flags().IntVar(&manageImportOptions.TranslationColumn, "translation-column", 0, "翻译所在的列（从 1 开始），默认第 2 列")
+	manageImportCmd.Flags().BoolVar(&manageImportOptions.HasHeader, "header", false, "CSV/TSV 的第一行是表头，导入时跳过")
+	manageImportCmd.Flags().StringVar(&manageImportOptions.Conflict, "conflict", "", "同名文件的处理方式：skip、overwrite、rename 或 merge，默认询问是否覆盖")
+	manageImportCmd.Flags().BoolVar(&manageOverwrite, "overwrite", false, "覆盖同名文件，等同于 --conflict overwrite")
+	manageImportCmd.Flags().StringVar(&manageImportOptions.MergePolicy, "merge-policy", "", "合并时翻译不同的处理方式：local、incoming 或 combine，默认保留本地翻译")
+	for _, cmd := range []*cobra.Command{manageImportCmd, manageDeleteCmd} {
+		cmd.Flags().StringVar(&manageFolder, "folder", "", "资源所在的文件夹，默认为“默认”文件夹")
+		cmd.Flags().BoolVarP(&manageYes, "yes", "y", false, "跳过确认，适用于脚本")
+	}
 
 	// 添加setting子命令
 	settingCmd.AddCommand(settingMatchModeCmd)
 	settingCmd.AddCommand(settingOrderCmd)
 	settingCmd.AddCommand(settingKeyboardSoundCmd)
 	settingCmd.AddCommand(settingTranslationCmd)
+	settingCmd.AddCommand(settingGradePromptCmd)
+	settingCmd.AddCommand(settingGoalCmd)
+	settingCmd.AddCommand(settingModeCmd)
+	settingCmd.AddCommand(settingClozeCmd)
+	settingCmd.AddCommand(settingDirectionCmd)
+	settingCmd.AddCommand(settingArticleLayoutCmd)
+	settingCmd.AddCommand(settingProfileCmd)
+
+	// 添加course子命令
+	courseCmd.AddCommand(courseStatusCmd)
+
+	// 添加stats子命令
+	statsCmd.AddCommand(statsExportCmd)
+	statsCmd.AddCommand(statsImportCmd)
+	statsCmd.AddCommand(statsStreakCmd)
+	statsCmd.AddCommand(statsChartCmd)
+	statsExportCmd.Flags().StringVarP(&statsExportFormat, "format", "f", "csv", "导出格式：csv 或 json")
+	statsExportCmd.Flags().StringVar(&statsExportFrom, "from", "", "开始日期（YYYY-MM-DD，含当天）")
+	statsExportCmd.Flags().StringVar(&statsExportTo, "to", "", "结束日期（YYYY-MM-DD，含当天）")
+	statsChartCmd.Flags().IntVarP(&statsChartDays, "days", "d", 30, "统计天数：30 或 90")
+	statsExportCmd.Flags().StringVarP(&statsExportOutput, "output", "o", "", "输出文件，默认输出到标准输出")
 }
 
 func main() {
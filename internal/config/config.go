@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -30,26 +31,115 @@ type Config struct {
 	InputKeyboardSound bool `mapstructure:"input_keyboard_sound"`
 	// v0.3 新增：全局是否显示翻译
 	ShowTranslation bool `mapstructure:"show_translation"`
+	// 艾宾浩斯模式下答对后是否提示手动评分（重来/困难/良好/简单）
+	SRSGradePrompt bool `mapstructure:"srs_grade_prompt"`
+	// 每日练习目标
+	DailyGoal DailyGoalConfig `mapstructure:"daily_goal"`
+	// 练习模式，可选值：copy（抄写，显示原文）、dictation（默写，只显示翻译或提示）、cloze（填空，只输入挖空的单词）
+	PracticeMode string `mapstructure:"practice_mode"`
+	// 填空练习配置
+	Cloze ClozeConfig `mapstructure:"cloze"`
+	// 练习方向，可选值：forward（看原文输入原文）、reverse（看原文输入翻译）
+	PracticeDirection string `mapstructure:"practice_direction"`
+	// 单词匹配模式下的文本规范化设置，按语言配置，未配置的语言使用内置默认值
+	Normalization map[string]NormalizationConfig `mapstructure:"normalization"`
+	// 按语言设置的练习配置，非空的字段优先于全局配置
+	LanguageProfiles map[string]LanguageProfile `mapstructure:"language_profiles"`
 }
 
-// WordsConfig 表示单词练习的配置
+// NormalizationConfig 表示某种语言在单词匹配模式下的文本规范化方式
+type NormalizationConfig struct {
+	// Unicode 规范化形式，可选值：nfc（保留全角/半角字符）、nfkc（默认，同时折叠全角/半角等兼容字符）
+	Form string `mapstructure:"form" yaml:"form"`
+	// 是否忽略变音符号，开启后 café 与 cafe 视为相同
+	FoldDiacritics bool `mapstructure:"fold_diacritics" yaml:"fold_diacritics"`
+	// 是否忽略空白，适用于日语、中文等不以空格分词的语言
+	IgnoreSpaces bool `mapstructure:"ignore_spaces" yaml:"ignore_spaces"`
+}
+
+// DailyGoalConfig 表示每日练习目标，0 表示不设该项目标
+type DailyGoalConfig struct {
+	// 每天答对的项目数
+	Items int `mapstructure:"items" yaml:"items"`
+	// 每天的练习分钟数
+	Minutes int `mapstructure:"minutes" yaml:"minutes"`
+}
+
+// Enabled 判断是否设置了任一每日目标
+func (g DailyGoalConfig) Enabled() bool {
+	return g.Items > 0 || g.Minutes > 0
+}
+
+// WordsConfig 表示单词练习的配置，非空的字段优先于语言配置和全局配置
 type WordsConfig struct {
-	// 单词练习配置（目前为空，预留扩展）
+	PracticeOverrides `mapstructure:",squash" yaml:",inline"`
 }
 
-// PhrasesConfig 表示短语练习的配置
+// PhrasesConfig 表示短语练习的配置，非空的字段优先于语言配置和全局配置
 type PhrasesConfig struct {
-	// 短语练习配置（目前为空，预留扩展）
+	PracticeOverrides `mapstructure:",squash" yaml:",inline"`
 }
 
-// SentencesConfig 表示句子练习的配置
+// SentencesConfig 表示句子练习的配置，非空的字段优先于语言配置和全局配置
 type SentencesConfig struct {
-	// 句子练习配置（目前为空，预留扩展）
+	PracticeOverrides `mapstructure:",squash" yaml:",inline"`
 }
 
-// ArticlesConfig 表示文章练习的配置
+// ArticlesConfig 表示文章练习的配置，非空的字段优先于语言配置和全局配置
 type ArticlesConfig struct {
-	// 文章练习配置（目前为空，预留扩展）
+	PracticeOverrides `mapstructure:",squash" yaml:",inline"`
+	// 练习方式：flow 连续输入整篇文章，lines 逐行输入；为空时使用 flow
+	Layout string `mapstructure:"layout" yaml:"layout,omitempty"`
+}
+
+// 文章练习方式
+const (
+	ArticleLayoutFlow  = "flow"  // 显示整篇文章，光标逐字前进，错误就地标出
+	ArticleLayoutLines = "lines" // 每行作为一个练习项目，输错后重新输入整行
+)
+
+// ArticleLayout 返回文章实际的练习方式，无效值按 flow 处理
+func (a ArticlesConfig) ArticleLayout() string {
+	if strings.ToLower(strings.TrimSpace(a.Layout)) == ArticleLayoutLines {
+		return ArticleLayoutLines
+	}
+	return ArticleLayoutFlow
+}
+
+// ClozeConfig 表示填空练习的配置
+type ClozeConfig struct {
+	// 挖空单词的来源：random 随机选择，word_lists 优先选择单词资源中出现的单词；为空时使用 random
+	Source string `mapstructure:"source" yaml:"source,omitempty"`
+	// 每行挖空的单词数，为 0 时挖 1 个
+	Blanks int `mapstructure:"blanks" yaml:"blanks,omitempty"`
+}
+
+// 挖空单词的来源
+const (
+	ClozeSourceRandom    = "random"     // 随机选择句子中的单词
+	ClozeSourceWordLists = "word_lists" // 优先选择单词资源中出现的单词，句子中没有时随机选择
+)
+
+// MaxClozeBlanks 每行最多挖空的单词数
+const MaxClozeBlanks = 10
+
+// BlankSource 返回实际的挖空单词来源，无效值按 random 处理
+func (c ClozeConfig) BlankSource() string {
+	if strings.ToLower(strings.TrimSpace(c.Source)) == ClozeSourceWordLists {
+		return ClozeSourceWordLists
+	}
+	return ClozeSourceRandom
+}
+
+// BlankCount 返回每行实际挖空的单词数，限制在 1 到 MaxClozeBlanks 之间
+func (c ClozeConfig) BlankCount() int {
+	if c.Blanks < 1 {
+		return 1
+	}
+	if c.Blanks > MaxClozeBlanks {
+		return MaxClozeBlanks
+	}
+	return c.Blanks
 }
 
 // 全局配置实例
@@ -128,6 +218,13 @@ func SaveConfig() error {
 		"next_one_order":          AppConfig.NextOneOrder,
 		"input_keyboard_sound":    AppConfig.InputKeyboardSound,
 		"show_translation":        AppConfig.ShowTranslation,
+		"srs_grade_prompt":        AppConfig.SRSGradePrompt,
+		"daily_goal":              AppConfig.DailyGoal,
+		"practice_direction":      AppConfig.PracticeDirection,
+		"practice_mode":           AppConfig.PracticeMode,
+		"cloze":                   AppConfig.Cloze,
+		"normalization":           AppConfig.Normalization,
+		"language_profiles":       AppConfig.LanguageProfiles,
 	} {
 		viper.Set(k, v)
 	}
@@ -0,0 +1,328 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 设置的来源，用于在设置界面中说明当前生效的值来自哪一层
+const (
+	SourceGlobal   = "global"   // 全局配置
+	SourceLanguage = "language" // 当前语言的配置
+	SourceType     = "type"     // 资源类型的配置
+)
+
+// PracticeOverrides 表示可以在语言或资源类型上覆盖全局配置的练习设置，
+// 字段为空（nil）表示沿用上一级的设置。优先级：资源类型 > 语言 > 全局。
+type PracticeOverrides struct {
+	// 正确性匹配模式：exact_match 或 word_match
+	CorrectnessMatchMode string `mapstructure:"correctness_match_mode" yaml:"correctness_match_mode,omitempty"`
+	// 练习顺序：random、sequential 或 ebbinghaus
+	NextOneOrder string `mapstructure:"next_one_order" yaml:"next_one_order,omitempty"`
+	// 是否显示翻译
+	ShowTranslation *bool `mapstructure:"show_translation" yaml:"show_translation,omitempty"`
+}
+
+// IsEmpty 判断是否没有任何覆盖设置
+func (o PracticeOverrides) IsEmpty() bool {
+	return o.CorrectnessMatchMode == "" && o.NextOneOrder == "" && o.ShowTranslation == nil
+}
+
+// LanguageProfile 表示某种语言的练习配置
+type LanguageProfile struct {
+	PracticeOverrides `mapstructure:",squash" yaml:",inline"`
+	// 输入法提示，练习时显示在输入框上方，例如"请切换到日语输入法（罗马字输入）"
+	IMEHint string `mapstructure:"ime_hint" yaml:"ime_hint,omitempty"`
+	// 是否将罗马字输入实时转换为假名，仅对日语生效
+	KanaInput bool `mapstructure:"kana_input" yaml:"kana_input,omitempty"`
+	// 条目写成"食べる（たべる）"时，是否接受只输入汉字写法或假名读音
+	AcceptReading bool `mapstructure:"accept_reading" yaml:"accept_reading,omitempty"`
+}
+
+// isEmpty 判断语言配置是否没有任何设置
+func (p LanguageProfile) isEmpty() bool {
+	return p.PracticeOverrides.IsEmpty() && p.IMEHint == "" && !p.KanaInput && !p.AcceptReading
+}
+
+// Setting 表示一项设置当前生效的值及其来源
+type Setting struct {
+	Value  string
+	Source string
+}
+
+// TypeOverrides 返回资源类型上的覆盖设置
+func (c *Config) TypeOverrides(resourceType string) PracticeOverrides {
+	switch resourceType {
+	case "words":
+		return c.Words.PracticeOverrides
+	case "phrases":
+		return c.Phrases.PracticeOverrides
+	case "sentences":
+		return c.Sentences.PracticeOverrides
+	case "articles":
+		return c.Articles.PracticeOverrides
+	}
+	return PracticeOverrides{}
+}
+
+// SetTypeOverrides 设置资源类型上的覆盖设置，未知的资源类型返回 false
+func (c *Config) SetTypeOverrides(resourceType string, overrides PracticeOverrides) bool {
+	switch resourceType {
+	case "words":
+		c.Words.PracticeOverrides = overrides
+	case "phrases":
+		c.Phrases.PracticeOverrides = overrides
+	case "sentences":
+		c.Sentences.PracticeOverrides = overrides
+	case "articles":
+		c.Articles.PracticeOverrides = overrides
+	default:
+		return false
+	}
+	return true
+}
+
+// CurrentProfile 返回当前语言的配置
+func (c *Config) CurrentProfile() LanguageProfile {
+	return c.LanguageProfiles[strings.ToLower(c.CurrentLanguage)]
+}
+
+// SetLanguageProfile 设置语言的配置，配置为空时从 language_profiles 中删除该语言
+func (c *Config) SetLanguageProfile(language string, profile LanguageProfile) {
+	language = strings.ToLower(language)
+	if profile.isEmpty() {
+		delete(c.LanguageProfiles, language)
+		return
+	}
+	if c.LanguageProfiles == nil {
+		c.LanguageProfiles = make(map[string]LanguageProfile)
+	}
+	c.LanguageProfiles[language] = profile
+}
+
+// resolve 按资源类型 > 当前语言 > 全局的顺序查找第一个设置了的值
+func (c *Config) resolve(resourceType string, pick func(PracticeOverrides) (string, bool), global string) Setting {
+	if value, ok := pick(c.TypeOverrides(resourceType)); ok {
+		return Setting{Value: value, Source: SourceType}
+	}
+	if value, ok := pick(c.CurrentProfile().PracticeOverrides); ok {
+		return Setting{Value: value, Source: SourceLanguage}
+	}
+	return Setting{Value: global, Source: SourceGlobal}
+}
+
+// ResolveMatchMode 返回资源类型实际生效的正确性匹配模式
+func (c *Config) ResolveMatchMode(resourceType string) Setting {
+	return c.resolve(resourceType, func(o PracticeOverrides) (string, bool) {
+		return o.CorrectnessMatchMode, o.CorrectnessMatchMode != ""
+	}, c.CorrectnessMatchMode)
+}
+
+// ResolveOrder 返回资源类型实际生效的练习顺序
+func (c *Config) ResolveOrder(resourceType string) Setting {
+	return c.resolve(resourceType, func(o PracticeOverrides) (string, bool) {
+		return o.NextOneOrder, o.NextOneOrder != ""
+	}, c.NextOneOrder)
+}
+
+// ResolveShowTranslation 返回资源类型实际生效的翻译显示设置，值为 "true" 或 "false"
+func (c *Config) ResolveShowTranslation(resourceType string) Setting {
+	return c.resolve(resourceType, func(o PracticeOverrides) (string, bool) {
+		if o.ShowTranslation == nil {
+			return "", false
+		}
+		return formatBool(*o.ShowTranslation), true
+	}, formatBool(c.ShowTranslation))
+}
+
+// MatchModeFor 返回资源类型实际生效的正确性匹配模式
+func (c *Config) MatchModeFor(resourceType string) string {
+	return c.ResolveMatchMode(resourceType).Value
+}
+
+// OrderFor 返回资源类型实际生效的练习顺序
+func (c *Config) OrderFor(resourceType string) string {
+	return c.ResolveOrder(resourceType).Value
+}
+
+// ShowTranslationFor 返回资源类型实际是否显示翻译
+func (c *Config) ShowTranslationFor(resourceType string) bool {
+	return c.ResolveShowTranslation(resourceType).Value == "true"
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "true"
+	}
+	return "false"
+}
+
+// Inherit 表示清除某项覆盖设置，沿用上一级的值
+const Inherit = "inherit"
+
+// 可以按语言或资源类型覆盖的设置项
+const (
+	KeyMatchMode       = "correctness_match_mode"
+	KeyOrder           = "next_one_order"
+	KeyShowTranslation = "show_translation"
+	KeyIMEHint         = "ime_hint"
+	KeyKanaInput       = "kana_input"
+	KeyAcceptReading   = "accept_reading"
+)
+
+// LanguageToggleKeys 只能按语言设置的开关项
+var LanguageToggleKeys = []string{KeyKanaInput, KeyAcceptReading}
+
+// OverrideKeys 可以按语言或资源类型覆盖的设置项，按显示顺序排列
+var OverrideKeys = []string{KeyMatchMode, KeyOrder, KeyShowTranslation}
+
+// OverrideChoices 返回设置项可选的值
+func OverrideChoices(key string) []string {
+	switch key {
+	case KeyMatchMode:
+		return []string{"exact_match", "word_match"}
+	case KeyOrder:
+		return []string{"random", "sequential", "ebbinghaus"}
+	case KeyShowTranslation:
+		return []string{"true", "false"}
+	}
+	return nil
+}
+
+// Get 返回设置项的覆盖值，未设置时返回空字符串
+func (o PracticeOverrides) Get(key string) string {
+	switch key {
+	case KeyMatchMode:
+		return o.CorrectnessMatchMode
+	case KeyOrder:
+		return o.NextOneOrder
+	case KeyShowTranslation:
+		if o.ShowTranslation != nil {
+			return formatBool(*o.ShowTranslation)
+		}
+	}
+	return ""
+}
+
+// Set 设置覆盖值，value 为 Inherit 或空字符串时清除该项
+func (o *PracticeOverrides) Set(key, value string) error {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == Inherit {
+		value = ""
+	}
+	switch key {
+	case KeyShowTranslation:
+		switch value {
+		case "true", "show":
+			enabled := true
+			o.ShowTranslation = &enabled
+		case "false", "hide":
+			enabled := false
+			o.ShowTranslation = &enabled
+		case "":
+			o.ShowTranslation = nil
+		default:
+			return fmt.Errorf("无效的值: %s，可选值: true, false, %s", value, Inherit)
+		}
+		return nil
+	case KeyMatchMode, KeyOrder:
+		if value != "" && !containsString(OverrideChoices(key), value) {
+			return fmt.Errorf("无效的值: %s，可选值: %s, %s", value, strings.Join(OverrideChoices(key), ", "), Inherit)
+		}
+		if key == KeyMatchMode {
+			o.CorrectnessMatchMode = value
+		} else {
+			o.NextOneOrder = value
+		}
+		return nil
+	}
+	return fmt.Errorf("未知的设置项: %s", key)
+}
+
+// Resolve 返回资源类型上某个设置项实际生效的值及来源
+func (c *Config) Resolve(resourceType, key string) Setting {
+	switch key {
+	case KeyMatchMode:
+		return c.ResolveMatchMode(resourceType)
+	case KeyOrder:
+		return c.ResolveOrder(resourceType)
+	case KeyShowTranslation:
+		return c.ResolveShowTranslation(resourceType)
+	}
+	return Setting{}
+}
+
+// IsResourceType 判断作用范围是否为资源类型
+func IsResourceType(scope string) bool {
+	switch scope {
+	case "words", "phrases", "sentences", "articles":
+		return true
+	}
+	return false
+}
+
+// SetOverride 在语言或资源类型上设置一项覆盖值。
+// scope 为资源类型（words/phrases/sentences/articles）或 languages 中的语言；
+// ime_hint 只能按语言设置。
+func (c *Config) SetOverride(scope, key, value string) error {
+	scope = strings.ToLower(strings.TrimSpace(scope))
+	if IsResourceType(scope) {
+		if key == KeyIMEHint || containsString(LanguageToggleKeys, key) {
+			return fmt.Errorf("%s 只能按语言设置", key)
+		}
+		overrides := c.TypeOverrides(scope)
+		if err := overrides.Set(key, value); err != nil {
+			return err
+		}
+		c.SetTypeOverrides(scope, overrides)
+		return nil
+	}
+
+	if !containsString(c.Languages, scope) {
+		return fmt.Errorf("未知的语言或资源类型: %s", scope)
+	}
+	profile := c.LanguageProfiles[scope]
+	switch key {
+	case KeyIMEHint:
+		profile.IMEHint = strings.TrimSpace(value)
+		if profile.IMEHint == Inherit {
+			profile.IMEHint = ""
+		}
+	case KeyKanaInput, KeyAcceptReading:
+		enabled, err := parseToggle(value)
+		if err != nil {
+			return err
+		}
+		if key == KeyKanaInput {
+			profile.KanaInput = enabled
+		} else {
+			profile.AcceptReading = enabled
+		}
+	default:
+		if err := profile.Set(key, value); err != nil {
+			return err
+		}
+	}
+	c.SetLanguageProfile(scope, profile)
+	return nil
+}
+
+// parseToggle 解析开关值，inherit 视为关闭
+func parseToggle(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "on", "enable":
+		return true, nil
+	case "false", "off", "disable", Inherit:
+		return false, nil
+	}
+	return false, fmt.Errorf("无效的值: %s，可选值: true, false", value)
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
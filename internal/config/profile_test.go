@@ -0,0 +1,99 @@
+package config
+
+import "testing"
+
+// 测试设置的优先级：资源类型 > 语言 > 全局
+func TestResolvePrecedence(t *testing.T) {
+	cfg := &Config{
+		Languages:            []string{"english", "japanese"},
+		CurrentLanguage:      "japanese",
+		CorrectnessMatchMode: "exact_match",
+		NextOneOrder:         "random",
+		ShowTranslation:      false,
+	}
+
+	if got := cfg.ResolveMatchMode("words"); got.Value != "exact_match" || got.Source != SourceGlobal {
+		t.Errorf("未覆盖时应使用全局设置，实际 %+v", got)
+	}
+
+	if err := cfg.SetOverride("japanese", KeyMatchMode, "word_match"); err != nil {
+		t.Fatalf("设置语言配置失败: %v", err)
+	}
+	if err := cfg.SetOverride("japanese", KeyShowTranslation, "true"); err != nil {
+		t.Fatalf("设置语言配置失败: %v", err)
+	}
+	if got := cfg.ResolveMatchMode("words"); got.Value != "word_match" || got.Source != SourceLanguage {
+		t.Errorf("应使用语言设置，实际 %+v", got)
+	}
+
+	if err := cfg.SetOverride("words", KeyMatchMode, "exact_match"); err != nil {
+		t.Fatalf("设置类型配置失败: %v", err)
+	}
+	if got := cfg.ResolveMatchMode("words"); got.Value != "exact_match" || got.Source != SourceType {
+		t.Errorf("应使用资源类型设置，实际 %+v", got)
+	}
+	if got := cfg.ResolveMatchMode("phrases"); got.Source != SourceLanguage {
+		t.Errorf("其他资源类型应沿用语言设置，实际 %+v", got)
+	}
+	if !cfg.ShowTranslationFor("sentences") {
+		t.Error("语言设置为显示翻译时应显示翻译")
+	}
+
+	// 切换语言后不再使用日语的设置
+	cfg.CurrentLanguage = "english"
+	if got := cfg.ResolveMatchMode("phrases"); got.Source != SourceGlobal {
+		t.Errorf("切换语言后应回到全局设置，实际 %+v", got)
+	}
+
+	// inherit 清除覆盖设置，语言配置为空时从列表中删除
+	cfg.SetOverride("words", KeyMatchMode, Inherit)
+	cfg.SetOverride("japanese", KeyMatchMode, Inherit)
+	cfg.SetOverride("japanese", KeyShowTranslation, Inherit)
+	if !cfg.Words.IsEmpty() {
+		t.Error("inherit 应清除资源类型设置")
+	}
+	if _, ok := cfg.LanguageProfiles["japanese"]; ok {
+		t.Error("语言配置为空时应删除")
+	}
+}
+
+// 测试无效的作用范围、设置项和取值
+func TestSetOverrideValidation(t *testing.T) {
+	cfg := &Config{Languages: []string{"english"}}
+
+	if err := cfg.SetOverride("french", KeyOrder, "random"); err == nil {
+		t.Error("未知语言应返回错误")
+	}
+	if err := cfg.SetOverride("words", KeyOrder, "backwards"); err == nil {
+		t.Error("无效的取值应返回错误")
+	}
+	if err := cfg.SetOverride("words", KeyIMEHint, "romaji"); err == nil {
+		t.Error("资源类型不能设置输入法提示")
+	}
+	if err := cfg.SetOverride("english", "font", "mono"); err == nil {
+		t.Error("未知设置项应返回错误")
+	}
+}
+
+// 测试只能按语言设置的开关项
+func TestSetLanguageToggle(t *testing.T) {
+	cfg := &Config{Languages: []string{"japanese"}, CurrentLanguage: "japanese"}
+
+	if err := cfg.SetOverride("japanese", KeyKanaInput, "true"); err != nil {
+		t.Fatalf("设置假名输入失败: %v", err)
+	}
+	if !cfg.CurrentProfile().KanaInput {
+		t.Error("应开启假名输入")
+	}
+	if err := cfg.SetOverride("words", KeyAcceptReading, "true"); err == nil {
+		t.Error("资源类型不能设置 accept_reading")
+	}
+	if err := cfg.SetOverride("japanese", KeyKanaInput, "maybe"); err == nil {
+		t.Error("无效的开关值应返回错误")
+	}
+
+	cfg.SetOverride("japanese", KeyKanaInput, "false")
+	if _, ok := cfg.LanguageProfiles["japanese"]; ok {
+		t.Error("所有开关关闭后应删除语言配置")
+	}
+}
@@ -0,0 +1,323 @@
+package course
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// ManifestFile 资源文件夹中的课程清单文件名，文件夹中有该文件时按课程顺序练习
+const ManifestFile = "course.json"
+
+// 默认的通过标准：按键准确率不低于 90%，不限速度
+const defaultPassAccuracy = 90
+
+// 课程中每一课的状态
+const (
+	LessonCompleted = "completed" // 已通过
+	LessonCurrent   = "current"   // 当前要学的一课
+	LessonPending   = "pending"   // 排在当前课之后、尚未通过的课，可以直接练习
+	LessonLocked    = "locked"    // 课程要求按顺序学习时，通过前面的课后解锁
+)
+
+// PassThreshold 通过一课的标准
+type PassThreshold struct {
+	Accuracy float64 `json:"accuracy"` // 最低准确率（百分比），没有按键统计时按答题正确率计算
+	WPM      float64 `json:"wpm"`      // 最低净速度，0 表示不限
+}
+
+// Manifest 课程清单，位于资源文件夹中的 course.json
+type Manifest struct {
+	Title   string         `json:"title"`
+	Pass    *PassThreshold `json:"pass,omitempty"`    // 为空时使用默认标准
+	Lessons []string       `json:"lessons,omitempty"` // 按顺序排列的课（不含 .txt），为空时为文件夹中的全部资源
+	Enforce bool           `json:"enforce,omitempty"` // 为 true 时锁定当前课之后的课，通过前面的课才能练习
+}
+
+// LessonResult 一课的练习成绩
+type LessonResult struct {
+	Passed       bool      `json:"passed"`
+	PassedAt     time.Time `json:"passed_at,omitempty"`
+	Attempts     int       `json:"attempts"`      // 完整练习的次数
+	BestAccuracy float64   `json:"best_accuracy"` // 最高准确率
+	BestWPM      float64   `json:"best_wpm"`      // 最高净速度
+	LastAt       time.Time `json:"last_at"`
+}
+
+// Lesson 课程中的一课
+type Lesson struct {
+	Name   string // 文件名（不含文件夹和 .txt）
+	Status string
+	Result LessonResult
+}
+
+// Course 一个资源文件夹对应的课程及学习进度
+type Course struct {
+	ResourceType string
+	Folder       string // 文件夹名
+	Title        string
+	Pass         PassThreshold
+	Enforce      bool // 是否锁定当前课之后的课
+	Lessons      []Lesson
+	results      map[string]LessonResult
+	progressPath string
+}
+
+// Outcome 完成一课后的结果
+type Outcome struct {
+	Lesson   string
+	Passed   bool
+	Accuracy float64
+	WPM      float64
+	Pass     PassThreshold
+	Next     string // 通过后的下一课，课程全部完成时为空
+}
+
+// Load 加载资源文件夹的课程，文件夹中没有课程清单时返回 nil
+func Load(resourceType string, folder practice.ResourceFolder) (*Course, error) {
+	manifest, err := readManifest(resourceType, folder.DirName)
+	if err != nil || manifest == nil {
+		return nil, err
+	}
+
+	course := &Course{
+		ResourceType: resourceType,
+		Folder:       folder.DirName,
+		Title:        manifest.Title,
+		Pass:         PassThreshold{Accuracy: defaultPassAccuracy},
+		Enforce:      manifest.Enforce,
+		results:      make(map[string]LessonResult),
+		progressPath: progressPath(resourceType, folder.DirName),
+	}
+	if course.Title == "" {
+		course.Title = folder.DisplayName
+	}
+	if manifest.Pass != nil {
+		course.Pass = *manifest.Pass
+	}
+
+	// 清单中的课按清单顺序，不存在的文件忽略
+	lessons := folder.Files
+	if len(manifest.Lessons) > 0 {
+		available := make(map[string]bool, len(folder.Files))
+		for _, file := range folder.Files {
+			available[file] = true
+		}
+		lessons = make([]string, 0, len(manifest.Lessons))
+		for _, lesson := range manifest.Lessons {
+			if available[lesson] {
+				lessons = append(lessons, lesson)
+			}
+		}
+	}
+	for _, lesson := range lessons {
+		course.Lessons = append(course.Lessons, Lesson{Name: lesson})
+	}
+
+	if data, err := os.ReadFile(course.progressPath); err == nil {
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &course.results); err != nil {
+				return nil, fmt.Errorf("解析课程进度失败: %w", err)
+			}
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("读取课程进度失败: %w", err)
+	}
+	course.updateStatus()
+	return course, nil
+}
+
+// LoadAll 加载资源类型下所有带课程清单的文件夹
+func LoadAll(resourceType string) ([]*Course, error) {
+	folders, err := practice.GetResourceFolders(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	var courses []*Course
+	for _, folder := range folders {
+		course, err := Load(resourceType, folder)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", folder.DirName, err)
+		}
+		if course != nil {
+			courses = append(courses, course)
+		}
+	}
+	return courses, nil
+}
+
+// LoadForFile 加载资源文件所在文件夹的课程，文件不属于任何课程时返回 nil
+func LoadForFile(resourceType, fileName string) (*Course, error) {
+	folderDir, _ := practice.SplitResourceIdentifier(fileName)
+	folders, err := practice.GetResourceFolders(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	for _, folder := range folders {
+		if folder.DirName == folderDir {
+			return Load(resourceType, folder)
+		}
+	}
+	return nil, nil
+}
+
+// readManifest 读取文件夹中的课程清单，用户数据目录中的清单优先
+func readManifest(resourceType, folderDir string) (*Manifest, error) {
+	for _, dir := range practice.GetFolderPaths(resourceType, folderDir) {
+		data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("读取课程清单失败: %w", err)
+		}
+		manifest := &Manifest{}
+		if err := json.Unmarshal(data, manifest); err != nil {
+			return nil, fmt.Errorf("解析课程清单 %s 失败: %w", filepath.Join(dir, ManifestFile), err)
+		}
+		return manifest, nil
+	}
+	return nil, nil
+}
+
+// progressPath 返回课程进度文件的路径
+func progressPath(resourceType, folderDir string) string {
+	return filepath.Join(practice.GetUserDataDir(), "courses", config.AppConfig.CurrentLanguage, resourceType, folderDir+".json")
+}
+
+// updateStatus 按成绩计算每一课的状态：通过的课为已完成，第一个未通过的课为当前课，
+// 之后的课在课程要求按顺序学习时锁定，否则为待学习
+func (c *Course) updateStatus() {
+	current := false
+	for i := range c.Lessons {
+		lesson := &c.Lessons[i]
+		lesson.Result = c.results[lesson.Name]
+		switch {
+		case lesson.Result.Passed:
+			lesson.Status = LessonCompleted
+		case !current:
+			lesson.Status = LessonCurrent
+			current = true
+		case c.Enforce:
+			lesson.Status = LessonLocked
+		default:
+			lesson.Status = LessonPending
+		}
+	}
+}
+
+// Lesson 返回指定名称的课，不在课程中时返回 nil
+func (c *Course) Lesson(name string) *Lesson {
+	for i := range c.Lessons {
+		if c.Lessons[i].Name == name {
+			return &c.Lessons[i]
+		}
+	}
+	return nil
+}
+
+// Current 返回当前要学的一课，课程全部完成时返回 nil
+func (c *Course) Current() *Lesson {
+	for i := range c.Lessons {
+		if c.Lessons[i].Status == LessonCurrent {
+			return &c.Lessons[i]
+		}
+	}
+	return nil
+}
+
+// CompletedCount 返回已通过的课数
+func (c *Course) CompletedCount() int {
+	count := 0
+	for _, lesson := range c.Lessons {
+		if lesson.Status == LessonCompleted {
+			count++
+		}
+	}
+	return count
+}
+
+// Passes 判断一次练习的成绩是否达到通过标准，wpm 为负数表示没有速度统计，只检查准确率
+func (p PassThreshold) Passes(accuracy, wpm float64) bool {
+	return accuracy >= p.Accuracy && (p.WPM <= 0 || wpm < 0 || wpm >= p.WPM)
+}
+
+// String 返回通过标准的说明
+func (p PassThreshold) String() string {
+	if p.WPM > 0 {
+		return fmt.Sprintf("准确率 ≥ %.0f%%，速度 ≥ %.0f WPM", p.Accuracy, p.WPM)
+	}
+	return fmt.Sprintf("准确率 ≥ %.0f%%", p.Accuracy)
+}
+
+// Record 记录一课的完整练习成绩，达到标准时通过该课并解锁下一课
+func (c *Course) Record(lessonName string, accuracy, wpm float64, now time.Time) (*Outcome, error) {
+	lesson := c.Lesson(lessonName)
+	if lesson == nil {
+		return nil, nil
+	}
+
+	result := c.results[lessonName]
+	result.Attempts++
+	result.LastAt = now
+	if accuracy > result.BestAccuracy {
+		result.BestAccuracy = accuracy
+	}
+	if wpm > result.BestWPM {
+		result.BestWPM = wpm
+	}
+	outcome := &Outcome{Lesson: lessonName, Accuracy: accuracy, WPM: wpm, Pass: c.Pass}
+	if c.Pass.Passes(accuracy, wpm) {
+		outcome.Passed = true
+		if !result.Passed {
+			result.Passed = true
+			result.PassedAt = now
+		}
+	}
+	c.results[lessonName] = result
+	c.updateStatus()
+
+	if outcome.Passed {
+		if next := c.Current(); next != nil {
+			outcome.Next = next.Name
+		}
+	}
+	return outcome, c.save()
+}
+
+// save 将课程进度写回磁盘
+func (c *Course) save() error {
+	if err := os.MkdirAll(filepath.Dir(c.progressPath), 0755); err != nil {
+		return fmt.Errorf("创建课程进度目录失败: %w", err)
+	}
+	data, err := json.MarshalIndent(c.results, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.progressPath, data, 0644)
+}
+
+// RecordSession 根据一次完整练习的统计记录更新课程进度，练习的资源不属于任何课程时返回 nil。
+// 准确率使用按键准确率，速度使用净速度；没有按键统计（如假名输入）时使用答题正确率，不检查速度。
+func RecordSession(record statistics.SessionRecord) (*Outcome, error) {
+	if !record.Completed {
+		return nil, nil
+	}
+	course, err := LoadForFile(record.ResourceType, record.FileName)
+	if err != nil || course == nil {
+		return nil, err
+	}
+
+	accuracy, wpm := record.Accuracy, -1.0
+	if record.Typing != nil && record.Typing.Keystrokes > 0 {
+		accuracy, wpm = record.Typing.CharAccuracy(), record.Typing.NetWPM
+	}
+	_, lessonName := practice.SplitResourceIdentifier(record.FileName)
+	return course.Record(lessonName, accuracy, wpm, record.Timestamp)
+}
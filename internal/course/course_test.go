@@ -0,0 +1,166 @@
+package course
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// setupCourseTest 在临时目录中创建一个带课程清单的文章文件夹
+func setupCourseTest(t *testing.T, manifest string) {
+	t.Helper()
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	dir := filepath.Join("resources", config.AppConfig.CurrentLanguage, practice.Articles, "NCE-1")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"1-001_Excuse_me.txt":        "Excuse me! ->> 对不起！\n",
+		"1-001_Excuse_me copy.txt":   "Excuse me! ->> 对不起！\n",
+		"1-003_Sorry_sir.txt":        "My coat and my umbrella please. ->> 请把我的大衣和伞拿给我。\n",
+		"1-005_Nice_to_meet_you.txt": "Good morning. ->> 早上好。\n",
+		ManifestFile:                 manifest,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// 测试课程清单：按清单顺序排列，不存在的课忽略，课程清单不会出现在资源列表中
+func TestLoadCourse(t *testing.T) {
+	setupCourseTest(t, `{
+  "title": "新概念英语第一册",
+  "pass": {"accuracy": 95, "wpm": 20},
+  "lessons": ["1-001_Excuse_me", "1-005_Nice_to_meet_you", "1-003_Sorry_sir", "1-999_Missing"],
+  "enforce": true
+}`)
+
+	courses, err := LoadAll(practice.Articles)
+	if err != nil || len(courses) != 1 {
+		t.Fatalf("加载课程失败: %v, %d", err, len(courses))
+	}
+	course := courses[0]
+	if course.Title != "新概念英语第一册" || course.Pass.Accuracy != 95 || course.Pass.WPM != 20 {
+		t.Errorf("课程信息 = %+v", course)
+	}
+
+	want := []string{"1-001_Excuse_me", "1-005_Nice_to_meet_you", "1-003_Sorry_sir"}
+	if len(course.Lessons) != len(want) {
+		t.Fatalf("课 = %+v", course.Lessons)
+	}
+	for i, lesson := range course.Lessons {
+		if lesson.Name != want[i] {
+			t.Errorf("第 %d 课 = %s, want %s", i+1, lesson.Name, want[i])
+		}
+	}
+	if course.Lessons[0].Status != LessonCurrent || course.Lessons[1].Status != LessonLocked {
+		t.Errorf("未开始时第一课应为当前课，其余锁定: %+v", course.Lessons)
+	}
+
+	folders, err := practice.GetResourceFolders(practice.Articles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, folder := range folders {
+		for _, file := range folder.Files {
+			if file == "course.json" || file == "course" {
+				t.Errorf("课程清单不应作为资源文件: %v", folder.Files)
+			}
+		}
+	}
+}
+
+// 测试完成一课后按通过标准更新进度并进入下一课，没有要求按顺序学习时之后的课不锁定
+func TestRecordSession(t *testing.T) {
+	setupCourseTest(t, `{"pass": {"accuracy": 90, "wpm": 15}, "lessons": ["1-001_Excuse_me", "1-003_Sorry_sir", "1-005_Nice_to_meet_you"]}`)
+
+	record := statistics.SessionRecord{
+		Timestamp:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		ResourceType: practice.Articles,
+		FileName:     "NCE-1/1-001_Excuse_me",
+		Completed:    true,
+		Typing:       &statistics.TypingStats{Keystrokes: 100, WrongChars: 20, NetWPM: 30},
+	}
+
+	// 准确率 80%，未达到标准
+	outcome, err := RecordSession(record)
+	if err != nil || outcome == nil {
+		t.Fatalf("记录成绩失败: %v", err)
+	}
+	if outcome.Passed || outcome.Accuracy != 80 {
+		t.Errorf("未达标的成绩 = %+v", outcome)
+	}
+
+	record.Typing.WrongChars = 5
+	outcome, err = RecordSession(record)
+	if err != nil || !outcome.Passed || outcome.Next != "1-003_Sorry_sir" {
+		t.Fatalf("达标后应通过并解锁下一课: %+v, %v", outcome, err)
+	}
+
+	course, err := LoadForFile(practice.Articles, "NCE-1/1-001_Excuse_me")
+	if err != nil || course == nil {
+		t.Fatalf("加载课程失败: %v", err)
+	}
+	first := course.Lessons[0]
+	if first.Status != LessonCompleted || first.Result.Attempts != 2 || first.Result.BestAccuracy != 95 {
+		t.Errorf("第一课 = %+v", first)
+	}
+	if course.Current().Name != "1-003_Sorry_sir" || course.Lessons[2].Status != LessonPending || course.CompletedCount() != 1 {
+		t.Errorf("课程状态 = %+v", course.Lessons)
+	}
+
+	// 中途退出和不属于课程的资源不记录
+	record.Completed = false
+	if outcome, _ := RecordSession(record); outcome != nil {
+		t.Error("中途退出不应记录成绩")
+	}
+	record.Completed = true
+	record.FileName = "1-001_Excuse_me"
+	if outcome, _ := RecordSession(record); outcome != nil {
+		t.Error("不属于课程的资源不应记录成绩")
+	}
+}
+
+// 测试通过标准：没有速度统计时只检查准确率
+func TestPassThreshold(t *testing.T) {
+	pass := PassThreshold{Accuracy: 90, WPM: 20}
+	if pass.Passes(95, 10) || !pass.Passes(95, 25) || pass.Passes(85, 25) || !pass.Passes(90, -1) {
+		t.Error("通过标准判断不正确")
+	}
+	if got := pass.String(); got != "准确率 ≥ 90%，速度 ≥ 20 WPM" {
+		t.Errorf("标准说明 = %q", got)
+	}
+}
+
+// 测试内置的《新概念英语》课程清单都会锁定之后的课
+func TestBundledManifestsEnforce(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "resources", "english", practice.Articles, "NCE-*", ManifestFile))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("未找到内置课程清单: %v", err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifest := &Manifest{}
+		if err := json.Unmarshal(data, manifest); err != nil {
+			t.Fatalf("解析 %s 失败: %v", path, err)
+		}
+		if !manifest.Enforce {
+			t.Errorf("%s 应设置 enforce", path)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package kana 将罗马字输入转换为平假名或片假名，用于没有日语输入法时练习假名拼写。
+package kana
+
+import (
+	"strings"
+	"unicode"
+)
+
+// romajiTable 罗马字到平假名的对照表，同时支持平文式、训令式和常见输入法写法
+var romajiTable = map[string]string{
+	"a": "あ", "i": "い", "u": "う", "e": "え", "o": "お",
+	"ka": "か", "ki": "き", "ku": "く", "ke": "け", "ko": "こ",
+	"kya": "きゃ", "kyu": "きゅ", "kyo": "きょ",
+	"ga": "が", "gi": "ぎ", "gu": "ぐ", "ge": "げ", "go": "ご",
+	"gya": "ぎゃ", "gyu": "ぎゅ", "gyo": "ぎょ",
+	"sa": "さ", "shi": "し", "si": "し", "su": "す", "se": "せ", "so": "そ",
+	"sha": "しゃ", "shu": "しゅ", "sho": "しょ", "she": "しぇ",
+	"sya": "しゃ", "syu": "しゅ", "syo": "しょ",
+	"za": "ざ", "ji": "じ", "zi": "じ", "zu": "ず", "ze": "ぜ", "zo": "ぞ",
+	"ja": "じゃ", "ju": "じゅ", "jo": "じょ", "je": "じぇ",
+	"jya": "じゃ", "jyu": "じゅ", "jyo": "じょ",
+	"zya": "じゃ", "zyu": "じゅ", "zyo": "じょ",
+	"ta": "た", "chi": "ち", "ti": "ち", "tsu": "つ", "tu": "つ", "te": "て", "to": "と",
+	"cha": "ちゃ", "chu": "ちゅ", "cho": "ちょ", "che": "ちぇ",
+	"tya": "ちゃ", "tyu": "ちゅ", "tyo": "ちょ",
+	"cya": "ちゃ", "cyu": "ちゅ", "cyo": "ちょ",
+	"thi": "てぃ", "dhi": "でぃ",
+	"da": "だ", "di": "ぢ", "du": "づ", "de": "で", "do": "ど",
+	"dya": "ぢゃ", "dyu": "ぢゅ", "dyo": "ぢょ",
+	"na": "な", "ni": "に", "nu": "ぬ", "ne": "ね", "no": "の",
+	"nya": "にゃ", "nyu": "にゅ", "nyo": "にょ",
+	"ha": "は", "hi": "ひ", "fu": "ふ", "hu": "ふ", "he": "へ", "ho": "ほ",
+	"hya": "ひゃ", "hyu": "ひゅ", "hyo": "ひょ",
+	"fa": "ふぁ", "fi": "ふぃ", "fe": "ふぇ", "fo": "ふぉ",
+	"ba": "ば", "bi": "び", "bu": "ぶ", "be": "べ", "bo": "ぼ",
+	"bya": "びゃ", "byu": "びゅ", "byo": "びょ",
+	"pa": "ぱ", "pi": "ぴ", "pu": "ぷ", "pe": "ぺ", "po": "ぽ",
+	"pya": "ぴゃ", "pyu": "ぴゅ", "pyo": "ぴょ",
+	"ma": "ま", "mi": "み", "mu": "む", "me": "め", "mo": "も",
+	"mya": "みゃ", "myu": "みゅ", "myo": "みょ",
+	"ya": "や", "yu": "ゆ", "yo": "よ",
+	"ra": "ら", "ri": "り", "ru": "る", "re": "れ", "ro": "ろ",
+	"rya": "りゃ", "ryu": "りゅ", "ryo": "りょ",
+	"wa": "わ", "wo": "を", "wi": "うぃ", "we": "うぇ",
+	"va": "ゔぁ", "vi": "ゔぃ", "vu": "ゔ", "ve": "ゔぇ", "vo": "ゔぉ",
+	// 小写假名
+	"xa": "ぁ", "xi": "ぃ", "xu": "ぅ", "xe": "ぇ", "xo": "ぉ",
+	"la": "ぁ", "li": "ぃ", "lu": "ぅ", "le": "ぇ", "lo": "ぉ",
+	"xya": "ゃ", "xyu": "ゅ", "xyo": "ょ",
+	"lya": "ゃ", "lyu": "ゅ", "lyo": "ょ",
+	"xtu": "っ", "ltu": "っ", "xtsu": "っ", "ltsu": "っ",
+	"xwa": "ゎ", "lwa": "ゎ",
+	// 标点
+	"-": "ー", ",": "、", ".": "。",
+}
+
+// maxKeyLength 对照表中最长的罗马字长度
+const maxKeyLength = 4
+
+// romajiPrefixes 对照表中所有罗马字的前缀，用于判断输入是否可能还未输完
+var romajiPrefixes = func() map[string]bool {
+	prefixes := make(map[string]bool)
+	for key := range romajiTable {
+		for i := 1; i < len(key); i++ {
+			prefixes[key[:i]] = true
+		}
+	}
+	return prefixes
+}()
+
+// Convert 将文本中的罗马字转换为假名，模拟输入法的实时转换：
+// 小写字母转换为平假名，大写字母转换为片假名；
+// 已经是假名或其他字符的部分保持不变，末尾还未输完的罗马字（如"k"、"n"）暂时保留。
+func Convert(text string) string {
+	runes := []rune(text)
+	var builder strings.Builder
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		if !isRomaji(r) {
+			builder.WriteRune(r)
+			i++
+			continue
+		}
+
+		lower := unicode.ToLower(r)
+		katakana := unicode.IsUpper(r)
+		next := runeAt(runes, i+1)
+
+		// 促音：重复的辅音（如"tte"）或"tch"转换为"っ"
+		if isConsonant(lower) && lower != 'n' &&
+			(unicode.ToLower(next) == lower || (lower == 't' && unicode.ToLower(next) == 'c')) {
+			builder.WriteString(convertCase("っ", katakana))
+			i++
+			continue
+		}
+
+		// 拨音：nn、n'、n 后接除 y 以外的辅音时转换为"ん"。
+		// nn 后接元音或 y 时只消耗一个 n，例如"onna"转换为"おんな"、"konnichiha"转换为"こんにちは"
+		if lower == 'n' {
+			lowerNext := unicode.ToLower(next)
+			afterNext := unicode.ToLower(runeAt(runes, i+2))
+			switch {
+			case lowerNext == 'n' && strings.ContainsRune("aiueoy", afterNext):
+				builder.WriteString(convertCase("ん", katakana))
+				i++
+				continue
+			case lowerNext == 'n' && i+2 == len(runes):
+				// 末尾的 nn 暂不转换，后面可能还要输入"ni"等音节
+				builder.WriteString(string(runes[i:]))
+				i += 2
+				continue
+			case lowerNext == 'n' || next == '\'':
+				builder.WriteString(convertCase("ん", katakana))
+				i += 2
+				continue
+			case next != 0 && isRomaji(next) && isConsonant(lowerNext) && lowerNext != 'y':
+				builder.WriteString(convertCase("ん", katakana))
+				i++
+				continue
+			}
+		}
+
+		if kana, length := lookup(runes[i:]); length > 0 {
+			builder.WriteString(convertCase(kana, katakana))
+			i += length
+			continue
+		}
+
+		// 末尾还未输完的罗马字原样保留，等待后续输入
+		if rest := strings.ToLower(string(runes[i:])); romajiPrefixes[rest] && allRomaji(runes[i:]) {
+			builder.WriteString(string(runes[i:]))
+			break
+		}
+
+		builder.WriteRune(r)
+		i++
+	}
+
+	return builder.String()
+}
+
+// Finalize 在提交答案时完成转换：末尾暂未转换的"n"或"nn"转换为"ん"
+func Finalize(text string) string {
+	converted := Convert(text)
+	for _, pending := range []string{"nn", "n"} {
+		if strings.HasSuffix(strings.ToLower(converted), pending) {
+			katakana := unicode.IsUpper([]rune(converted[len(converted)-len(pending):])[0])
+			return converted[:len(converted)-len(pending)] + convertCase("ん", katakana)
+		}
+	}
+	return converted
+}
+
+// ToKatakana 将平假名转换为片假名，其他字符保持不变
+func ToKatakana(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			return r + 0x60
+		}
+		return r
+	}, text)
+}
+
+// IsKana 判断文本是否只由平假名、片假名和长音符号组成
+func IsKana(text string) bool {
+	if text == "" {
+		return false
+	}
+	for _, r := range text {
+		if !unicode.Is(unicode.Hiragana, r) && !unicode.Is(unicode.Katakana, r) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// SplitReading 拆分"食べる（たべる）"形式的写法，返回汉字写法和假名读音。
+// 括号中不全是假名，或没有括号时，返回原文和空读音。
+func SplitReading(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	for _, pair := range [][2]string{{"（", "）"}, {"(", ")"}} {
+		if !strings.HasSuffix(text, pair[1]) {
+			continue
+		}
+		open := strings.LastIndex(text, pair[0])
+		if open <= 0 {
+			continue
+		}
+		form := strings.TrimSpace(text[:open])
+		reading := strings.TrimSpace(text[open+len(pair[0]) : len(text)-len(pair[1])])
+		if form != "" && IsKana(reading) {
+			return form, reading
+		}
+	}
+	return text, ""
+}
+
+// lookup 从输入开头查找最长的罗马字，返回对应的平假名和匹配的长度
+func lookup(runes []rune) (string, int) {
+	for length := maxKeyLength; length > 0; length-- {
+		if length > len(runes) || !allRomaji(runes[:length]) {
+			continue
+		}
+		if kana, ok := romajiTable[strings.ToLower(string(runes[:length]))]; ok {
+			return kana, length
+		}
+	}
+	return "", 0
+}
+
+func convertCase(kana string, katakana bool) string {
+	if katakana {
+		return ToKatakana(kana)
+	}
+	return kana
+}
+
+func isRomaji(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '-' || r == ',' || r == '.' || r == '\''
+}
+
+func allRomaji(runes []rune) bool {
+	for _, r := range runes {
+		if !isRomaji(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isConsonant(r rune) bool {
+	return r >= 'a' && r <= 'z' && !strings.ContainsRune("aiueo", r)
+}
+
+func runeAt(runes []rune, index int) rune {
+	if index < len(runes) {
+		return runes[index]
+	}
+	return 0
+}
@@ -0,0 +1,74 @@
+package kana
+
+import "testing"
+
+// 测试罗马字转换：平假名、片假名、促音、拨音和未输完的罗马字
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"taberu", "たべる"},
+		{"konnichiha", "こんにちは"},
+		{"onna", "おんな"},
+		{"kitte", "きって"},
+		{"matcha", "まっちゃ"},
+		{"shinbun", "しんぶn"},
+		{"kan'i", "かんい"},
+		{"kyou", "きょう"},
+		{"TEREBI", "テレビ"},
+		{"ko-hi-", "こーひー"},
+		{"たb", "たb"},
+		{"たbe", "たべ"},
+		{"tabe,", "たべ、"},
+		{"ky", "ky"},
+		{"konn", "こnn"},
+		{"konni", "こんに"},
+	}
+
+	for _, tt := range tests {
+		if got := Convert(tt.input); got != tt.expected {
+			t.Errorf("Convert(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+
+	// 转换结果再次转换时保持不变，便于每次按键后对整个输入重新转换
+	if got := Convert(Convert("gakkou")); got != "がっこう" {
+		t.Errorf("重复转换结果不正确: %q", got)
+	}
+}
+
+// 测试提交时末尾的 n 转换为"ん"
+func TestFinalize(t *testing.T) {
+	if got := Finalize("shinbun"); got != "しんぶん" {
+		t.Errorf("Finalize(shinbun) = %q", got)
+	}
+	if got := Finalize("PAN"); got != "パン" {
+		t.Errorf("Finalize(PAN) = %q", got)
+	}
+	if got := Finalize("honn"); got != "ほん" {
+		t.Errorf("Finalize(honn) = %q", got)
+	}
+}
+
+// 测试拆分汉字写法和假名读音
+func TestSplitReading(t *testing.T) {
+	tests := []struct {
+		input   string
+		form    string
+		reading string
+	}{
+		{"食べる（たべる）", "食べる", "たべる"},
+		{"珈琲(コーヒー)", "珈琲", "コーヒー"},
+		{"食べる", "食べる", ""},
+		{"東京（Tokyo）", "東京（Tokyo）", ""},
+		{"（たべる）", "（たべる）", ""},
+	}
+
+	for _, tt := range tests {
+		form, reading := SplitReading(tt.input)
+		if form != tt.form || reading != tt.reading {
+			t.Errorf("SplitReading(%q) = (%q, %q), want (%q, %q)", tt.input, form, reading, tt.form, tt.reading)
+		}
+	}
+}
@@ -0,0 +1,445 @@
+package manage
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/bookmark"
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/mistakes"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// 数据包的格式标识和版本
+const (
+	bundleFormat       = "mllt-bundle"
+	bundleVersion      = 1
+	bundleManifestName = "manifest.json"
+)
+
+// 数据包中文件的类别，同时也是包内的顶层目录
+const (
+	BundleKindResource   = "resources"  // 用户导入的资源文件
+	BundleKindList       = "lists"      // 收藏、标记列表，包内与资源文件放在一起
+	BundleKindSRS        = "srs"        // 复习计划
+	BundleKindMistakes   = "mistakes"   // 错题记录
+	BundleKindStatistics = "statistics" // 练习统计
+)
+
+// 练习统计在数据包中的文件
+const bundleStatisticsPath = BundleKindStatistics + "/sessions.json"
+
+// BundleManifest 数据包清单，记录数据包的来源和包含的文件
+type BundleManifest struct {
+	Format    string       `json:"format"`
+	Version   int          `json:"version"`
+	Language  string       `json:"language"`
+	Types     []string     `json:"types"`
+	CreatedAt time.Time    `json:"created_at"`
+	Files     []BundleFile `json:"files"`
+}
+
+// BundleFile 数据包中的一个文件
+type BundleFile struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+	Size int64  `json:"size"`
+}
+
+// BundleImportOptions 表示恢复数据包时的选项
+type BundleImportOptions struct {
+	// 恢复到的语言，为空时使用数据包中记录的语言
+	Language string
+	// 是否覆盖已存在的资源、复习计划和错题记录；收藏和标记列表总是合并
+	Overwrite bool
+}
+
+// BundleImportResult 数据包恢复结果
+type BundleImportResult struct {
+	Language        string
+	Restored        int // 新写入或覆盖的文件数
+	Merged          int // 合并的收藏、标记列表数
+	Skipped         int // 因已存在而跳过的文件数
+	SessionsAdded   int // 新增的练习记录数
+	SessionsSkipped int // 重复而跳过的练习记录数
+}
+
+// ExportBundle 将某种语言的用户资源、收藏和标记列表、复习计划、错题记录和练习统计打包为 zip。
+// resourceTypes 为空时导出全部资源类型，返回数据包清单。
+func ExportBundle(w io.Writer, language string, resourceTypes []string) (*BundleManifest, error) {
+	if language == "" {
+		language = config.AppConfig.CurrentLanguage
+	}
+	if !isConfiguredLanguage(language) {
+		return nil, fmt.Errorf("不支持的语言: %s", language)
+	}
+	if len(resourceTypes) == 0 {
+		resourceTypes = []string{Words, Phrases, Sentences, Articles}
+	}
+	for _, resourceType := range resourceTypes {
+		if !ValidateResourceType(resourceType) {
+			return nil, fmt.Errorf("无效的资源类型: %s", resourceType)
+		}
+	}
+
+	manifest := &BundleManifest{
+		Format:    bundleFormat,
+		Version:   bundleVersion,
+		Language:  language,
+		Types:     resourceTypes,
+		CreatedAt: time.Now(),
+	}
+
+	archive := zip.NewWriter(w)
+	userDataDir := getUserDataBaseDir()
+	for _, resourceType := range resourceTypes {
+		sources := []struct {
+			kind   string
+			root   string
+			prefix string
+		}{
+			{BundleKindResource, filepath.Join(userDataDir, language, resourceType), path.Join(BundleKindResource, resourceType)},
+			{BundleKindSRS, srs.Dir(language, resourceType), path.Join(BundleKindSRS, resourceType)},
+		}
+		for _, source := range sources {
+			if err := addBundleDir(archive, manifest, source.kind, source.root, source.prefix); err != nil {
+				return nil, err
+			}
+		}
+
+		mistakesPath := mistakes.Path(language, resourceType)
+		if err := addBundleFile(archive, manifest, BundleKindMistakes, mistakesPath, path.Join(BundleKindMistakes, resourceType+".json")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := addBundleStatistics(archive, manifest, language, resourceTypes); err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	entry, err := archive.CreateHeader(bundleFileHeader(bundleManifestName, manifest.CreatedAt))
+	if err != nil {
+		return nil, fmt.Errorf("写入数据包清单失败: %w", err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return nil, fmt.Errorf("写入数据包清单失败: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("写入数据包失败: %w", err)
+	}
+	return manifest, nil
+}
+
+// addBundleDir 将目录下的所有文件加入数据包，目录不存在时跳过
+func addBundleDir(archive *zip.Writer, manifest *BundleManifest, kind, root, prefix string) error {
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return nil
+	}
+
+	var paths []string
+	err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			paths = append(paths, filePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("读取目录失败: %w", err)
+	}
+
+	sort.Strings(paths)
+	for _, filePath := range paths {
+		relative, err := filepath.Rel(root, filePath)
+		if err != nil {
+			return err
+		}
+		fileKind := kind
+		if kind == BundleKindResource && isBookmarkList(filePath) {
+			fileKind = BundleKindList
+		}
+		if err := addBundleFile(archive, manifest, fileKind, filePath, path.Join(prefix, filepath.ToSlash(relative))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addBundleFile 将单个文件加入数据包，文件不存在时跳过
+func addBundleFile(archive *zip.Writer, manifest *BundleManifest, kind, filePath, name string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("读取文件失败: %w", err)
+	}
+	return writeBundleEntry(archive, manifest, kind, name, data)
+}
+
+// addBundleStatistics 将所选语言和资源类型的练习记录加入数据包
+func addBundleStatistics(archive *zip.Writer, manifest *BundleManifest, language string, resourceTypes []string) error {
+	records, err := statistics.GetAllSessions()
+	if err != nil {
+		return fmt.Errorf("读取练习统计失败: %w", err)
+	}
+
+	selected := make(map[string]bool, len(resourceTypes))
+	for _, resourceType := range resourceTypes {
+		selected[resourceType] = true
+	}
+	filtered := make([]statistics.SessionRecord, 0, len(records))
+	for _, record := range records {
+		if selected[record.ResourceType] && recordInLanguage(record, language) {
+			filtered = append(filtered, record)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+	if err := statistics.WriteJSON(&buffer, filtered); err != nil {
+		return err
+	}
+	return writeBundleEntry(archive, manifest, BundleKindStatistics, bundleStatisticsPath, buffer.Bytes())
+}
+
+// recordInLanguage 判断练习记录是否属于指定语言。旧版本的记录没有语言，
+// 只有导出当前语言且当前语言下存在该资源文件时才认为属于该语言。
+func recordInLanguage(record statistics.SessionRecord, language string) bool {
+	if record.Language != "" {
+		return record.Language == language
+	}
+	if language != config.AppConfig.CurrentLanguage {
+		return false
+	}
+	_, err := os.Stat(practice.GetResourcePath(record.ResourceType, record.FileName))
+	return err == nil
+}
+
+// writeBundleEntry 写入数据包中的一个文件并记录到清单
+func writeBundleEntry(archive *zip.Writer, manifest *BundleManifest, kind, name string, data []byte) error {
+	entry, err := archive.CreateHeader(bundleFileHeader(name, manifest.CreatedAt))
+	if err != nil {
+		return fmt.Errorf("写入数据包失败: %w", err)
+	}
+	if _, err := entry.Write(data); err != nil {
+		return fmt.Errorf("写入数据包失败: %w", err)
+	}
+	manifest.Files = append(manifest.Files, BundleFile{Path: name, Kind: kind, Size: int64(len(data))})
+	return nil
+}
+
+// bundleFileHeader 返回数据包中文件的压缩头，修改时间记为导出时间
+func bundleFileHeader(name string, modified time.Time) *zip.FileHeader {
+	return &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified}
+}
+
+// ImportBundle 恢复 ExportBundle 导出的数据包。
+// 资源、复习计划和错题记录已存在时默认跳过，收藏和标记列表与本地列表合并，练习统计按记录去重合并。
+func ImportBundle(bundlePath string, options BundleImportOptions) (*BundleImportResult, error) {
+	archive, err := zip.OpenReader(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("打开数据包失败: %w", err)
+	}
+	defer archive.Close()
+
+	manifest, err := readBundleManifest(&archive.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	language := options.Language
+	if language == "" {
+		language = manifest.Language
+	}
+	if !isConfiguredLanguage(language) {
+		return nil, fmt.Errorf("不支持的语言: %s，请先在配置中添加该语言", language)
+	}
+
+	result := &BundleImportResult{Language: language}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || file.Name == bundleManifestName {
+			continue
+		}
+		if err := restoreBundleEntry(file, language, options, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// readBundleManifest 读取并校验数据包清单
+func readBundleManifest(archive *zip.Reader) (*BundleManifest, error) {
+	for _, file := range archive.File {
+		if file.Name != bundleManifestName {
+			continue
+		}
+		data, err := readZipFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var manifest BundleManifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return nil, fmt.Errorf("解析数据包清单失败: %w", err)
+		}
+		if manifest.Format != bundleFormat {
+			return nil, fmt.Errorf("不是有效的数据包: %s", manifest.Format)
+		}
+		if manifest.Version > bundleVersion {
+			return nil, fmt.Errorf("数据包版本 %d 过新，请升级程序后再导入", manifest.Version)
+		}
+		return &manifest, nil
+	}
+	return nil, fmt.Errorf("数据包中缺少 %s", bundleManifestName)
+}
+
+// restoreBundleEntry 将数据包中的一个文件恢复到用户数据目录
+func restoreBundleEntry(file *zip.File, language string, options BundleImportOptions, result *BundleImportResult) error {
+	name := path.Clean(file.Name)
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return fmt.Errorf("数据包中包含非法路径: %s", file.Name)
+	}
+
+	data, err := readZipFile(file)
+	if err != nil {
+		return err
+	}
+
+	if name == bundleStatisticsPath {
+		records, err := statistics.ReadJSON(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("解析练习统计失败: %w", err)
+		}
+		// 恢复到其他语言时，练习记录也归入该语言
+		for i := range records {
+			records[i].Language = language
+		}
+		added, skipped, err := statistics.ImportSessions(records)
+		result.SessionsAdded += added
+		result.SessionsSkipped += skipped
+		return err
+	}
+
+	kind, relative, found := strings.Cut(name, "/")
+	resourceType, _, nested := strings.Cut(relative, "/")
+	userDataDir := getUserDataBaseDir()
+	var target string
+	switch {
+	case found && nested && kind == BundleKindResource:
+		target = filepath.Join(userDataDir, language, filepath.FromSlash(relative))
+	case found && nested && kind == BundleKindSRS:
+		target = filepath.Join(srs.Dir(language, resourceType), filepath.FromSlash(strings.TrimPrefix(relative, resourceType+"/")))
+	case found && !nested && kind == BundleKindMistakes:
+		resourceType = strings.TrimSuffix(relative, ".json")
+		target = mistakes.Path(language, resourceType)
+	}
+	if target == "" || !ValidateResourceType(resourceType) {
+		return fmt.Errorf("数据包中包含无法识别的文件: %s", file.Name)
+	}
+
+	if _, err := os.Stat(target); err == nil {
+		if kind == BundleKindResource && isBookmarkList(target) {
+			if err := mergeBookmarkList(target, data); err != nil {
+				return err
+			}
+			result.Merged++
+			return nil
+		}
+		if !options.Overwrite {
+			result.Skipped++
+			return nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("创建目标目录失败: %w", err)
+	}
+	if err := os.WriteFile(target, data, 0644); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	result.Restored++
+	return nil
+}
+
+// readZipFile 读取数据包中一个文件的内容
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("读取数据包失败: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("读取数据包失败: %w", err)
+	}
+	return data, nil
+}
+
+// mergeBookmarkList 将数据包中的收藏或标记列表合并到本地列表，保留本地顺序并追加新条目
+func mergeBookmarkList(target string, data []byte) error {
+	existing, err := os.ReadFile(target)
+	if err != nil {
+		return fmt.Errorf("读取列表失败: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var lines []string
+	for _, content := range [][]byte{existing, data} {
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || seen[line] {
+				continue
+			}
+			seen[line] = true
+			lines = append(lines, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("读取列表失败: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(target, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("写入列表失败: %w", err)
+	}
+	return nil
+}
+
+// isBookmarkList 判断资源文件是否为收藏或标记列表
+func isBookmarkList(filePath string) bool {
+	name := strings.TrimSuffix(filepath.Base(filePath), ".txt")
+	return name == bookmark.FavoriteList || name == bookmark.MarkedList
+}
+
+// isConfiguredLanguage 判断语言是否在配置的语言列表中
+func isConfiguredLanguage(language string) bool {
+	for _, configured := range config.AppConfig.Languages {
+		if configured == language {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package manage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// writeTestFile 写入测试文件并创建所需目录
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("创建目录失败: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+}
+
+// 测试导出数据包后在另一台设备上恢复：资源、列表合并、复习计划、错题和统计
+func TestExportImportBundle(t *testing.T) {
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	language := config.AppConfig.CurrentLanguage
+
+	// 在临时目录中模拟用户数据，避免影响仓库中的资源
+	t.Chdir(t.TempDir())
+	userData := getUserDataBaseDir()
+	writeTestFile(t, filepath.Join(userData, language, Words, "default", "vocab.txt"), "hello ->> 你好\n")
+	writeTestFile(t, filepath.Join(userData, language, Words, "default", "收藏.txt"), "hello ->> 你好\n")
+	writeTestFile(t, filepath.Join(userData, language, Phrases, "default", "daily.txt"), "good morning ->> 早上好\n")
+	writeTestFile(t, filepath.Join(userData, "srs", language, Words, "default", "vocab.json"), `{"items":{}}`)
+	writeTestFile(t, filepath.Join(userData, "mistakes", language, Words+".json"), `{"items":{}}`)
+	writeTestFile(t, filepath.Join(userData, "statistics", "2026-10-01.json"),
+		`[{"timestamp":"2026-10-01T10:00:00Z","resource_type":"words","file_name":"vocab","total":1,"correct":1},
+		{"timestamp":"2026-10-01T11:00:00Z","language":"other","resource_type":"words","file_name":"vocab","total":1,"correct":1},
+		{"timestamp":"2026-10-01T12:00:00Z","resource_type":"words","file_name":"missing","total":1,"correct":1}]`)
+
+	var buffer bytes.Buffer
+	manifest, err := ExportBundle(&buffer, language, []string{Words})
+	if err != nil {
+		t.Fatalf("导出数据包失败: %v", err)
+	}
+	kinds := make(map[string]int)
+	for _, file := range manifest.Files {
+		kinds[file.Kind]++
+	}
+	for kind, want := range map[string]int{BundleKindResource: 1, BundleKindList: 1, BundleKindSRS: 1, BundleKindMistakes: 1, BundleKindStatistics: 1} {
+		if kinds[kind] != want {
+			t.Errorf("数据包中 %s 类文件 %d 个，want %d（清单: %+v）", kind, kinds[kind], want, manifest.Files)
+		}
+	}
+
+	// 换到新的用户目录恢复，本地已有一个收藏列表
+	bundlePath := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := os.WriteFile(bundlePath, buffer.Bytes(), 0644); err != nil {
+		t.Fatalf("写入数据包失败: %v", err)
+	}
+	t.Chdir(t.TempDir())
+	userData = getUserDataBaseDir()
+	favoritePath := filepath.Join(userData, language, Words, "default", "收藏.txt")
+	writeTestFile(t, favoritePath, "bye ->> 再见\n")
+
+	result, err := ImportBundle(bundlePath, BundleImportOptions{})
+	if err != nil {
+		t.Fatalf("恢复数据包失败: %v", err)
+	}
+	if result.Restored != 3 || result.Merged != 1 || result.Skipped != 0 || result.SessionsAdded != 1 {
+		t.Errorf("恢复结果 = %+v", result)
+	}
+
+	// 只导出该语言的练习记录，恢复后记为恢复到的语言
+	sessions, err := statistics.GetAllSessions()
+	if err != nil || len(sessions) != 1 || sessions[0].FileName != "vocab" || sessions[0].Language != language {
+		t.Errorf("恢复的练习记录 = %+v, %v", sessions, err)
+	}
+
+	favorites, err := os.ReadFile(favoritePath)
+	if err != nil {
+		t.Fatalf("读取收藏列表失败: %v", err)
+	}
+	if want := "bye ->> 再见\nhello ->> 你好\n"; string(favorites) != want {
+		t.Errorf("合并后的收藏列表 = %q, want %q", favorites, want)
+	}
+	if _, err := os.Stat(filepath.Join(userData, language, Phrases, "default", "daily.txt")); !os.IsNotExist(err) {
+		t.Error("只导出单词时不应包含短语资源")
+	}
+
+	// 再次恢复时已存在的文件跳过，统计记录去重
+	result, err = ImportBundle(bundlePath, BundleImportOptions{})
+	if err != nil {
+		t.Fatalf("再次恢复数据包失败: %v", err)
+	}
+	if result.Restored != 0 || result.Skipped != 3 || result.SessionsAdded != 0 || result.SessionsSkipped != 1 {
+		t.Errorf("再次恢复结果 = %+v", result)
+	}
+}
@@ -0,0 +1,30 @@
+package manage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Confirmer 在执行导入、删除等操作前向用户确认，返回 true 表示继续。
+// 为 nil 时不再询问，直接执行，适用于界面、脚本和测试。
+type Confirmer func(prompt string) bool
+
+// 标准输入的读取器，多次确认共用同一个缓冲，避免通过管道输入时丢失后续的回答
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// ConfirmFromStdin 在终端中询问 y/n，只有输入 y 时继续
+func ConfirmFromStdin(prompt string) bool {
+	fmt.Printf("%s(y/n): ", prompt)
+	input, _ := stdinReader.ReadString('\n')
+	return strings.ToLower(strings.TrimSpace(input)) == "y"
+}
+
+// confirm 调用确认函数，未提供确认函数时视为同意
+func (c Confirmer) confirm(format string, args ...interface{}) bool {
+	if c == nil {
+		return true
+	}
+	return c(fmt.Sprintf(format, args...))
+}
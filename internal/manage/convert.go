@@ -0,0 +1,290 @@
+package manage
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 导入文件格式
+const (
+	FormatAuto = ""     // 根据扩展名和文件头自动识别
+	FormatText = "txt"  // 本程序的资源格式，原样导入
+	FormatCSV  = "csv"  // 逗号分隔的表格，例如 Google Sheets 导出的文件
+	FormatTSV  = "tsv"  // 制表符分隔的表格
+	FormatAnki = "anki" // Anki 导出的纯文本笔记（带"#separator:"、"#html:"等文件头）
+)
+
+// ImportFormats 支持的导入格式
+var ImportFormats = []string{FormatText, FormatCSV, FormatTSV, FormatAnki}
+
+// ImportOptions 表示导入时的格式和列映射
+type ImportOptions struct {
+	// 导入格式，为空时自动识别
+	Format string
+	// 原文所在的列，从 1 开始，0 表示使用默认列
+	TermColumn int
+	// 翻译所在的列，从 1 开始，0 表示使用默认列
+	TranslationColumn int
+	// CSV/TSV 的第一行是否为表头，为表头时跳过
+	HasHeader bool
+	// 目标文件已存在时的处理方式，见 ConflictSkip 等常量
+	Conflict string
+	// 合并时原文相同但翻译不同的处理方式，见 MergeKeepLocal 等常量
+	MergePolicy string
+	// 按原文单独指定的冲突处理方式，优先于 MergePolicy
+	Resolutions map[string]string
+	// 导入前的确认，为 nil 时不询问
+	Confirm Confirmer
+	// 只计算导入结果，不写入文件
+	DryRun bool
+
+	// 目录导入时已整体确认，不再逐个文件确认
+	confirmed bool
+}
+
+var (
+	// Anki 文件头，例如"#separator:tab"、"#html:true"
+	ankiHeaderPattern = regexp.MustCompile(`^#([a-z ]+):(.*)$`)
+	// HTML 换行标签
+	htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</(?:div|p|li)>`)
+	// 其余 HTML 标签
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+	// Anki 的 [sound:xxx.mp3] 媒体引用
+	ankiSoundPattern = regexp.MustCompile(`\[sound:[^\]]*\]`)
+)
+
+// NormalizeImportFormat 规范化导入格式，无效的格式返回错误
+func NormalizeImportFormat(format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	switch format {
+	case "", "auto":
+		return FormatAuto, nil
+	case "text":
+		return FormatText, nil
+	}
+	for _, valid := range ImportFormats {
+		if format == valid {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的导入格式: %s（可选: auto, %s）", format, strings.Join(ImportFormats, ", "))
+}
+
+// DetectImportFormat 根据扩展名和文件开头识别导入格式。
+// .txt 文件以"#separator:"等 Anki 文件头开头时按 Anki 格式导入。
+func DetectImportFormat(path string, head []byte) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".tsv":
+		return FormatTSV, nil
+	case ".txt":
+		firstLine := strings.TrimPrefix(string(head), "\ufeff")
+		if index := strings.IndexByte(firstLine, '\n'); index >= 0 {
+			firstLine = firstLine[:index]
+		}
+		if ankiHeaderPattern.MatchString(strings.TrimSpace(firstLine)) {
+			return FormatAnki, nil
+		}
+		return FormatText, nil
+	}
+	return "", fmt.Errorf("文件必须是 .txt、.csv 或 .tsv 格式: %s", path)
+}
+
+// ConvertImport 将 CSV/TSV/Anki 文件转换为" ->> "分隔的资源行
+func ConvertImport(r io.Reader, options ImportOptions) ([]string, error) {
+	switch options.Format {
+	case FormatCSV:
+		return convertTable(r, ',', options)
+	case FormatTSV:
+		return convertTable(r, '\t', options)
+	case FormatAnki:
+		return convertAnki(r, options)
+	}
+	return nil, fmt.Errorf("不支持转换的导入格式: %s", options.Format)
+}
+
+// convertTable 按列映射转换 CSV/TSV 表格
+func convertTable(r io.Reader, comma rune, options ImportOptions) ([]string, error) {
+	reader := newRecordReader(r, comma)
+	termColumn, translationColumn := resolveColumns(options, nil)
+
+	var lines []string
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析表格失败: %w", err)
+		}
+		if first {
+			first = false
+			if options.HasHeader {
+				continue
+			}
+		}
+		if line, ok := buildImportLine(record, termColumn, translationColumn, false); ok {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// convertAnki 转换 Anki 导出的纯文本笔记。
+// 支持的文件头：#separator、#html，以及 #guid/#notetype/#deck/#tags column，
+// 未指定列时跳过这些元数据列，取剩下的前两列作为原文和翻译。
+func convertAnki(r io.Reader, options ImportOptions) ([]string, error) {
+	buffered := bufio.NewReader(r)
+	comma := '\t'
+	isHTML := false
+	metadataColumns := map[int]bool{}
+
+	for {
+		peek, err := buffered.Peek(1)
+		if err != nil || peek[0] != '#' {
+			break
+		}
+		header, err := buffered.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("读取 Anki 文件头失败: %w", err)
+		}
+		match := ankiHeaderPattern.FindStringSubmatch(strings.TrimSpace(header))
+		if match == nil {
+			continue
+		}
+		key, value := strings.TrimSpace(match[1]), strings.TrimSpace(match[2])
+		switch key {
+		case "separator":
+			separator, sepErr := parseAnkiSeparator(value)
+			if sepErr != nil {
+				return nil, sepErr
+			}
+			comma = separator
+		case "html":
+			isHTML = strings.EqualFold(value, "true")
+		case "guid column", "notetype column", "deck column", "tags column":
+			if column, convErr := strconv.Atoi(value); convErr == nil && column > 0 {
+				metadataColumns[column] = true
+			}
+		}
+	}
+
+	reader := newRecordReader(buffered, comma)
+	var lines []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 Anki 笔记失败: %w", err)
+		}
+		termColumn, translationColumn := resolveColumns(options, contentColumns(len(record), metadataColumns))
+		if line, ok := buildImportLine(record, termColumn, translationColumn, isHTML); ok {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// parseAnkiSeparator 解析 Anki 的 #separator 文件头
+func parseAnkiSeparator(value string) (rune, error) {
+	switch strings.ToLower(value) {
+	case "tab":
+		return '\t', nil
+	case "comma":
+		return ',', nil
+	case "semicolon":
+		return ';', nil
+	case "space":
+		return ' ', nil
+	case "pipe":
+		return '|', nil
+	case "colon":
+		return ':', nil
+	}
+	if runes := []rune(value); len(runes) == 1 {
+		return runes[0], nil
+	}
+	return 0, fmt.Errorf("不支持的 Anki 分隔符: %s", value)
+}
+
+// newRecordReader 创建宽松的表格读取器：允许每行列数不同和不规范的引号
+func newRecordReader(r io.Reader, comma rune) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.Comma = comma
+	reader.Comment = 0
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	return reader
+}
+
+// contentColumns 返回除元数据列以外的列号（从 1 开始）
+func contentColumns(count int, metadata map[int]bool) []int {
+	columns := make([]int, 0, count)
+	for column := 1; column <= count; column++ {
+		if !metadata[column] {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// resolveColumns 返回原文和翻译所在的列，未指定时依次取可用列中的前两列
+func resolveColumns(options ImportOptions, available []int) (int, int) {
+	defaults := []int{1, 2}
+	if available != nil {
+		defaults = []int{0, 0}
+		copy(defaults, available)
+	}
+
+	termColumn, translationColumn := options.TermColumn, options.TranslationColumn
+	if termColumn <= 0 {
+		termColumn = defaults[0]
+	}
+	if translationColumn <= 0 {
+		translationColumn = defaults[1]
+	}
+	return termColumn, translationColumn
+}
+
+// buildImportLine 取出原文和翻译并拼接为资源行，原文为空的行跳过
+func buildImportLine(record []string, termColumn, translationColumn int, isHTML bool) (string, bool) {
+	term := importField(record, termColumn, isHTML)
+	if term == "" {
+		return "", false
+	}
+	translation := importField(record, translationColumn, isHTML)
+	if translation == "" || translationColumn == termColumn {
+		return term, true
+	}
+	return term + " ->> " + translation, true
+}
+
+// importField 取出某一列的内容，去掉 HTML 和 Anki 媒体引用，并将换行合并为空格
+func importField(record []string, column int, isHTML bool) string {
+	if column <= 0 || column > len(record) {
+		return ""
+	}
+	value := strings.TrimPrefix(record[column-1], "\ufeff")
+	if isHTML {
+		value = htmlBreakPattern.ReplaceAllString(value, " ")
+		value = htmlTagPattern.ReplaceAllString(value, "")
+		value = html.UnescapeString(value)
+	}
+	value = ankiSoundPattern.ReplaceAllString(value, "")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// errEmptyImport 转换后没有任何条目
+var errEmptyImport = errors.New("文件中没有可导入的条目")
@@ -0,0 +1,110 @@
+package manage
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 测试根据扩展名和 Anki 文件头识别导入格式
+func TestDetectImportFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		head string
+		want string
+	}{
+		{"words.csv", "apple,苹果", FormatCSV},
+		{"words.TSV", "apple\t苹果", FormatTSV},
+		{"words.txt", "apple ->> 苹果", FormatText},
+		{"deck.txt", "#separator:tab\n#html:true\napple\t苹果", FormatAnki},
+		{"deck.txt", "\ufeff#separator:comma\napple,苹果", FormatAnki},
+	}
+	for _, tt := range tests {
+		got, err := DetectImportFormat(tt.path, []byte(tt.head))
+		if err != nil || got != tt.want {
+			t.Errorf("DetectImportFormat(%q) = %q, %v, want %q", tt.path, got, err, tt.want)
+		}
+	}
+
+	if _, err := DetectImportFormat("words.xlsx", nil); err == nil {
+		t.Error("不支持的扩展名应返回错误")
+	}
+}
+
+// 测试 CSV/TSV 的列映射、表头、引号和换行处理
+func TestConvertTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		options ImportOptions
+		want    []string
+	}{
+		{
+			name:    "默认前两列",
+			content: "apple,苹果\nbanana,香蕉\n",
+			options: ImportOptions{Format: FormatCSV},
+			want:    []string{"apple ->> 苹果", "banana ->> 香蕉"},
+		},
+		{
+			name:    "指定列并跳过表头",
+			content: "id,word,meaning\n1,apple,苹果\n2,,空行\n3,banana,\n",
+			options: ImportOptions{Format: FormatCSV, TermColumn: 2, TranslationColumn: 3, HasHeader: true},
+			want:    []string{"apple ->> 苹果", "banana"},
+		},
+		{
+			name:    "引号中的逗号和换行",
+			content: "\"good morning, sir\",\"早上好，\n先生\"\n",
+			options: ImportOptions{Format: FormatCSV},
+			want:    []string{"good morning, sir ->> 早上好， 先生"},
+		},
+		{
+			name:    "制表符分隔",
+			content: "apple\t苹果\n",
+			options: ImportOptions{Format: FormatTSV},
+			want:    []string{"apple ->> 苹果"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertImport(strings.NewReader(tt.content), tt.options)
+			if err != nil {
+				t.Fatalf("转换失败: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("转换结果 = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// 测试 Anki 导出文件：文件头、HTML、媒体引用和元数据列
+func TestConvertAnki(t *testing.T) {
+	content := strings.Join([]string{
+		"#separator:tab",
+		"#html:true",
+		"#guid column:1",
+		"#tags column:4",
+		"a1b2\tapple[sound:apple.mp3]\t<b>苹果</b><br>n.&nbsp;水果\tfruit",
+		"c3d4\tbanana\t香蕉\tfruit",
+	}, "\n")
+
+	got, err := ConvertImport(strings.NewReader(content), ImportOptions{Format: FormatAnki})
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	want := []string{"apple ->> 苹果 n. 水果", "banana ->> 香蕉"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("转换结果 = %q, want %q", got, want)
+	}
+
+	// 分号分隔并交换原文和翻译
+	content = "#separator:semicolon\n苹果;apple\n"
+	got, err = ConvertImport(strings.NewReader(content), ImportOptions{Format: FormatAnki, TermColumn: 2, TranslationColumn: 1})
+	if err != nil {
+		t.Fatalf("转换失败: %v", err)
+	}
+	if want := []string{"apple ->> 苹果"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("转换结果 = %q, want %q", got, want)
+	}
+}
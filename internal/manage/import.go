@@ -2,8 +2,10 @@ package manage
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -77,131 +79,383 @@ func isValidSeparatorFormat(line string) bool {
 	return false
 }
 
-// ImportResource 导入资源
-func ImportResource(resourceType, folderDir, sourcePath string) error {
-	// 验证资源类型
-	if !ValidateResourceType(resourceType) {
-		return fmt.Errorf("无效的资源类型: %s", resourceType)
+// 同名资源文件已存在时的处理方式
+const (
+	ConflictAsk       = ""          // 询问是否覆盖，未提供确认函数时直接覆盖
+	ConflictSkip      = "skip"      // 跳过，保留已有文件
+	ConflictOverwrite = "overwrite" // 覆盖已有文件
+	ConflictRename    = "rename"    // 另存为"文件名-2"等不重名的文件
+	ConflictMerge     = "merge"     // 按原文合并条目，翻译不同时按 ImportOptions.MergePolicy 处理
+)
+
+// ConflictPolicies 可选的同名文件处理方式
+var ConflictPolicies = []string{ConflictSkip, ConflictOverwrite, ConflictRename, ConflictMerge}
+
+// 导入结果
+const (
+	ImportCreated     = "created"
+	ImportOverwritten = "overwritten"
+	ImportRenamed     = "renamed"
+	ImportMerged      = "merged"
+	ImportSkipped     = "skipped"
+	ImportCancelled   = "cancelled"
+)
+
+// ImportResult 表示一个文件的导入结果
+type ImportResult struct {
+	Source     string // 源文件路径
+	Identifier string // 目标资源标识（文件夹/文件名）
+	Format     string // 导入格式
+	Action     string // 导入结果，见 ImportCreated 等常量
+	Entries    int    // 转换出的条目数，原样复制时为 -1
+
+	// 合并到已有文件时的统计
+	MergeReport
+}
+
+// DisplayName 返回目标资源的显示名称
+func (r ImportResult) DisplayName() string {
+	return practice.FormatResourceDisplayName(r.Identifier)
+}
+
+// NormalizeConflictPolicy 规范化同名文件处理方式，无效的值返回错误
+func NormalizeConflictPolicy(policy string) (string, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" || policy == "ask" {
+		return ConflictAsk, nil
 	}
+	for _, valid := range ConflictPolicies {
+		if policy == valid {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的同名文件处理方式: %s（可选: %s）", policy, strings.Join(ConflictPolicies, ", "))
+}
 
-	// 检查源文件是否存在
-	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
-		return fmt.Errorf("源文件不存在: %s", sourcePath)
+// ImportResource 导入资源。
+// .txt 资源文件原样复制；CSV/TSV 表格和 Anki 导出的笔记按 options 中的列映射转换为" ->> "格式。
+// 目标文件已存在时按 options.Conflict 处理；options.Confirm 为 nil 时不询问用户。
+// options.DryRun 为 true 时只计算导入结果（包括合并统计和冲突），不询问也不写入。
+func ImportResource(resourceType, folderDir, sourcePath string, options ImportOptions) (*ImportResult, error) {
+	conflict, err := NormalizeConflictPolicy(options.Conflict)
+	if err != nil {
+		return nil, err
+	}
+	mergePolicy, err := NormalizeMergePolicy(options.MergePolicy)
+	if err != nil {
+		return nil, err
+	}
+	if options.DryRun {
+		options.Confirm = nil
+	}
+	target, options, err := prepareImport(resourceType, folderDir, sourcePath, options)
+	if err != nil {
+		return nil, err
 	}
 
-	// 检查文件扩展名
-	if filepath.Ext(sourcePath) != ".txt" {
-		return fmt.Errorf("文件必须是.txt格式: %s", sourcePath)
+	result := &ImportResult{Source: sourcePath, Identifier: target.identifier, Format: options.Format, Entries: -1}
+	content, err := readImportContent(sourcePath, options)
+	if err != nil {
+		return nil, err
+	}
+	if options.Format != FormatText {
+		result.Entries = len(content.lines)
 	}
 
-	// 获取目标文件名
-	fileName := filepath.Base(sourcePath)
-	baseName := strings.TrimSuffix(fileName, ".txt")
-	identifier := practice.BuildResourceIdentifier(folderDir, baseName)
-	targetPath := GetResourcePath(resourceType, identifier)
-	fmt.Printf("调试: 目标路径 = %s\n", targetPath)
-
-	// 检查目标文件是否已存在
-	if _, err := os.Stat(targetPath); err == nil {
-		// 确认覆盖
-		fmt.Printf("文件 %s 已存在，是否覆盖？(y/n): ", fileName)
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(input)
-
-		if strings.ToLower(input) != "y" {
-			fmt.Println("取消导入。")
-			return nil
+	action := ImportCreated
+	if _, err := os.Stat(target.path); err == nil {
+		switch conflict {
+		case ConflictSkip:
+			result.Action = ImportSkipped
+			return result, nil
+		case ConflictRename:
+			target = renameImportTarget(resourceType, target)
+			result.Identifier = target.identifier
+			action = ImportRenamed
+		case ConflictMerge:
+			if !SupportsMerge(resourceType) {
+				return nil, fmt.Errorf("%s 已存在，句子和文章不支持合并导入，请选择覆盖、跳过或重命名", filepath.Base(target.path))
+			}
+			action = ImportMerged
+		case ConflictOverwrite:
+			action = ImportOverwritten
+		default:
+			if !options.Confirm.confirm("文件 %s 已存在，是否覆盖？", filepath.Base(target.path)) {
+				result.Action = ImportCancelled
+				return result, nil
+			}
+			action = ImportOverwritten
 		}
 	}
 
-	// 确认导入
-	fmt.Printf("确认导入 %s 到 %s/%s 吗？(y/n): ", sourcePath, resourceType, practice.FormatResourceDisplayName(identifier))
-	reader := bufio.NewReader(os.Stdin)
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
+	if !options.confirmed && !options.Confirm.confirm("确认导入 %s 到 %s/%s 吗？", sourcePath, resourceType, result.DisplayName()) {
+		result.Action = ImportCancelled
+		return result, nil
+	}
 
-	if strings.ToLower(input) != "y" {
-		fmt.Println("取消导入。")
-		return nil
+	result.Action = action
+	if action == ImportMerged {
+		existing, err := os.ReadFile(target.path)
+		if err != nil {
+			return nil, fmt.Errorf("读取目标文件失败: %w", err)
+		}
+		localEntries, header, err := (&importContent{raw: existing}).entries(resourceType)
+		if err != nil {
+			return nil, fmt.Errorf("解析目标文件失败: %w", err)
+		}
+		incomingEntries, _, err := content.entries(resourceType)
+		if err != nil {
+			return nil, fmt.Errorf("解析源文件失败: %w", err)
+		}
+		var merged []string
+		merged, result.MergeReport = MergeEntries(localEntries, incomingEntries, mergePolicy, options.Resolutions)
+		if options.DryRun || !result.Changed() {
+			return result, nil
+		}
+		// 合并后的条目统一写成" ->> "分隔的单行格式，保留本地文件头中的元数据
+		if header != nil {
+			if header.Separator != "" {
+				header.Separator = " ->> "
+			}
+			if header.Format != "" {
+				header.Format = practice.FormatLine
+			}
+			merged = append([]string{header.String()}, merged...)
+		}
+		content = &importContent{lines: merged}
+	} else if options.DryRun {
+		return result, nil
 	}
 
-	// 复制文件
-	sourceFile, err := os.Open(sourcePath)
+	if err := writeImportedResource(target.path, content); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ImportDirectory 导入目录中的所有资源文件（.txt、.csv、.tsv），包括子目录。
+// 目录第一层的文件导入到 folderDir，子目录中的文件导入到与子目录同名的资源文件夹，
+// 多层子目录的名称用"_"连接，例如 unit1/day1 导入到"unit1_day1"文件夹。
+// 提供确认函数时只在开始前确认一次，同名文件仍按 options.Conflict 处理。
+func ImportDirectory(resourceType, folderDir, sourceDir string, options ImportOptions) ([]ImportResult, error) {
+	if !ValidateResourceType(resourceType) {
+		return nil, fmt.Errorf("无效的资源类型: %s", resourceType)
+	}
+
+	files, err := collectImportFiles(sourceDir)
 	if err != nil {
-		return fmt.Errorf("打开源文件失败: %w", err)
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("目录中没有可导入的文件: %s", sourceDir)
 	}
-	defer sourceFile.Close()
 
-	// 确保目标目录存在
-	targetDir := filepath.Dir(targetPath)
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return fmt.Errorf("创建目标目录失败: %w", err)
+	if !options.Confirm.confirm("确认导入目录 %s 中的 %d 个文件到 %s 吗？", sourceDir, len(files), resourceType) {
+		return nil, nil
 	}
+	options.confirmed = true
 
-	targetFile, err := os.Create(targetPath)
+	results := make([]ImportResult, 0, len(files))
+	for _, file := range files {
+		targetFolder := folderDir
+		if relativeDir := filepath.Dir(file.relative); relativeDir != "." {
+			targetFolder, _ = practice.NormalizeFolderName(relativeDir)
+		}
+		result, err := ImportResource(resourceType, targetFolder, file.path, options)
+		if err != nil {
+			return results, fmt.Errorf("导入 %s 失败: %w", file.relative, err)
+		}
+		results = append(results, *result)
+	}
+	return results, nil
+}
+
+// importFile 目录导入中的一个源文件
+type importFile struct {
+	path     string // 完整路径
+	relative string // 相对于导入目录的路径
+}
+
+// collectImportFiles 收集目录中可导入的文件，跳过隐藏文件和目录
+func collectImportFiles(sourceDir string) ([]importFile, error) {
+	info, err := os.Stat(sourceDir)
 	if err != nil {
-		return fmt.Errorf("创建目标文件失败: %w", err)
+		return nil, fmt.Errorf("源目录不存在: %s", sourceDir)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("不是目录: %s", sourceDir)
 	}
-	defer targetFile.Close()
 
-	// 直接复制文件，不进行格式转换
-	if _, err := io.Copy(targetFile, sourceFile); err != nil {
-		return fmt.Errorf("复制文件失败: %w", err)
+	var files []importFile
+	err = filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != sourceDir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".txt", ".csv", ".tsv":
+		default:
+			return nil
+		}
+		relative, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, importFile{path: path, relative: relative})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("读取源目录失败: %w", err)
 	}
+	return files, nil
+}
 
-	fmt.Printf("成功导入 %s 到 %s/%s\n", fileName, resourceType, practice.FormatResourceDisplayName(identifier))
-	return nil
+// importTarget 表示导入的目标资源
+type importTarget struct {
+	folderDir  string // 目标资源文件夹
+	baseName   string // 目标文件名（不含扩展名）
+	identifier string // 目标资源标识（文件夹/文件名）
+	path       string // 目标文件路径
 }
 
-// ImportResourceForTest 导入资源（用于测试，不需要用户确认）
-// ImportResourceForTest 导入资源（用于测试，不需要用户确认）
-func ImportResourceForTest(resourceType, folderDir, sourcePath string) error {
+// prepareImport 检查源文件，识别导入格式，并计算目标资源路径
+func prepareImport(resourceType, folderDir, sourcePath string, options ImportOptions) (importTarget, ImportOptions, error) {
 	// 验证资源类型
 	if !ValidateResourceType(resourceType) {
-		return fmt.Errorf("无效的资源类型: %s", resourceType)
+		return importTarget{}, options, fmt.Errorf("无效的资源类型: %s", resourceType)
 	}
 
 	// 检查源文件是否存在
 	if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
-		return fmt.Errorf("源文件不存在: %s", sourcePath)
+		return importTarget{}, options, fmt.Errorf("源文件不存在: %s", sourcePath)
 	}
 
-	// 检查文件扩展名
-	if filepath.Ext(sourcePath) != ".txt" {
-		return fmt.Errorf("文件必须是.txt格式: %s", sourcePath)
+	format, err := NormalizeImportFormat(options.Format)
+	if err != nil {
+		return importTarget{}, options, err
 	}
+	if format == FormatAuto {
+		head, err := readFileHead(sourcePath)
+		if err != nil {
+			return importTarget{}, options, err
+		}
+		if format, err = DetectImportFormat(sourcePath, head); err != nil {
+			return importTarget{}, options, err
+		}
+	}
+	options.Format = format
 
-	// 获取目标文件名
+	// 获取目标文件名，导入后统一保存为 .txt
 	fileName := filepath.Base(sourcePath)
-	baseName := strings.TrimSuffix(fileName, ".txt")
+	baseName := strings.TrimSuffix(fileName, filepath.Ext(fileName))
 	identifier := practice.BuildResourceIdentifier(folderDir, baseName)
 	targetPath := GetResourcePath(resourceType, identifier)
+	return importTarget{folderDir: folderDir, baseName: baseName, identifier: identifier, path: targetPath}, options, nil
+}
+
+// readFileHead 读取文件开头的内容，用于识别格式
+func readFileHead(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开源文件失败: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("读取源文件失败: %w", err)
+	}
+	return head[:n], nil
+}
+
+// importContent 表示读取到的导入内容：原样复制的文件保留原始字节，转换后的文件为资源行
+type importContent struct {
+	raw   []byte
+	lines []string
+}
+
+// entries 返回导入内容中的条目及文件头。原样复制的文件按资源文件的规则解析
+// （换行分隔格式、文件头声明的分隔符），条目统一为" ->> "分隔的单行格式
+func (c *importContent) entries(resourceType string) ([]string, *practice.ResourceHeader, error) {
+	if c.raw == nil {
+		return c.lines, nil, nil
+	}
+	content, err := practice.ParseResource(resourceType, bytes.NewReader(c.raw))
+	if err != nil {
+		return nil, nil, err
+	}
+	entries := make([]string, 0, len(content.Lines))
+	for _, line := range content.Lines {
+		if line = strings.TrimSpace(line); line != "" {
+			entries = append(entries, line)
+		}
+	}
+	return entries, content.Header, nil
+}
+
+// readImportContent 读取源文件，CSV/TSV/Anki 文件转换为资源行
+func readImportContent(sourcePath string, options ImportOptions) (*importContent, error) {
+	if options.Format == FormatText {
+		raw, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("读取源文件失败: %w", err)
+		}
+		return &importContent{raw: raw}, nil
+	}
 
-	// 复制文件
 	sourceFile, err := os.Open(sourcePath)
 	if err != nil {
-		return fmt.Errorf("打开源文件失败: %w", err)
+		return nil, fmt.Errorf("打开源文件失败: %w", err)
 	}
 	defer sourceFile.Close()
 
+	lines, err := ConvertImport(sourceFile, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errEmptyImport
+	}
+	return &importContent{lines: lines}, nil
+}
+
+// writeImportedResource 写入目标资源文件，资源格式的文件直接复制，不进行格式转换
+func writeImportedResource(targetPath string, content *importContent) error {
 	// 确保目标目录存在
-	targetDir := filepath.Dir(targetPath)
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
 		return fmt.Errorf("创建目标目录失败: %w", err)
 	}
 
-	targetFile, err := os.Create(targetPath)
-	if err != nil {
-		return fmt.Errorf("创建目标文件失败: %w", err)
+	data := content.raw
+	if data == nil {
+		data = []byte(strings.Join(content.lines, "\n") + "\n")
 	}
-	defer targetFile.Close()
-
-	// 直接复制文件，不进行格式转换
-	if _, err := io.Copy(targetFile, sourceFile); err != nil {
-		return fmt.Errorf("复制文件失败: %w", err)
+	if err := os.WriteFile(targetPath, data, 0644); err != nil {
+		return fmt.Errorf("写入目标文件失败: %w", err)
 	}
-
-	fmt.Printf("成功导入 %s 到 %s/%s\n", fileName, resourceType, practice.FormatResourceDisplayName(identifier))
 	return nil
 }
+
+// renameImportTarget 为导入的文件选择不重名的文件名，例如"words-2"
+func renameImportTarget(resourceType string, target importTarget) importTarget {
+	for i := 2; ; i++ {
+		baseName := fmt.Sprintf("%s-%d", target.baseName, i)
+		identifier := practice.BuildResourceIdentifier(target.folderDir, baseName)
+		path := GetResourcePath(resourceType, identifier)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			target.baseName = baseName
+			target.identifier = identifier
+			target.path = path
+			return target
+		}
+	}
+}
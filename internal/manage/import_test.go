@@ -0,0 +1,111 @@
+package manage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+)
+
+// setupImportTest 加载配置并切换到临时目录，返回存放源文件的目录
+func setupImportTest(t *testing.T) string {
+	t.Helper()
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	t.Chdir(t.TempDir())
+	return t.TempDir()
+}
+
+// readResource 读取导入后的资源文件
+func readResource(t *testing.T, identifier string) string {
+	t.Helper()
+	content, err := os.ReadFile(GetResourcePath(Words, identifier))
+	if err != nil {
+		t.Fatalf("读取资源 %s 失败: %v", identifier, err)
+	}
+	return string(content)
+}
+
+// 测试同名文件的处理方式：跳过、覆盖、重命名、合并，以及取消确认
+func TestImportResourceConflict(t *testing.T) {
+	sourceDir := setupImportTest(t)
+	source := filepath.Join(sourceDir, "vocab.txt")
+	writeTestFile(t, source, "apple ->> 苹果\n")
+	if _, err := ImportResource(Words, "course", source, ImportOptions{}); err != nil {
+		t.Fatalf("导入失败: %v", err)
+	}
+	writeTestFile(t, source, "apple ->> 苹果\nbanana ->> 香蕉\n")
+
+	tests := []struct {
+		conflict   string
+		action     string
+		identifier string
+		want       string
+	}{
+		{ConflictSkip, ImportSkipped, "course/vocab", "apple ->> 苹果\n"},
+		{ConflictRename, ImportRenamed, "course/vocab-2", "apple ->> 苹果\nbanana ->> 香蕉\n"},
+		{ConflictMerge, ImportMerged, "course/vocab", "apple ->> 苹果\nbanana ->> 香蕉\n"},
+		{ConflictOverwrite, ImportOverwritten, "course/vocab", "apple ->> 苹果\nbanana ->> 香蕉\n"},
+	}
+	for _, tt := range tests {
+		result, err := ImportResource(Words, "course", source, ImportOptions{Conflict: tt.conflict})
+		if err != nil {
+			t.Fatalf("%s: 导入失败: %v", tt.conflict, err)
+		}
+		if result.Action != tt.action || result.Identifier != tt.identifier {
+			t.Errorf("%s: 结果 = %s %s, want %s %s", tt.conflict, result.Action, result.Identifier, tt.action, tt.identifier)
+		}
+		if got := readResource(t, tt.identifier); got != tt.want {
+			t.Errorf("%s: 资源内容 = %q, want %q", tt.conflict, got, tt.want)
+		}
+	}
+
+	// 用户拒绝覆盖时保留原文件
+	refuse := func(string) bool { return false }
+	result, err := ImportResource(Words, "course", source, ImportOptions{Confirm: refuse})
+	if err != nil || result.Action != ImportCancelled {
+		t.Errorf("拒绝确认时应取消导入，结果 %+v, %v", result, err)
+	}
+
+	if _, err := ImportResource(Words, "course", source, ImportOptions{Conflict: "replace"}); err == nil {
+		t.Error("无效的同名文件处理方式应返回错误")
+	}
+}
+
+// 测试导入目录：第一层文件导入到指定文件夹，子目录导入到同名文件夹
+func TestImportDirectory(t *testing.T) {
+	sourceDir := setupImportTest(t)
+	writeTestFile(t, filepath.Join(sourceDir, "intro.txt"), "hello ->> 你好\n")
+	writeTestFile(t, filepath.Join(sourceDir, "unit1", "day1.csv"), "apple,苹果\n")
+	writeTestFile(t, filepath.Join(sourceDir, "unit2", "day1", "words.txt"), "banana ->> 香蕉\n")
+	writeTestFile(t, filepath.Join(sourceDir, "unit1", "notes.md"), "不导入")
+	writeTestFile(t, filepath.Join(sourceDir, ".hidden", "secret.txt"), "不导入")
+
+	asked := 0
+	confirm := func(string) bool { asked++; return true }
+	results, err := ImportDirectory(Words, "course", sourceDir, ImportOptions{Confirm: confirm})
+	if err != nil {
+		t.Fatalf("导入目录失败: %v", err)
+	}
+	if asked != 1 {
+		t.Errorf("导入目录应只确认一次，实际 %d 次", asked)
+	}
+	if len(results) != 3 {
+		t.Fatalf("应导入 3 个文件，实际 %+v", results)
+	}
+
+	if got := readResource(t, "course/intro"); got != "hello ->> 你好\n" {
+		t.Errorf("第一层文件内容 = %q", got)
+	}
+	if got := readResource(t, "unit1/day1"); got != "apple ->> 苹果\n" {
+		t.Errorf("子目录文件内容 = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(getUserDataBaseDir(), config.AppConfig.CurrentLanguage, Words, "unit1", "day1.txt")); err != nil {
+		t.Errorf("子目录应导入到同名资源文件夹: %v", err)
+	}
+	if got := readResource(t, "unit2_day1/words"); got != "banana ->> 香蕉\n" {
+		t.Errorf("多层子目录文件内容 = %q", got)
+	}
+}
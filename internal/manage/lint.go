@@ -0,0 +1,310 @@
+package manage
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// 资源检查发现的问题类型
+const (
+	LintSpaceSplit       = "space-split"       // 按空格拆分时把多个单词的原文拆开了
+	LintDuplicate        = "duplicate"         // 文件中原文重复
+	LintEmptyTranslation = "empty-translation" // 条目没有翻译
+	LintMixedSeparators  = "mixed-separators"  // 同一文件混用多种分隔符
+	LintFormatSwitch     = "format-switch"     // 换行分隔格式解析到一半改为逐行解析
+	LintCopyName         = "copy-name"         // 文件名像是误复制的副本
+	LintDuplicateFile    = "duplicate-file"    // 与另一个资源文件内容完全相同
+	LintHeader           = "header"            // 文件头无效或内容不符合声明的格式
+)
+
+// LintCodes 所有的问题类型
+var LintCodes = []string{
+	LintSpaceSplit, LintDuplicate, LintEmptyTranslation, LintMixedSeparators,
+	LintFormatSwitch, LintCopyName, LintDuplicateFile, LintHeader,
+}
+
+// LintIssue 资源检查发现的一个问题
+type LintIssue struct {
+	File    string `json:"file"`           // 资源标识（类型/文件夹/文件名）或文件路径
+	Line    int    `json:"line,omitempty"` // 行号（从 1 开始），0 表示针对整个文件
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// String 按"文件:行号: 类型: 说明"的格式输出，便于编辑器和 CI 定位
+func (i LintIssue) String() string {
+	location := i.File
+	if i.Line > 0 {
+		location = fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, i.Code, i.Message)
+}
+
+// LintReport 资源检查结果
+type LintReport struct {
+	Files  int         `json:"files"` // 检查的文件数
+	Issues []LintIssue `json:"issues"`
+}
+
+var (
+	// 误复制的文件名，如"words copy"、"words copy 2"、"words - 副本"、"words (1)"
+	copyNamePattern = regexp.MustCompile(`(?i)(?:[ _-]+copy(?:[ _]*\d+)?|[ _-]*副本\d*|[ _]*\(\d+\))$`)
+)
+
+// lintFile 待检查的文件
+type lintFile struct {
+	name string // 输出时使用的名称
+	path string
+}
+
+// LintResources 检查当前语言下的资源（包括内置资源和用户导入的资源）。
+// resourceType 为空时检查所有类型；identifier 不为空时只检查该资源。
+func LintResources(resourceType, identifier string) (*LintReport, error) {
+	resourceTypes := []string{Words, Phrases, Sentences, Articles}
+	if resourceType != "" {
+		if !ValidateResourceType(resourceType) {
+			return nil, fmt.Errorf("无效的资源类型: %s", resourceType)
+		}
+		resourceTypes = []string{resourceType}
+	}
+
+	report := &LintReport{}
+	for _, resourceType := range resourceTypes {
+		identifiers, err := GetResourceFiles(resourceType)
+		if err != nil {
+			return nil, err
+		}
+		if identifier != "" {
+			if !containsString(identifiers, identifier) {
+				return nil, fmt.Errorf("资源不存在: %s/%s", resourceType, identifier)
+			}
+			identifiers = []string{identifier}
+		}
+
+		files := make([]lintFile, 0, len(identifiers))
+		for _, id := range identifiers {
+			files = append(files, lintFile{name: resourceType + "/" + id, path: GetResourcePath(resourceType, id)})
+		}
+		if err := lintFiles(report, resourceType, files); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// LintPath 按 resourceType 的解析规则检查磁盘上的资源文件，
+// path 为目录时检查其中（包括子目录）所有的 .txt 文件，适合在 CI 中检查共享的课程仓库
+func LintPath(resourceType, path string) (*LintReport, error) {
+	if !ValidateResourceType(resourceType) {
+		return nil, fmt.Errorf("无效的资源类型: %s", resourceType)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取路径失败: %w", err)
+	}
+
+	var files []lintFile
+	if !info.IsDir() {
+		files = append(files, lintFile{name: path, path: path})
+	} else {
+		err = filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if filePath != path && strings.HasPrefix(entry.Name(), ".") {
+				if entry.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".txt") {
+				files = append(files, lintFile{name: filePath, path: filePath})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("读取目录失败: %w", err)
+		}
+	}
+
+	report := &LintReport{}
+	if err := lintFiles(report, resourceType, files); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// lintFiles 逐个检查文件，并找出内容完全相同的文件
+func lintFiles(report *LintReport, resourceType string, files []lintFile) error {
+	// 文件名像副本的排在后面，内容相同时把它们报告为重复的一方
+	sort.SliceStable(files, func(i, j int) bool {
+		copyI, copyJ := isCopyName(files[i].path), isCopyName(files[j].path)
+		if copyI != copyJ {
+			return copyJ
+		}
+		return files[i].name < files[j].name
+	})
+
+	seen := make(map[string]string, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file.path)
+		if err != nil {
+			return fmt.Errorf("读取资源文件失败: %w", err)
+		}
+		report.Files++
+
+		if isCopyName(file.path) {
+			report.add(file.name, 0, LintCopyName, "文件名像是误复制的副本，请确认后删除或重命名")
+		}
+		if trimmed := string(bytes.TrimSpace(data)); trimmed != "" {
+			if original, ok := seen[trimmed]; ok {
+				report.add(file.name, 0, LintDuplicateFile, "与 "+original+" 的内容完全相同")
+			} else {
+				seen[trimmed] = file.name
+			}
+		}
+
+		content, err := practice.ParseResource(resourceType, bytes.NewReader(data))
+		if err != nil {
+			report.add(file.name, 0, LintHeader, err.Error())
+			continue
+		}
+		lintContent(report, resourceType, file.name, content)
+	}
+	return nil
+}
+
+// lintContent 检查一个文件解析出的条目
+func lintContent(report *LintReport, resourceType, name string, content *practice.ResourceContent) {
+	if content.FallbackEntries > 0 {
+		report.add(name, content.FallbackLine, LintFormatSwitch, fmt.Sprintf(
+			"前 %d 个条目是换行分隔格式（原文、翻译各占一行），从这一行起不再符合，整个文件改为逐行解析",
+			content.FallbackEntries))
+	}
+
+	// 文章中的句子可能重复出现（如对话），不检查重复；文章常有没有翻译的整句外文，不检查翻译
+	checkDuplicates := resourceType != Articles
+	checkTranslations := resourceType != Articles
+	firstLine := make(map[string]int, len(content.Lines))
+	separatorLines := make(map[string][]int)
+	var separators []string
+
+	for i, line := range content.Lines {
+		lineNumber := content.LineNumbers[i]
+		term, translation := practice.ParseLine(line)
+		separator := practice.LineSeparator(line)
+		if resourceType == Articles {
+			// 与练习时一样按 practice.ArticleText 取正文，整行都是正文时没有分隔符
+			term, translation = practice.ArticleText(line)
+			if translation == "" && term == strings.TrimSpace(line) {
+				separator = practice.SeparatorNone
+			}
+		}
+		if separator == practice.SeparatorSpace && isStructuredEntry(line) {
+			// 原文后带音标或词性的词汇行（如文章中的生词表）按结构化条目解析，不算作空格分隔
+			separator = practice.SeparatorNone
+		}
+
+		if separator != practice.SeparatorNone {
+			if _, ok := separatorLines[separator]; !ok {
+				separators = append(separators, separator)
+			}
+			separatorLines[separator] = append(separatorLines[separator], lineNumber)
+		}
+
+		if separator == practice.SeparatorSpace && practice.LooksLikeSplitPhrase(term, translation) {
+			report.add(name, lineNumber, LintSpaceSplit, fmt.Sprintf(
+				"按第一个空格拆分为原文 %q 和翻译 %q，多个单词的原文请使用\" ->> \"或制表符分隔", term, translation))
+		}
+		if checkTranslations && strings.TrimSpace(translation) == "" {
+			report.add(name, lineNumber, LintEmptyTranslation, fmt.Sprintf("%q 没有翻译", strings.TrimSpace(line)))
+		}
+		if checkDuplicates {
+			key := practice.EntryKey(line)
+			if first, ok := firstLine[key]; ok {
+				report.add(name, lineNumber, LintDuplicate, fmt.Sprintf("%q 与第 %d 行重复", key, first))
+			} else {
+				firstLine[key] = lineNumber
+			}
+		}
+	}
+
+	if len(separators) > 1 {
+		// 以使用最多的分隔符为准，指出第一处使用其他分隔符的行
+		sort.SliceStable(separators, func(i, j int) bool {
+			return len(separatorLines[separators[i]]) > len(separatorLines[separators[j]])
+		})
+		counts := make([]string, 0, len(separators))
+		minorityLine := 0
+		for i, separator := range separators {
+			counts = append(counts, fmt.Sprintf("%s %d 行", separator, len(separatorLines[separator])))
+			if first := separatorLines[separator][0]; i > 0 && (minorityLine == 0 || first < minorityLine) {
+				minorityLine = first
+			}
+		}
+		report.add(name, minorityLine, LintMixedSeparators, "混用了多种分隔符（"+strings.Join(counts, "，")+"）")
+	}
+}
+
+// isStructuredEntry 判断按空格分隔的一行是否为原文后跟音标或词性的结构化条目。
+// 生词表中的条目可以是 Markdown 标题，原文后也可以先跟括号中的变化形式，
+// 例如"### boss /bɔs/ n. 老板"和"write (wrote, written) /raɪt/ v. 写"。
+func isStructuredEntry(line string) bool {
+	line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+	if term, rest, ok := strings.Cut(line, " "); ok && strings.HasPrefix(rest, "(") {
+		if end := strings.Index(rest, ")"); end >= 0 {
+			line = term + " " + strings.TrimSpace(rest[end+1:])
+		}
+	}
+	entry := practice.ParseEntry(line)
+	return entry.Phonetic != "" || entry.PartOfSpeech != ""
+}
+
+// isCopyName 判断文件名是否像误复制的副本
+func isCopyName(path string) bool {
+	return copyNamePattern.MatchString(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// Ignore 去掉指定类型的问题，无效的类型返回错误
+func (r *LintReport) Ignore(codes []string) error {
+	ignored := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if !containsString(LintCodes, code) {
+			return fmt.Errorf("未知的问题类型: %s（可选: %s）", code, strings.Join(LintCodes, ", "))
+		}
+		ignored[code] = true
+	}
+
+	issues := r.Issues[:0]
+	for _, issue := range r.Issues {
+		if !ignored[issue.Code] {
+			issues = append(issues, issue)
+		}
+	}
+	r.Issues = issues
+	return nil
+}
+
+// add 记录一个问题
+func (r *LintReport) add(file string, line int, code, message string) {
+	r.Issues = append(r.Issues, LintIssue{File: file, Line: line, Code: code, Message: message})
+}
+
+// containsString 判断列表中是否包含指定的字符串
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package manage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// 测试检查磁盘上的资源目录：各类问题都能报告到正确的行
+func TestLintPath(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "vocab.txt"),
+		"apple ->> 苹果\nthank you 谢谢\nbanana\napple ->> 苹果\ncherry ->> 樱桃\n")
+	writeTestFile(t, filepath.Join(dir, "unit1", "vocab copy.txt"),
+		"apple ->> 苹果\nthank you 谢谢\nbanana\napple ->> 苹果\ncherry ->> 樱桃\n")
+
+	report, err := LintPath(Words, dir)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	if report.Files != 2 {
+		t.Errorf("检查的文件数 = %d, want 2", report.Files)
+	}
+
+	vocab := filepath.Join(dir, "vocab.txt")
+	copied := filepath.Join(dir, "unit1", "vocab copy.txt")
+	want := map[LintIssue]bool{
+		{File: vocab, Line: 2, Code: LintSpaceSplit}:       true,
+		{File: vocab, Line: 3, Code: LintEmptyTranslation}: true,
+		{File: vocab, Line: 4, Code: LintDuplicate}:        true,
+		{File: vocab, Line: 2, Code: LintMixedSeparators}:  true,
+		{File: copied, Code: LintCopyName}:                 true,
+		{File: copied, Code: LintDuplicateFile}:            true,
+	}
+	got := map[LintIssue]bool{}
+	for _, issue := range report.Issues {
+		issue.Message = ""
+		got[issue] = true
+	}
+	for issue := range want {
+		if !got[issue] {
+			t.Errorf("缺少问题 %+v，实际 %+v", issue, report.Issues)
+		}
+	}
+
+	if err := report.Ignore([]string{LintSpaceSplit, LintDuplicateFile}); err != nil {
+		t.Fatalf("忽略问题类型失败: %v", err)
+	}
+	for _, issue := range report.Issues {
+		if issue.Code == LintSpaceSplit || issue.Code == LintDuplicateFile {
+			t.Errorf("忽略的问题仍然存在: %+v", issue)
+		}
+	}
+	if err := report.Ignore([]string{"typo"}); err == nil {
+		t.Error("未知的问题类型应返回错误")
+	}
+}
+
+// 测试换行分隔格式解析到一半改为逐行解析时报告放弃的位置
+func TestLintFormatSwitch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dialogue.txt")
+	writeTestFile(t, path, "Hello\n你好\n\nCan you make the tea? ->> 你能沏茶吗？\nYes, of course. ->> 当然可以。\n")
+
+	report, err := LintPath(Sentences, path)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Code == LintFormatSwitch {
+			found = issue.Line == 4
+		}
+	}
+	if !found {
+		t.Errorf("应在第 4 行报告格式切换，实际 %+v", report.Issues)
+	}
+
+	// 单词不尝试换行分隔格式
+	report, err = LintPath(Words, path)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	for _, issue := range report.Issues {
+		if issue.Code == LintFormatSwitch {
+			t.Errorf("单词资源不应报告格式切换: %+v", issue)
+		}
+	}
+}
+
+// 测试文件头：声明了分隔符的文件不报告按空格拆分，无效的文件头单独报告
+func TestLintHeader(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "phrases.txt"), "# mllt: separator=\" = \"\nlook into sth = 调查\nthank you = 谢谢\n")
+	writeTestFile(t, filepath.Join(dir, "broken.txt"), "# mllt: format=csv\nlook into sth ->> 调查\n")
+
+	report, err := LintPath(Phrases, dir)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Code != LintHeader || report.Issues[0].File != filepath.Join(dir, "broken.txt") {
+		t.Errorf("应只报告 broken.txt 的文件头问题，实际 %+v", report.Issues)
+	}
+}
+
+// 测试文章按练习时的规则取正文：没有翻译的整句外文不报告按空格拆分、缺少翻译和混用分隔符
+func TestLintArticles(t *testing.T) {
+	dir := t.TempDir()
+	lesson := filepath.Join(dir, "lesson.txt")
+	writeTestFile(t, lesson, "Excuse me! ->> 对不起！\nIs this your handbag?\nPardon? ->> 什么？\nThank you very much.\n")
+	mixed := filepath.Join(dir, "mixed.txt")
+	writeTestFile(t, mixed, "Excuse me! ->> 对不起！\nIs this your handbag?\nPardon?\t什么？\n")
+
+	report, err := LintPath(Articles, dir)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].File != mixed || report.Issues[0].Code != LintMixedSeparators || report.Issues[0].Line != 3 {
+		t.Errorf("应只报告 mixed.txt 第 3 行混用分隔符，实际 %+v", report.Issues)
+	}
+}
+
+// 测试文章中音标、词性齐全的生词条目不计为空格分隔，句子与生词表混排时不报告混用分隔符
+func TestLintArticleVocabulary(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "1-045_The_bosss_letter.txt"),
+		"Can you help me? ->> 你能帮我吗？\n\n"+
+			"### boss /bɔs/ n. 老板，上司\n"+
+			"My boss praised my hard work. ->> 我的老板表扬了我的努力工作。\n\n"+
+			"write (wrote, written) /raɪt/ v. 写\n"+
+			"portfolio /pɔːtˈfəʊliəʊ/ n. 投资组合\n"+
+			"She wrote a letter. ->> 她写了一封信。\n")
+
+	report, err := LintPath(Articles, dir)
+	if err != nil {
+		t.Fatalf("检查失败: %v", err)
+	}
+	if len(report.Issues) != 0 {
+		t.Errorf("不应报告问题，实际 %+v", report.Issues)
+	}
+}
@@ -142,8 +142,8 @@ func TestDeleteResource(t *testing.T) {
 	setupTest(t)
 	defer cleanupTest(t)
 
-	// 测试删除资源，使用测试专用的删除函数，不需要用户确认
-	err := DeleteResourceForTest(practice.Words, "test_manage_words.txt")
+	// 测试删除资源，不提供确认函数，删除时不需要用户确认
+	_, err := DeleteResource(practice.Words, "test_manage_words.txt", nil)
 	if err != nil {
 		t.Errorf("删除资源失败: %v", err)
 	}
@@ -162,8 +162,8 @@ func TestImportResource(t *testing.T) {
 
 	// 测试导入资源
 	importFilePath := filepath.Join(os.TempDir(), "import_test_words.txt")
-	// 使用测试专用的导入函数，不需要用户确认
-	err := ImportResourceForTest(practice.Words, testFolderName, importFilePath)
+	// 不提供确认函数，导入时不需要用户确认
+	_, err := ImportResource(practice.Words, testFolderName, importFilePath, ImportOptions{})
 	if err != nil {
 		t.Errorf("导入资源失败: %v", err)
 	}
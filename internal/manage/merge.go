@@ -0,0 +1,136 @@
+package manage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// 合并时原文相同但翻译不同的处理方式
+const (
+	MergeKeepLocal   = "local"    // 保留本地的翻译，不丢失个人修改
+	MergeUseIncoming = "incoming" // 使用导入文件中的翻译
+	MergeCombine     = "combine"  // 将导入的翻译追加到本地翻译之后
+)
+
+// MergePolicies 可选的冲突处理方式
+var MergePolicies = []string{MergeKeepLocal, MergeUseIncoming, MergeCombine}
+
+// MergePolicyNames 冲突处理方式的显示名称
+var MergePolicyNames = map[string]string{
+	MergeKeepLocal:   "保留本地",
+	MergeUseIncoming: "使用导入",
+	MergeCombine:     "合并翻译",
+}
+
+// MergeConflict 原文相同但翻译不同的一组条目
+type MergeConflict struct {
+	Term       string // 原文，即条目的键
+	Local      string // 本地的条目
+	Incoming   string // 导入文件中的条目
+	Resolution string // 采用的处理方式
+}
+
+// MergeReport 合并结果统计
+type MergeReport struct {
+	Added     int             // 本地没有的新条目
+	Updated   int             // 补充或修改了翻译的条目
+	Unchanged int             // 与本地相同或保留本地翻译的条目
+	Conflicts []MergeConflict // 翻译不同的条目及其处理方式
+}
+
+// Changed 判断合并是否修改了本地文件
+func (r MergeReport) Changed() bool {
+	return r.Added > 0 || r.Updated > 0
+}
+
+// SupportsMerge 判断资源类型是否支持按原文合并导入。
+// 句子和文章的原文是整句，按第一个空格拆分出的键会把不同的句子当作同一个条目，文章的行序也无法合并。
+func SupportsMerge(resourceType string) bool {
+	return resourceType == Words || resourceType == Phrases
+}
+
+// NormalizeMergePolicy 规范化冲突处理方式，为空时保留本地翻译，无效的值返回错误
+func NormalizeMergePolicy(policy string) (string, error) {
+	policy = strings.ToLower(strings.TrimSpace(policy))
+	if policy == "" {
+		return MergeKeepLocal, nil
+	}
+	for _, valid := range MergePolicies {
+		if policy == valid {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的冲突处理方式: %s（可选: %s）", policy, strings.Join(MergePolicies, ", "))
+}
+
+// MergeEntries 按原文合并本地条目和导入的条目（键与记忆计划相同，见 practice.EntryKey）。
+// 本地没有的条目追加到末尾；本地条目没有翻译时补上导入的翻译；
+// 翻译不同时优先使用 resolutions 中为该原文指定的处理方式，否则按 policy 处理。
+func MergeEntries(existing, incoming []string, policy string, resolutions map[string]string) ([]string, MergeReport) {
+	merged := make([]string, 0, len(existing)+len(incoming))
+	index := make(map[string]int, len(existing))
+	for _, line := range existing {
+		key := practice.EntryKey(line)
+		if _, ok := index[key]; !ok {
+			index[key] = len(merged)
+		}
+		merged = append(merged, line)
+	}
+
+	var report MergeReport
+	for _, line := range incoming {
+		key := practice.EntryKey(line)
+		position, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, line)
+			report.Added++
+			continue
+		}
+
+		local := merged[position]
+		_, localTranslation := practice.ParseLine(local)
+		_, incomingTranslation := practice.ParseLine(line)
+		localTranslation = strings.TrimSpace(localTranslation)
+		incomingTranslation = strings.TrimSpace(incomingTranslation)
+
+		switch {
+		case incomingTranslation == "" || incomingTranslation == localTranslation:
+			report.Unchanged++
+			continue
+		case localTranslation == "":
+			merged[position] = line
+			report.Updated++
+			continue
+		}
+
+		resolution := resolutions[key]
+		if resolution == "" {
+			resolution = policy
+		}
+		report.Conflicts = append(report.Conflicts, MergeConflict{
+			Term:       key,
+			Local:      local,
+			Incoming:   line,
+			Resolution: resolution,
+		})
+
+		switch resolution {
+		case MergeUseIncoming:
+			merged[position] = line
+			report.Updated++
+		case MergeCombine:
+			if practice.MatchTranslation(incomingTranslation, localTranslation, true) {
+				report.Unchanged++
+				continue
+			}
+			merged[position] = key + " ->> " + localTranslation + "；" + incomingTranslation
+			report.Updated++
+		default:
+			report.Unchanged++
+		}
+	}
+	return merged, report
+}
@@ -0,0 +1,156 @@
+package manage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// 测试按原文合并条目：新增、补充翻译、未变以及翻译不同时的各种处理方式
+func TestMergeEntries(t *testing.T) {
+	existing := []string{"apple ->> 苹果", "banana", "cherry ->> 樱桃", "durian ->> 榴莲"}
+	incoming := []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 车厘子", "durian", "egg ->> 鸡蛋"}
+
+	tests := []struct {
+		policy      string
+		resolutions map[string]string
+		want        []string
+		report      MergeReport
+	}{
+		{
+			policy: MergeKeepLocal,
+			want:   []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃", "durian ->> 榴莲", "egg ->> 鸡蛋"},
+			report: MergeReport{Added: 1, Updated: 1, Unchanged: 3},
+		},
+		{
+			policy: MergeUseIncoming,
+			want:   []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 车厘子", "durian ->> 榴莲", "egg ->> 鸡蛋"},
+			report: MergeReport{Added: 1, Updated: 2, Unchanged: 2},
+		},
+		{
+			policy: MergeCombine,
+			want:   []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃；车厘子", "durian ->> 榴莲", "egg ->> 鸡蛋"},
+			report: MergeReport{Added: 1, Updated: 2, Unchanged: 2},
+		},
+		{
+			// 单独指定的处理方式优先于整体策略
+			policy:      MergeKeepLocal,
+			resolutions: map[string]string{"cherry": MergeUseIncoming},
+			want:        []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 车厘子", "durian ->> 榴莲", "egg ->> 鸡蛋"},
+			report:      MergeReport{Added: 1, Updated: 2, Unchanged: 2},
+		},
+	}
+	for _, tt := range tests {
+		merged, report := MergeEntries(existing, incoming, tt.policy, tt.resolutions)
+		if !reflect.DeepEqual(merged, tt.want) {
+			t.Errorf("%s: 合并结果 = %q, want %q", tt.policy, merged, tt.want)
+		}
+		if report.Added != tt.report.Added || report.Updated != tt.report.Updated || report.Unchanged != tt.report.Unchanged {
+			t.Errorf("%s: 统计 = %+v, want %+v", tt.policy, report, tt.report)
+		}
+		if len(report.Conflicts) != 1 || report.Conflicts[0].Term != "cherry" || report.Conflicts[0].Local != "cherry ->> 樱桃" {
+			t.Errorf("%s: 冲突 = %+v, want cherry", tt.policy, report.Conflicts)
+		}
+	}
+
+	if _, err := NormalizeMergePolicy("theirs"); err == nil {
+		t.Error("无效的冲突处理方式应返回错误")
+	}
+}
+
+// 测试合并导入：试算不写入文件，确认后按策略写入并返回统计
+func TestImportResourceMerge(t *testing.T) {
+	sourceDir := setupImportTest(t)
+	source := filepath.Join(sourceDir, "shared.txt")
+	writeTestFile(t, source, "apple ->> 苹果\nbanana ->> 香蕉\n")
+	if _, err := ImportResource(Words, "", source, ImportOptions{}); err != nil {
+		t.Fatalf("导入失败: %v", err)
+	}
+
+	// 本地修改过的翻译与更新后的共享文件冲突
+	writeTestFile(t, GetResourcePath(Words, "shared"), "apple ->> 苹果（我的笔记）\nbanana ->> 香蕉\n")
+	writeTestFile(t, source, "apple ->> 苹果\nbanana ->> 香蕉\ncherry ->> 樱桃\n")
+
+	preview, err := ImportResource(Words, "", source, ImportOptions{Conflict: ConflictMerge, DryRun: true})
+	if err != nil {
+		t.Fatalf("试算合并失败: %v", err)
+	}
+	if preview.Action != ImportMerged || preview.Added != 1 || preview.Unchanged != 2 || len(preview.Conflicts) != 1 {
+		t.Errorf("试算结果 = %+v", preview)
+	}
+	if got := readResource(t, "shared"); got != "apple ->> 苹果（我的笔记）\nbanana ->> 香蕉\n" {
+		t.Errorf("试算不应修改文件，实际 %q", got)
+	}
+
+	// 默认保留本地翻译，只追加新条目
+	if _, err := ImportResource(Words, "", source, ImportOptions{Conflict: ConflictMerge}); err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	want := "apple ->> 苹果（我的笔记）\nbanana ->> 香蕉\ncherry ->> 樱桃\n"
+	if got := readResource(t, "shared"); got != want {
+		t.Errorf("合并后内容 = %q, want %q", got, want)
+	}
+
+	// 改为使用导入的翻译，覆盖本地修改
+	result, err := ImportResource(Words, "", source, ImportOptions{Conflict: ConflictMerge, MergePolicy: MergeUseIncoming})
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if result.Added != 0 || result.Updated != 1 || result.Unchanged != 2 {
+		t.Errorf("使用导入翻译的统计 = %+v", result.MergeReport)
+	}
+	if got := readResource(t, "shared"); got != "apple ->> 苹果\nbanana ->> 香蕉\ncherry ->> 樱桃\n" {
+		t.Errorf("使用导入翻译后内容 = %q", got)
+	}
+}
+
+// 测试合并按资源格式解析两边的条目：换行分隔格式和文件头声明的分隔符，句子不支持合并
+func TestImportResourceMergeFormats(t *testing.T) {
+	sourceDir := setupImportTest(t)
+
+	// 换行分隔格式的短语文件，原文和翻译不会被当作两个条目
+	pairPath := GetResourcePath(Phrases, "pair")
+	writeTestFile(t, pairPath, "look into sth\n调查\n\nfigure out\n弄清楚\n")
+	source := filepath.Join(sourceDir, "pair.txt")
+	writeTestFile(t, source, "figure out ->> 想出\ngive up ->> 放弃\n")
+	result, err := ImportResource(Phrases, "", source, ImportOptions{Conflict: ConflictMerge})
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if result.Added != 1 || len(result.Conflicts) != 1 || result.Conflicts[0].Term != "figure out" {
+		t.Errorf("换行分隔格式的合并结果 = %+v", result.MergeReport)
+	}
+	if got, _ := os.ReadFile(pairPath); string(got) != "look into sth ->> 调查\nfigure out ->> 弄清楚\ngive up ->> 放弃\n" {
+		t.Errorf("合并后内容 = %q", got)
+	}
+
+	// 文件头声明的分隔符：按声明拆分原文，写回时统一为" ->> "并保留元数据
+	headerPath := GetResourcePath(Phrases, "header")
+	writeTestFile(t, headerPath, "# mllt: separator=\" - \" title=\"常用 短语\"\nlook into sth - 调查\nlook after - 照顾\n")
+	source = filepath.Join(sourceDir, "header.txt")
+	writeTestFile(t, source, "look into sth ->> 调查\nlook up ->> 查找\n")
+	result, err = ImportResource(Phrases, "", source, ImportOptions{Conflict: ConflictMerge})
+	if err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if result.Added != 1 || result.Unchanged != 1 || len(result.Conflicts) != 0 {
+		t.Errorf("文件头格式的合并结果 = %+v", result.MergeReport)
+	}
+	want := "# mllt: separator=\" ->> \" title=\"常用 短语\"\nlook into sth ->> 调查\nlook after ->> 照顾\nlook up ->> 查找\n"
+	if got, _ := os.ReadFile(headerPath); string(got) != want {
+		t.Errorf("合并后内容 = %q, want %q", got, want)
+	}
+
+	// 句子按第一个空格拆分的键会把不同的句子当作同一条，不支持合并
+	sentencePath := GetResourcePath(Sentences, "daily")
+	writeTestFile(t, sentencePath, "I like apples.\n")
+	source = filepath.Join(sourceDir, "daily.txt")
+	writeTestFile(t, source, "I am here.\n")
+	if _, err := ImportResource(Sentences, "", source, ImportOptions{Conflict: ConflictMerge}); err == nil {
+		t.Error("句子合并导入应返回错误")
+	}
+	if got, _ := os.ReadFile(sentencePath); string(got) != "I like apples.\n" {
+		t.Errorf("拒绝合并时不应修改文件: %q", got)
+	}
+}
@@ -0,0 +1,241 @@
+package mistakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// ListName 错题本在练习界面中显示的名称
+const ListName = "错题本"
+
+const (
+	// maxAttempts 每个条目最多保留的作答记录数
+	maxAttempts = 50
+	// recentWindow 计算错误率时只统计最近这段时间内的作答
+	recentWindow = 30 * 24 * time.Hour
+	// masteredStreak 最近连续答对这么多次后，条目不再出现在错题本中
+	masteredStreak = 3
+)
+
+// Attempt 记录一次答错的详情
+type Attempt struct {
+	At       time.Time `json:"at"`
+	FileName string    `json:"file_name"`
+	Expected string    `json:"expected"`
+	Typed    string    `json:"typed"`
+}
+
+// ItemLog 记录一个条目的答错详情和答对时间
+type ItemLog struct {
+	Item      string      `json:"item"`      // 资源文件中的原始行
+	FileName  string      `json:"file_name"` // 最近一次练习该条目时所在的资源文件
+	Mistakes  []Attempt   `json:"mistakes"`
+	Successes []time.Time `json:"successes,omitempty"`
+}
+
+// WeakItem 表示错题本中的一个条目及其近期错误情况
+type WeakItem struct {
+	Item        string
+	FileName    string
+	Wrong       int       // 近期答错次数
+	Attempts    int       // 近期作答次数（答错 + 答对）
+	ErrorRate   float64   // 近期错误率，0~1
+	LastMistake time.Time // 最近一次答错的时间
+	LastTyped   string    // 最近一次答错时的输入
+}
+
+// Log 某种资源类型的错题记录
+type Log struct {
+	Items    map[string]ItemLog `json:"items"`
+	filePath string
+}
+
+// Path 返回某种语言下某类资源的错题记录文件
+func Path(language, resourceType string) string {
+	return filepath.Join(practice.GetUserDataDir(), "mistakes", language, resourceType+".json")
+}
+
+// Load 加载当前语言下指定资源类型的错题记录
+func Load(resourceType string) (*Log, error) {
+	filePath := Path(config.AppConfig.CurrentLanguage, resourceType)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("创建错题目录失败: %w", err)
+	}
+
+	log := &Log{
+		Items:    make(map[string]ItemLog),
+		filePath: filePath,
+	}
+
+	data, err := os.ReadFile(log.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return log, nil
+		}
+		return nil, fmt.Errorf("读取错题文件失败: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, log); err != nil {
+			return nil, fmt.Errorf("解析错题文件失败: %w", err)
+		}
+	}
+	if log.Items == nil {
+		log.Items = make(map[string]ItemLog)
+	}
+	return log, nil
+}
+
+// Save 将错题记录写回磁盘
+func (l *Log) Save() error {
+	if l == nil || l.filePath == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.filePath, data, 0644)
+}
+
+// RecordMistake 记录一次答错
+func RecordMistake(resourceType, fileName, item, expected, typed string) error {
+	log, err := Load(resourceType)
+	if err != nil {
+		return err
+	}
+	log.addMistake(fileName, item, expected, typed, time.Now())
+	return log.Save()
+}
+
+// RecordSuccess 记录一次答对。只更新已有错题记录的条目，从未答错的条目不会写入。
+func RecordSuccess(resourceType, fileName, item string) error {
+	log, err := Load(resourceType)
+	if err != nil {
+		return err
+	}
+	if !log.addSuccess(fileName, item, time.Now()) {
+		return nil
+	}
+	return log.Save()
+}
+
+// WeakestItems 返回指定资源类型下近期错误率最高的条目，limit <= 0 时返回全部
+func WeakestItems(resourceType string, limit int) ([]WeakItem, error) {
+	log, err := Load(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	return log.Weakest(limit, time.Now()), nil
+}
+
+func (l *Log) addMistake(fileName, item, expected, typed string, now time.Time) {
+	key := itemKey(item)
+	entry := l.Items[key]
+	entry.Item = strings.TrimSpace(item)
+	entry.FileName = fileName
+	entry.Mistakes = append(entry.Mistakes, Attempt{
+		At:       now,
+		FileName: fileName,
+		Expected: expected,
+		Typed:    typed,
+	})
+	if len(entry.Mistakes) > maxAttempts {
+		entry.Mistakes = entry.Mistakes[len(entry.Mistakes)-maxAttempts:]
+	}
+	l.Items[key] = entry
+}
+
+func (l *Log) addSuccess(fileName, item string, now time.Time) bool {
+	key := itemKey(item)
+	entry, ok := l.Items[key]
+	if !ok {
+		return false
+	}
+	entry.FileName = fileName
+	entry.Successes = append(entry.Successes, now)
+	if len(entry.Successes) > maxAttempts {
+		entry.Successes = entry.Successes[len(entry.Successes)-maxAttempts:]
+	}
+	l.Items[key] = entry
+	return true
+}
+
+// Weakest 按近期错误率从高到低排列错题，错误率相同时答错次数多、最近答错的排在前面
+func (l *Log) Weakest(limit int, now time.Time) []WeakItem {
+	since := now.Add(-recentWindow)
+	items := make([]WeakItem, 0, len(l.Items))
+
+	for _, entry := range l.Items {
+		if entry.mastered() {
+			continue
+		}
+
+		weak := WeakItem{Item: entry.Item, FileName: entry.FileName}
+		for _, mistake := range entry.Mistakes {
+			if mistake.At.Before(since) {
+				continue
+			}
+			weak.Wrong++
+			if mistake.At.After(weak.LastMistake) {
+				weak.LastMistake = mistake.At
+				weak.LastTyped = mistake.Typed
+			}
+		}
+		if weak.Wrong == 0 {
+			continue
+		}
+
+		successes := 0
+		for _, at := range entry.Successes {
+			if !at.Before(since) {
+				successes++
+			}
+		}
+		weak.Attempts = weak.Wrong + successes
+		weak.ErrorRate = float64(weak.Wrong) / float64(weak.Attempts)
+		items = append(items, weak)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].ErrorRate != items[j].ErrorRate {
+			return items[i].ErrorRate > items[j].ErrorRate
+		}
+		if items[i].Wrong != items[j].Wrong {
+			return items[i].Wrong > items[j].Wrong
+		}
+		return items[i].LastMistake.After(items[j].LastMistake)
+	})
+
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// mastered 判断条目最近是否已连续答对 masteredStreak 次
+func (e ItemLog) mastered() bool {
+	if len(e.Mistakes) == 0 {
+		return true
+	}
+	lastMistake := e.Mistakes[len(e.Mistakes)-1].At
+	streak := 0
+	for _, at := range e.Successes {
+		if at.After(lastMistake) {
+			streak++
+		}
+	}
+	return streak >= masteredStreak
+}
+
+// itemKey 使用条目的正文部分作为键，与记忆计划保持一致
+func itemKey(item string) string {
+	return practice.EntryKey(item)
+}
@@ -0,0 +1,55 @@
+package mistakes
+
+import (
+	"testing"
+	"time"
+)
+
+// 测试按近期错误率排列错题
+func TestWeakestOrdersByErrorRate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	log := &Log{Items: make(map[string]ItemLog)}
+
+	// apple：答错 2 次、答对 2 次，错误率 50%
+	log.addMistake("fruits", "apple ->> 苹果", "apple", "aple", now.Add(-4*time.Hour))
+	log.addMistake("fruits", "apple ->> 苹果", "apple", "appel", now.Add(-3*time.Hour))
+	log.addSuccess("fruits", "apple ->> 苹果", now.Add(-2*time.Hour))
+	log.addSuccess("fruits", "apple ->> 苹果", now.Add(-time.Hour))
+
+	// banana：答错 1 次，错误率 100%
+	log.addMistake("fruits", "banana ->> 香蕉", "banana", "banan", now.Add(-time.Hour))
+
+	// cherry：很久以前答错，不计入近期
+	log.addMistake("fruits", "cherry ->> 樱桃", "cherry", "chery", now.Add(-60*24*time.Hour))
+
+	// 从未答错的条目不会因为答对而写入
+	if log.addSuccess("fruits", "grape ->> 葡萄", now) {
+		t.Error("从未答错的条目不应记录答对")
+	}
+
+	weak := log.Weakest(0, now)
+	if len(weak) != 2 {
+		t.Fatalf("期望 2 个错题，实际 %d: %+v", len(weak), weak)
+	}
+	if weak[0].Item != "banana ->> 香蕉" || weak[0].ErrorRate != 1 {
+		t.Errorf("错误率最高的应为 banana: %+v", weak[0])
+	}
+	if weak[1].Item != "apple ->> 苹果" || weak[1].ErrorRate != 0.5 || weak[1].LastTyped != "appel" {
+		t.Errorf("apple 的错题统计不正确: %+v", weak[1])
+	}
+}
+
+// 测试连续答对后条目移出错题本
+func TestWeakestSkipsMasteredItems(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	log := &Log{Items: make(map[string]ItemLog)}
+
+	log.addMistake("fruits", "apple", "apple", "aple", now.Add(-time.Hour))
+	for i := 0; i < masteredStreak; i++ {
+		log.addSuccess("fruits", "apple", now.Add(time.Duration(i)*time.Minute))
+	}
+
+	if weak := log.Weakest(0, now.Add(time.Hour)); len(weak) != 0 {
+		t.Errorf("连续答对 %d 次后不应再出现在错题本中: %+v", masteredStreak, weak)
+	}
+}
@@ -0,0 +1,138 @@
+package practice
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 随机挖空时优先选择的最短单词长度，太短的虚词挖空意义不大
+const minClozeWordLength = 3
+
+// ClozeBlank 填空题中的一个空
+type ClozeBlank struct {
+	Start  int    // 单词在原文中的起始字节位置
+	End    int    // 单词在原文中的结束字节位置（不含）
+	Answer string // 挖去的单词
+}
+
+// SupportsCloze 判断资源类型是否支持填空练习，单词和短语本身太短，只有句子和文章支持
+func SupportsCloze(resourceType string) bool {
+	return resourceType == Sentences || resourceType == Articles
+}
+
+// PickClozeBlanks 从文本中选择 count 个单词挖空，按在文本中的位置排序。
+// vocabulary 不为空时优先选择词表中的单词（不区分大小写），文本中没有词表中的单词时随机选择；
+// 随机选择时优先选择不少于 3 个字符的单词，不挖纯数字。
+// 至少保留一个单词不挖空，文本少于两个单词时返回 nil。
+func PickClozeBlanks(text string, count int, vocabulary map[string]bool, rng *rand.Rand) []ClozeBlank {
+	words := clozeWords(text)
+	if len(words) < 2 || count < 1 {
+		return nil
+	}
+
+	candidates := make([]ClozeBlank, 0, len(words))
+	for _, word := range words {
+		if strings.IndexFunc(word.Answer, unicode.IsLetter) >= 0 {
+			candidates = append(candidates, word)
+		}
+	}
+	if len(vocabulary) > 0 {
+		if known := filterClozeWords(candidates, func(word ClozeBlank) bool {
+			return vocabulary[strings.ToLower(word.Answer)]
+		}); len(known) > 0 {
+			candidates = known
+		}
+	}
+	if long := filterClozeWords(candidates, func(word ClozeBlank) bool {
+		return utf8.RuneCountInString(word.Answer) >= minClozeWordLength
+	}); len(long) > 0 {
+		candidates = long
+	}
+
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	if count > len(words)-1 {
+		count = len(words) - 1
+	}
+	blanks := make([]ClozeBlank, 0, count)
+	for _, index := range rng.Perm(len(candidates))[:count] {
+		blanks = append(blanks, candidates[index])
+	}
+	sort.Slice(blanks, func(i, j int) bool { return blanks[i].Start < blanks[j].Start })
+	return blanks
+}
+
+// filterClozeWords 返回满足条件的单词
+func filterClozeWords(words []ClozeBlank, keep func(ClozeBlank) bool) []ClozeBlank {
+	var filtered []ClozeBlank
+	for _, word := range words {
+		if keep(word) {
+			filtered = append(filtered, word)
+		}
+	}
+	return filtered
+}
+
+// clozeWords 返回文本中的单词：连续的字母或数字，单词内部可以有撇号和连字符，如 don't、well-known
+func clozeWords(text string) []ClozeBlank {
+	isWordRune := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+	}
+
+	var words []ClozeBlank
+	start := -1
+	for i, r := range text {
+		switch {
+		case isWordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0 && (r == '\'' || r == '’' || r == '-'):
+			// 撇号和连字符后面紧跟字母时属于单词内部
+			next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+			if !isWordRune(next) {
+				words = append(words, ClozeBlank{Start: start, End: i, Answer: text[start:i]})
+				start = -1
+			}
+		default:
+			if start >= 0 {
+				words = append(words, ClozeBlank{Start: start, End: i, Answer: text[start:i]})
+				start = -1
+			}
+		}
+	}
+	if start >= 0 {
+		words = append(words, ClozeBlank{Start: start, End: len(text), Answer: text[start:]})
+	}
+	return words
+}
+
+// ClozeVocabulary 返回当前语言下单词资源中的全部单词（小写），用于选择挖空的单词。
+// 只收录不含空格的原文，读取失败的文件会被跳过。
+func ClozeVocabulary() map[string]bool {
+	vocabulary := make(map[string]bool)
+	folders, err := GetResourceFolders(Words)
+	if err != nil {
+		return vocabulary
+	}
+	for _, folder := range folders {
+		for _, file := range folder.Files {
+			items, err := ReadResourceFile(Words, BuildResourceIdentifier(folder.DirName, file))
+			if err != nil {
+				continue
+			}
+			for _, item := range items {
+				term, _ := ParseLine(item)
+				term = strings.ToLower(strings.TrimSpace(term))
+				if term != "" && !strings.ContainsFunc(term, unicode.IsSpace) {
+					vocabulary[term] = true
+				}
+			}
+		}
+	}
+	return vocabulary
+}
@@ -0,0 +1,61 @@
+package practice
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// 测试单词切分：撇号和连字符在单词内部时属于单词，标点不属于单词
+func TestClozeWords(t *testing.T) {
+	text := "Don't worry, it's a well-known 'café' - 42!"
+	want := []string{"Don't", "worry", "it's", "a", "well-known", "café", "42"}
+
+	words := clozeWords(text)
+	if len(words) != len(want) {
+		t.Fatalf("单词 = %+v", words)
+	}
+	for i, word := range words {
+		if word.Answer != want[i] || text[word.Start:word.End] != want[i] {
+			t.Errorf("第 %d 个单词 = %+v, want %q", i+1, word, want[i])
+		}
+	}
+}
+
+// 测试随机挖空：优先挖较长的单词，不挖数字，至少保留一个单词，按位置排序
+func TestPickClozeBlanks(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	text := "I have 2 cats and a dog."
+
+	blanks := PickClozeBlanks(text, 10, nil, rng)
+	if len(blanks) != 4 {
+		t.Fatalf("挖空 = %+v", blanks)
+	}
+	for i, want := range []string{"have", "cats", "and", "dog"} {
+		if blanks[i].Answer != want {
+			t.Errorf("第 %d 个空 = %q, want %q", i+1, blanks[i].Answer, want)
+		}
+	}
+
+	if blanks := PickClozeBlanks("Hello!", 1, nil, rng); blanks != nil {
+		t.Errorf("只有一个单词时不应挖空: %+v", blanks)
+	}
+	if blanks := PickClozeBlanks("Go on", 3, nil, rng); len(blanks) != 1 {
+		t.Errorf("至少应保留一个单词: %+v", blanks)
+	}
+}
+
+// 测试从词表中选择挖空的单词，句子中没有词表中的单词时随机选择
+func TestPickClozeBlanksVocabulary(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	vocabulary := map[string]bool{"umbrella": true, "coat": true}
+
+	for i := 0; i < 5; i++ {
+		blanks := PickClozeBlanks("My Coat and my umbrella, please.", 1, vocabulary, rng)
+		if len(blanks) != 1 || (blanks[0].Answer != "Coat" && blanks[0].Answer != "umbrella") {
+			t.Fatalf("应挖去词表中的单词: %+v", blanks)
+		}
+	}
+	if blanks := PickClozeBlanks("Thank you very much.", 1, vocabulary, rng); len(blanks) != 1 {
+		t.Errorf("没有词表中的单词时应随机挖空: %+v", blanks)
+	}
+}
@@ -2,7 +2,10 @@ package practice
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -126,7 +129,8 @@ func FolderDisplayName(dir string) string {
 	return dir
 }
 
-func splitResourceIdentifier(name string) (string, string) {
+// SplitResourceIdentifier 将资源标识拆分为文件夹和文件名
+func SplitResourceIdentifier(name string) (string, string) {
 	trimmed := strings.TrimSpace(name)
 	trimmed = strings.ReplaceAll(trimmed, "\\", "/")
 	trimmed = strings.TrimSuffix(trimmed, ".txt")
@@ -158,7 +162,7 @@ func BuildResourceIdentifier(folderDir, fileName string) string {
 }
 
 func FormatResourceDisplayName(identifier string) string {
-	folderDir, fileName := splitResourceIdentifier(identifier)
+	folderDir, fileName := SplitResourceIdentifier(identifier)
 	displayFolder := FolderDisplayName(folderDir)
 	if fileName == "" {
 		return displayFolder
@@ -171,7 +175,7 @@ func FormatResourceDisplayName(identifier string) string {
 
 func GetResourcePath(resourceType string, fileName string) string {
 	currentLanguage := config.AppConfig.CurrentLanguage
-	folderDir, baseName := splitResourceIdentifier(fileName)
+	folderDir, baseName := SplitResourceIdentifier(fileName)
 	if baseName == "" {
 		baseName = folderDir
 		folderDir = DefaultFolderDir
@@ -254,6 +258,16 @@ func isTestEnvironment() bool {
 	return strings.HasSuffix(base, ".test")
 }
 
+// GetFolderPaths 返回资源文件夹在用户数据目录和内置资源目录中的路径，用户数据目录在前
+func GetFolderPaths(resourceType, folderDir string) []string {
+	currentLanguage := config.AppConfig.CurrentLanguage
+	folderDir = normalizeFolderDir(folderDir)
+	return []string{
+		filepath.Join(getUserDataBaseDir(), currentLanguage, resourceType, folderDir),
+		filepath.Join(getResourceBaseDir(), currentLanguage, resourceType, folderDir),
+	}
+}
+
 // GetResourceFiles 获取指定类型的资源文件列表
 func GetResourceFolders(resourceType string) ([]ResourceFolder, error) {
 	currentLanguage := config.AppConfig.CurrentLanguage
@@ -280,7 +294,8 @@ func GetResourceFolders(resourceType string) ([]ResourceFolder, error) {
 					return err
 				}
 				for _, f := range files {
-					if f.IsDir() {
+					// 文件夹中还可能有课程清单（course.json）等非资源文件
+					if f.IsDir() || !strings.HasSuffix(f.Name(), ".txt") {
 						continue
 					}
 					fname := strings.TrimSuffix(f.Name(), ".txt")
@@ -393,29 +408,99 @@ func ReadResourceFile(resourceType string, fileName string) ([]string, error) {
 	}
 	defer file.Close()
 
-	// 对于非单词类型的资源，尝试解析换行符分隔格式
-	if resourceType != Words {
-		if lines, err := parseNewlineFormat(file); err == nil && len(lines) > 0 {
-			return lines, nil
+	content, err := ParseResource(resourceType, file)
+	if err != nil {
+		return nil, err
+	}
+	return content.Lines, nil
+}
+
+// ResourceContent 资源文件的解析结果
+type ResourceContent struct {
+	// 解析出的条目
+	Lines []string
+	// 每个条目在文件中的行号（从 1 开始）
+	LineNumbers []int
+	// 文件头声明的格式和元数据，没有文件头时为 nil
+	Header *ResourceHeader
+	// 是否按换行符分隔格式（原文、翻译各占一行，空行分隔条目）解析
+	NewlineFormat bool
+	// 换行符分隔格式解析到第几行时放弃并回退到逐行解析，0 表示没有回退
+	FallbackLine int
+	// 放弃之前已按换行符分隔格式解析出的条目数
+	FallbackEntries int
+}
+
+// ParseResource 解析资源文件内容。
+// 文件第一行可以是"# mllt: ..."文件头（见 ResourceHeader），声明了格式和分隔符时按声明解析；
+// 否则除单词外的资源先尝试换行符分隔格式，不符合时回退到逐行解析；只声明了分隔符时条目都在同一行，不再尝试换行符分隔格式。
+func ParseResource(resourceType string, r io.Reader) (*ResourceContent, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取文件失败: %w", err)
+	}
+
+	// 文件头占第一行，之后的行号都要加上它
+	firstLine, body, _ := bytes.Cut(data, []byte("\n"))
+	header, err := ParseResourceHeader(string(firstLine))
+	if err != nil {
+		return nil, err
+	}
+	headerLines := 0
+	if header != nil {
+		headerLines = 1
+	} else {
+		body = data
+	}
+
+	content := &ResourceContent{Header: header}
+	format := ""
+	if header != nil {
+		format = header.Format
+		if format == "" && header.Separator != "" {
+			format = FormatLine
+		}
+	}
+	if format == FormatPair || (format == "" && resourceType != Words) {
+		parsed, err := parseNewlineFormat(bytes.NewReader(body))
+		if err == nil && len(parsed.Lines) > 0 {
+			parsed.Header = header
+			for i := range parsed.LineNumbers {
+				parsed.LineNumbers[i] += headerLines
+			}
+			return parsed, nil
+		}
+		var formatErr *newlineFormatError
+		if errors.As(err, &formatErr) && formatErr.line > 0 {
+			content.FallbackLine = formatErr.line + headerLines
+			content.FallbackEntries = formatErr.entries
+		}
+		if format == FormatPair {
+			return nil, fmt.Errorf("第 %d 行不符合文件头声明的 format=pair: %w", content.FallbackLine, err)
 		}
 		// 如果解析换行符格式失败，回退到原来的逐行解析
-		file.Seek(0, 0) // 重置文件指针
 	}
 
-	var lines []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	lineNumber := headerLines
 	for scanner.Scan() {
+		lineNumber++
 		line := scanner.Text()
-		if line != "" {
-			lines = append(lines, line)
+		if line == "" {
+			continue
+		}
+		if header != nil {
+			line = header.normalizeLine(line)
 		}
+		content.Lines = append(content.Lines, line)
+		content.LineNumbers = append(content.LineNumbers, lineNumber)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("读取文件失败: %w", err)
 	}
 
-	return lines, nil
+	return content, nil
 }
 
 // WriteResourceFile 将内容写入资源文件（覆盖写入）
@@ -448,7 +533,7 @@ func WriteResourceFile(resourceType string, fileName string, lines []string) err
 
 func resolveReadableResourcePath(resourceType, fileName string) (string, error) {
 	currentLanguage := config.AppConfig.CurrentLanguage
-	folderDir, baseName := splitResourceIdentifier(fileName)
+	folderDir, baseName := SplitResourceIdentifier(fileName)
 	if baseName == "" {
 		baseName = folderDir
 		folderDir = DefaultFolderDir
@@ -499,7 +584,7 @@ func resolveReadableResourcePath(resourceType, fileName string) (string, error)
 
 func getWritableResourcePath(resourceType, fileName string) string {
 	currentLanguage := config.AppConfig.CurrentLanguage
-	folderDir, baseName := splitResourceIdentifier(fileName)
+	folderDir, baseName := SplitResourceIdentifier(fileName)
 	if baseName == "" {
 		baseName = folderDir
 		folderDir = DefaultFolderDir
@@ -518,67 +603,82 @@ func getWritableResourcePath(resourceType, fileName string) string {
 	return filepath.Join(dir, baseName)
 }
 
+// newlineFormatError 表示文件不符合换行符分隔格式，记录放弃解析的位置
+type newlineFormatError struct {
+	line    int // 放弃解析的行号，0 表示整个文件都没有内容
+	entries int // 放弃之前已解析出的条目数
+	message string
+}
+
+func (e *newlineFormatError) Error() string {
+	return e.message
+}
+
 // parseNewlineFormat 解析换行符分隔格式的资源文件
 // 格式：原文\n翻译\n\n（空行分隔不同的条目）
-func parseNewlineFormat(file *os.File) ([]string, error) {
-	var lines []string
+func parseNewlineFormat(r io.Reader) (*ResourceContent, error) {
+	content := &ResourceContent{NewlineFormat: true}
 	var currentOriginal string
 	var currentTranslation string
+	var originalLine int
 	var lineCount int
 
-	scanner := bufio.NewScanner(file)
+	// 保存当前条目，没有翻译时只保存原文
+	flush := func() {
+		if currentOriginal == "" {
+			return
+		}
+		if currentTranslation != "" {
+			content.Lines = append(content.Lines, currentOriginal+" ->> "+currentTranslation)
+		} else {
+			content.Lines = append(content.Lines, currentOriginal)
+		}
+		content.LineNumbers = append(content.LineNumbers, originalLine)
+		currentOriginal = ""
+		currentTranslation = ""
+	}
+	fail := func(message string) error {
+		return &newlineFormatError{line: lineCount, entries: len(content.Lines), message: message}
+	}
+
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		lineCount++
 
 		if line == "" {
 			// 遇到空行，如果有当前条目，则保存
-			if currentOriginal != "" {
-				if currentTranslation != "" {
-					// 有翻译的情况
-					lines = append(lines, currentOriginal+" ->> "+currentTranslation)
-				} else {
-					// 没有翻译的情况
-					lines = append(lines, currentOriginal)
-				}
-				currentOriginal = ""
-				currentTranslation = ""
-			}
+			flush()
 		} else if currentOriginal == "" {
 			if containsInlineSeparator(line) {
-				return nil, fmt.Errorf("检测到行内分隔符，回退到逐行解析")
+				return nil, fail("检测到行内分隔符，回退到逐行解析")
 			}
 			// 第一行是原文
 			currentOriginal = line
+			originalLine = lineCount
 		} else if currentTranslation == "" {
 			// 第二行是翻译
 			currentTranslation = line
 		} else {
 			// 如果已经有原文和翻译，但还有内容，说明格式不对
 			// 回退到普通格式解析
-			return nil, fmt.Errorf("格式不符合换行符分隔规则")
+			return nil, fail("格式不符合换行符分隔规则")
 		}
 	}
 
 	// 处理文件末尾的条目
-	if currentOriginal != "" {
-		if currentTranslation != "" {
-			lines = append(lines, currentOriginal+" ->> "+currentTranslation)
-		} else {
-			lines = append(lines, currentOriginal)
-		}
-	}
+	flush()
 
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
 
 	// 如果没有解析到任何内容，或者格式不符合要求，返回错误
-	if len(lines) == 0 {
-		return nil, fmt.Errorf("没有找到符合换行符分隔格式的内容")
+	if len(content.Lines) == 0 {
+		return nil, &newlineFormatError{message: "没有找到符合换行符分隔格式的内容"}
 	}
 
-	return lines, nil
+	return content, nil
 }
 
 func containsInlineSeparator(line string) bool {
@@ -589,28 +689,67 @@ func containsInlineSeparator(line string) bool {
 }
 
 // ParseLine 解析行内容，返回原文和翻译
-// 支持多种分隔符，按优先级顺序：" ->> ", 制表符, 空格, "/", ":", "："
+// 支持多种分隔符，按优先级顺序：" ->> ", 制表符, 空格, "/", ":", "："；
+// 用" | "分隔的多字段格式（见 ParseEntry）返回原文和拼接后的翻译
 func ParseLine(line string) (string, string) {
+	if isFieldLine(line) {
+		entry := parseFieldEntry(line)
+		return entry.Term, entry.Translation()
+	}
+	return parseInlineLine(line)
+}
+
+// 单行格式的分隔符名称，见 LineSeparator
+const (
+	SeparatorArrow = "->>"
+	SeparatorTab   = "tab"
+	SeparatorSpace = "space"
+	SeparatorField = "|"
+	SeparatorNone  = ""
+)
+
+// LineSeparator 返回 ParseLine 解析该行时使用的分隔符，
+// 斜杠和冒号返回其本身，没有分隔符时返回 SeparatorNone
+func LineSeparator(line string) string {
+	if isFieldLine(line) {
+		return SeparatorField
+	}
+	_, _, separator := splitInlineLine(line)
+	return separator
+}
+
+// parseInlineLine 按单行分隔符解析原文和翻译
+func parseInlineLine(line string) (string, string) {
+	term, translation, _ := splitInlineLine(line)
+	return term, translation
+}
+
+// splitInlineLine 按单行分隔符拆分原文和翻译，同时返回使用的分隔符
+func splitInlineLine(line string) (string, string, string) {
 	// 首先检查 " ->> " 分隔符
 	if strings.Contains(line, " ->> ") {
 		parts := strings.SplitN(line, " ->> ", 2)
 		if len(parts) == 2 {
-			return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+			return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), SeparatorArrow
 		}
 	}
+	// 没有翻译的条目可以写作"原文 ->>"，例如文件头声明了分隔符时
+	if trimmed := strings.TrimRight(line, " \t"); strings.HasSuffix(trimmed, " ->>") {
+		return strings.TrimSpace(strings.TrimSuffix(trimmed, " ->>")), "", SeparatorArrow
+	}
 
 	// 然后检查制表符分隔符
 	// 第一个制表符之前的文本作为原文，之后的作为翻译
 	tabIndex := strings.Index(line, "\t")
 	if tabIndex > 0 {
-		return strings.TrimSpace(line[:tabIndex]), strings.TrimSpace(line[tabIndex+1:])
+		return strings.TrimSpace(line[:tabIndex]), strings.TrimSpace(line[tabIndex+1:]), SeparatorTab
 	}
 
 	// 然后检查空格分隔符
 	// 第一个空格之前的文本作为原文，之后的作为翻译
 	spaceIndex := strings.Index(line, " ")
 	if spaceIndex > 0 {
-		return strings.TrimSpace(line[:spaceIndex]), strings.TrimSpace(line[spaceIndex+1:])
+		return strings.TrimSpace(line[:spaceIndex]), strings.TrimSpace(line[spaceIndex+1:]), SeparatorSpace
 	}
 
 	// 定义其他分隔符优先级列表
@@ -621,13 +760,13 @@ func ParseLine(line string) (string, string) {
 		if strings.Contains(line, sep) {
 			parts := strings.SplitN(line, sep, 2)
 			if len(parts) == 2 {
-				return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+				return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), sep
 			}
 		}
 	}
 
 	// 如果没有找到任何分隔符，整行作为原文，翻译为空
-	return line, ""
+	return line, "", SeparatorNone
 }
 
 // GetNextIndex 获取下一个索引
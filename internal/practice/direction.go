@@ -0,0 +1,109 @@
+package practice
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/textnorm"
+)
+
+// 练习方向
+const (
+	DirectionForward = "forward" // 看原文输入原文
+	DirectionReverse = "reverse" // 看原文输入翻译
+)
+
+// 翻译中多个释义之间的分隔符，词性标记（如"n."、"vt."）也视为分隔
+var translationSeparators = regexp.MustCompile(`[；;／/]|\b` + posTagPattern)
+
+// 翻译中的括号注释，例如"苹果（水果）"
+var translationNotes = regexp.MustCompile(`[（(][^（()）]*[)）]`)
+
+// NormalizeDirection 规范化练习方向，无效值按正向处理
+func NormalizeDirection(direction string) string {
+	if strings.ToLower(strings.TrimSpace(direction)) == DirectionReverse {
+		return DirectionReverse
+	}
+	return DirectionForward
+}
+
+// IsReverse 判断当前配置是否为反向练习
+func IsReverse() bool {
+	return NormalizeDirection(config.AppConfig.PracticeDirection) == DirectionReverse
+}
+
+// SupportsReverse 判断资源类型是否支持反向练习，文章按段落练习，不支持反向
+func SupportsReverse(resourceType string) bool {
+	return resourceType != Articles
+}
+
+// ExpectsTranslation 判断条目在给定方向下是否要求输入翻译。
+// 反向练习时没有翻译的条目仍按正向练习。
+func ExpectsTranslation(line string, reverse bool) bool {
+	if !reverse {
+		return false
+	}
+	_, translation := ParseLine(line)
+	return strings.TrimSpace(translation) != ""
+}
+
+// CheckAnswer 检查命令行练习中的输入：正向练习要求与原文完全一致，
+// 反向练习按资源类型生效的匹配模式比较翻译。
+func CheckAnswer(input, line, resourceType string, reverse bool) bool {
+	primary, translation := ParseLine(line)
+	if ExpectsTranslation(line, reverse) {
+		return MatchTranslation(input, translation, config.AppConfig.MatchModeFor(resourceType) == "exact_match")
+	}
+	return input == primary
+}
+
+// MatchTranslation 判断输入的翻译是否正确。
+// 翻译中用"；"、";"、"/"或词性标记分隔的任一释义都算正确，括号中的注释可以省略。
+// exact 为 false 时忽略空白、标点、全半角和大小写差异。
+func MatchTranslation(input, translation string, exact bool) bool {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return false
+	}
+
+	normalize := NormalizeTranslation
+	if exact {
+		normalize = strings.TrimSpace
+	}
+
+	target := normalize(input)
+	for _, candidate := range translationCandidates(translation) {
+		if normalize(candidate) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// translationCandidates 返回翻译本身、各个释义以及去掉括号注释后的写法
+func translationCandidates(translation string) []string {
+	translation = strings.TrimSpace(translation)
+	candidates := []string{translation}
+	if stripped := strings.TrimSpace(translationNotes.ReplaceAllString(translation, "")); stripped != "" && stripped != translation {
+		candidates = append(candidates, stripped)
+	}
+
+	for _, part := range translationSeparators.Split(translation, -1) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		candidates = append(candidates, part)
+		if stripped := strings.TrimSpace(translationNotes.ReplaceAllString(part, "")); stripped != "" && stripped != part {
+			candidates = append(candidates, stripped)
+		}
+	}
+	return candidates
+}
+
+// NormalizeTranslation 规范化翻译文本：折叠全角/半角、转小写，
+// 并去掉所有空白、标点和符号。适用于中文、日文等不以空格分词的文本。
+func NormalizeTranslation(text string) string {
+	return textnorm.Normalize(text, textnorm.Options{Form: textnorm.FormNFKC, IgnoreSpaces: true})
+}
@@ -0,0 +1,49 @@
+package practice
+
+import "testing"
+
+// 测试反向练习的翻译匹配：多个释义、括号注释、全角标点和空格
+func TestMatchTranslation(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		translation string
+		exact       bool
+		want        bool
+	}{
+		{"完整翻译", "苹果；苹果树", "苹果；苹果树", false, true},
+		{"任一释义", "苹果树", "苹果；苹果树", false, true},
+		{"斜杠分隔的释义", "早安", "早上好/早安", false, true},
+		{"词典格式的释义", "放弃", "/əˈbændən/\tv. 遗弃；离开；放弃；终止；陷入n. 放任，狂热", false, true},
+		{"词性标记后的释义", "放任，狂热", "/əˈbændən/\tv. 遗弃；陷入n. 放任，狂热", false, true},
+		{"省略括号注释", "银行", "银行（金融机构）", false, true},
+		{"忽略标点和空格", "你好 世界", "你好，世界！", false, true},
+		{"全角字母", "ＯＫ", "ok", false, true},
+		{"逗号不拆分释义", "你好", "你好，世界", false, false},
+		{"错误翻译", "香蕉", "苹果；苹果树", false, false},
+		{"空输入", "", "苹果", false, false},
+		{"完全匹配要求标点一致", "你好世界", "你好，世界", true, false},
+		{"完全匹配仍接受任一释义", "苹果树", "苹果；苹果树", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchTranslation(tt.input, tt.translation, tt.exact); got != tt.want {
+				t.Errorf("MatchTranslation(%q, %q, %v) = %v, want %v", tt.input, tt.translation, tt.exact, got, tt.want)
+			}
+		})
+	}
+}
+
+// 测试只有带翻译的条目在反向练习时才要求输入翻译
+func TestExpectsTranslation(t *testing.T) {
+	if ExpectsTranslation("apple ->> 苹果", false) {
+		t.Error("正向练习不应要求输入翻译")
+	}
+	if !ExpectsTranslation("apple ->> 苹果", true) {
+		t.Error("带翻译的条目在反向练习时应要求输入翻译")
+	}
+	if ExpectsTranslation("apple", true) {
+		t.Error("没有翻译的条目应按正向练习")
+	}
+}
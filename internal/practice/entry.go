@@ -0,0 +1,205 @@
+package practice
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// 词性标记，如"n."、"vt."、"adj."
+const posTagPattern = `(?:n|v|vt|vi|a|adj|adv|prep|conj|pron|num|art|int|interj|aux|abbr|pl)\.`
+
+// 多字段格式的字段分隔符，例如"abandon | 音标: /əˈbændən/ | 词性: v. | 释义: 遗弃"
+const entryFieldSeparator = " | "
+
+var (
+	// 翻译开头的音标，支持"/əˈbændən/"和"[əˈbændən]"两种写法
+	leadingPhonetic = regexp.MustCompile(`^(/[^/]+/|\[[^\[\]]+\])\s*`)
+	// 翻译开头的一个或多个词性标记，如"v."、"vt. & vi."、"n./v."
+	leadingPOS = regexp.MustCompile(`^(?:` + posTagPattern + `\s*(?:[&/,，]\s*)?)+`)
+	// 例句标记，标记之后的内容作为例句
+	exampleMarker = regexp.MustCompile(`(?:例句|例)[:：]|e\.g\.`)
+	// 判断原文是否被按空格拆开时比较的文字系统，不含汉字：中日文原文和中文翻译都可能以汉字开头
+	splitPhraseScripts = []*unicode.RangeTable{
+		unicode.Latin, unicode.Cyrillic, unicode.Greek, unicode.Hangul,
+		unicode.Hiragana, unicode.Katakana, unicode.Arabic, unicode.Thai,
+	}
+)
+
+// Entry 表示资源文件中的一个结构化条目
+type Entry struct {
+	// 原文，即练习时需要输入的内容
+	Term string
+	// 音标，保留原有的"/…/"或"[…]"
+	Phonetic string
+	// 词性，如"v."、"vt. & vi."
+	PartOfSpeech string
+	// 释义
+	Meaning string
+	// 例句
+	Examples []string
+}
+
+// EntryKey 返回条目的键：原文部分，没有原文时使用整行。
+// 记忆计划、错题记录和导入合并都用它识别同一个条目。
+func EntryKey(line string) string {
+	primary, _ := ParseLine(line)
+	key := strings.TrimSpace(primary)
+	if key == "" {
+		key = strings.TrimSpace(line)
+	}
+	return key
+}
+
+// Translation 返回音标、词性和释义拼接成的翻译，与 ParseLine 返回的翻译一致
+func (e Entry) Translation() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{e.Phonetic, e.PartOfSpeech, e.Meaning} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Definition 返回词性和释义，适合单独一行显示
+func (e Entry) Definition() string {
+	if e.PartOfSpeech == "" {
+		return e.Meaning
+	}
+	if e.Meaning == "" {
+		return e.PartOfSpeech
+	}
+	return e.PartOfSpeech + " " + e.Meaning
+}
+
+// ParseEntry 将一行资源解析为结构化条目。
+// 支持两种写法：
+//  1. 原有的单行格式（见 ParseLine），从翻译中识别开头的音标、词性标记以及"例："之后的例句；
+//  2. 用" | "分隔的多字段格式，第一个字段为原文，其余字段以"音标:"、"词性:"、"释义:"、"例句:"
+//     （或 phonetic、pos、meaning、example）开头，例句可以出现多次，未标注的字段视为释义；
+//     至少要有一个字段带标签，见 isFieldLine。
+func ParseEntry(line string) Entry {
+	line = strings.TrimSpace(line)
+	if isFieldLine(line) {
+		return parseFieldEntry(line)
+	}
+
+	term, translation := parseInlineLine(line)
+	entry := Entry{Term: term}
+
+	translation = strings.TrimSpace(translation)
+	if match := leadingPhonetic.FindStringSubmatch(translation); match != nil {
+		entry.Phonetic = match[1]
+		translation = translation[len(match[0]):]
+	}
+	if match := leadingPOS.FindString(translation); match != "" {
+		entry.PartOfSpeech = strings.TrimSpace(match)
+		translation = translation[len(match):]
+	}
+
+	parts := exampleMarker.Split(translation, -1)
+	entry.Meaning = strings.TrimSpace(parts[0])
+	for _, example := range parts[1:] {
+		if example = strings.TrimSpace(example); example != "" {
+			entry.Examples = append(entry.Examples, example)
+		}
+	}
+	return entry
+}
+
+// isFieldLine 判断一行是否为多字段格式：没有" ->> "和制表符分隔符，且原文之后至少有一个字段带有可识别的标签。
+// 翻译或句子中出现的" | "不会使整行按多字段格式解析；文件头声明" | "分隔符时由 normalizeLine 转换。
+func isFieldLine(line string) bool {
+	if !strings.Contains(line, entryFieldSeparator) || strings.Contains(line, " ->>") || strings.Contains(line, "\t") {
+		return false
+	}
+	for _, field := range strings.Split(line, entryFieldSeparator)[1:] {
+		if label, _ := splitFieldLabel(strings.TrimSpace(field)); label != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFieldEntry 解析用" | "分隔的多字段格式
+func parseFieldEntry(line string) Entry {
+	fields := strings.Split(line, entryFieldSeparator)
+	entry := Entry{Term: strings.TrimSpace(fields[0])}
+
+	var meanings []string
+	for _, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		label, value := splitFieldLabel(field)
+		switch label {
+		case "phonetic", "音标":
+			entry.Phonetic = value
+		case "pos", "词性":
+			entry.PartOfSpeech = value
+		case "example", "例句", "例":
+			entry.Examples = append(entry.Examples, value)
+		default:
+			meanings = append(meanings, value)
+		}
+	}
+	entry.Meaning = strings.Join(meanings, "；")
+	return entry
+}
+
+// splitFieldLabel 拆分"标签: 内容"形式的字段，标签不区分大小写，未识别的标签返回空
+func splitFieldLabel(field string) (string, string) {
+	index := strings.IndexAny(field, ":：")
+	if index <= 0 {
+		return "", field
+	}
+	label := strings.ToLower(strings.TrimSpace(field[:index]))
+	switch label {
+	case "phonetic", "音标", "pos", "词性", "meaning", "释义", "example", "例句", "例":
+		return label, strings.TrimSpace(strings.TrimLeft(field[index:], ":："))
+	}
+	return "", field
+}
+
+// LooksLikeSplitPhrase 判断按空格拆分的原文和翻译是否像是同一个短语或句子被拆开：
+// 翻译以与原文相同文字系统的单词开头，例如"thank you 谢谢"被拆成"thank"和"you 谢谢"。
+// 翻译以音标、词性标记或汉字开头时视为正常拆分。
+func LooksLikeSplitPhrase(term, translation string) bool {
+	if translation == "" || leadingPOS.MatchString(translation) {
+		return false
+	}
+	termRunes := []rune(term)
+	var last rune
+	for i := len(termRunes) - 1; i >= 0; i-- {
+		if unicode.IsLetter(termRunes[i]) {
+			last = termRunes[i]
+			break
+		}
+	}
+	first := []rune(translation)[0]
+	if last == 0 || !unicode.IsLetter(first) {
+		return false
+	}
+	for _, script := range splitPhraseScripts {
+		if unicode.Is(script, last) && unicode.Is(script, first) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArticleText 返回文章中一行需要输入的正文和翻译。
+// 文章里常有整句外文而没有翻译的行，按第一个空格拆分会只剩第一个单词，这时整行都是正文。
+func ArticleText(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	text, translation := ParseLine(line)
+	if LineSeparator(line) == SeparatorSpace && LooksLikeSplitPhrase(text, translation) {
+		return line, ""
+	}
+	if text == "" {
+		return line, translation
+	}
+	return text, translation
+}
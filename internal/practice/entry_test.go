@@ -0,0 +1,130 @@
+package practice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 测试从单行格式和多字段格式中解析音标、词性、释义和例句
+func TestParseEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Entry
+	}{
+		{
+			name: "制表符分隔的词典格式",
+			line: "abandon\t/əˈbændən/\tv. 遗弃；离开；放弃",
+			want: Entry{Term: "abandon", Phonetic: "/əˈbændən/", PartOfSpeech: "v.", Meaning: "遗弃；离开；放弃"},
+		},
+		{
+			name: "箭头分隔的词典格式",
+			line: "computer ->> /kəmˈpjuːtər/ n. 计算机",
+			want: Entry{Term: "computer", Phonetic: "/kəmˈpjuːtər/", PartOfSpeech: "n.", Meaning: "计算机"},
+		},
+		{
+			name: "方括号音标和多个词性",
+			line: "run ->> [rʌn] vt. & vi. 跑；运行",
+			want: Entry{Term: "run", Phonetic: "[rʌn]", PartOfSpeech: "vt. & vi.", Meaning: "跑；运行"},
+		},
+		{
+			name: "释义后的例句",
+			line: "apple ->> n. 苹果 例：I ate an apple. 例：Apples are red.",
+			want: Entry{Term: "apple", PartOfSpeech: "n.", Meaning: "苹果", Examples: []string{"I ate an apple.", "Apples are red."}},
+		},
+		{
+			name: "普通翻译",
+			line: "apple ->> 苹果",
+			want: Entry{Term: "apple", Meaning: "苹果"},
+		},
+		{
+			name: "多字段格式",
+			line: "abandon | 音标: /əˈbændən/ | 词性: v. | 释义: 遗弃 | 例句: He abandoned the car. | example: Don't abandon hope.",
+			want: Entry{Term: "abandon", Phonetic: "/əˈbændən/", PartOfSpeech: "v.", Meaning: "遗弃",
+				Examples: []string{"He abandoned the car.", "Don't abandon hope."}},
+		},
+		{
+			name: "多字段格式中未标注的字段视为释义",
+			line: "bank | 词性: n. | 银行 | 河岸",
+			want: Entry{Term: "bank", PartOfSpeech: "n.", Meaning: "银行；河岸"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseEntry(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEntry(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+// 测试多字段格式经 ParseLine 解析后翻译不包含例句
+func TestParseLineFieldEntry(t *testing.T) {
+	primary, translation := ParseLine("abandon | phonetic: /əˈbændən/ | pos: v. | meaning: 遗弃 | example: He abandoned the car.")
+	if primary != "abandon" {
+		t.Errorf("原文 = %q, want %q", primary, "abandon")
+	}
+	if want := "/əˈbændən/ v. 遗弃"; translation != want {
+		t.Errorf("翻译 = %q, want %q", translation, want)
+	}
+	if !MatchTranslation("遗弃", translation, false) {
+		t.Errorf("反向练习应接受释义 %q", "遗弃")
+	}
+}
+
+// 测试翻译或句子中出现" | "时仍按原有分隔符解析，只有带标签的字段才按多字段格式解析
+func TestParseLinePipeInTranslation(t *testing.T) {
+	tests := []struct {
+		line, term, translation string
+	}{
+		{"a ->> b | c", "a", "b | c"},
+		{"or ->> 或者 | 还是", "or", "或者 | 还是"},
+		{"either	要么 | 或者", "either", "要么 | 或者"},
+		{"bank | 词性: n. | 银行", "bank", "n. 银行"},
+	}
+	for _, tt := range tests {
+		if term, translation := ParseLine(tt.line); term != tt.term || translation != tt.translation {
+			t.Errorf("ParseLine(%q) = %q, %q, want %q, %q", tt.line, term, translation, tt.term, tt.translation)
+		}
+	}
+	if text, _ := ArticleText("Choose one | or the other."); text != "Choose one | or the other." {
+		t.Errorf("没有标签的句子不应按多字段格式截断: %q", text)
+	}
+
+	// 文件头声明" | "分隔符时，没有标签的字段也按多字段格式解析
+	content, err := ParseResource(Words, strings.NewReader("# mllt: separator=\" | \"\nbank | 银行 | 河岸\nabandon | 释义: 遗弃\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"bank", "abandon"} {
+		if term := EntryKey(content.Lines[i]); term != want {
+			t.Errorf("第 %d 行的原文 = %q, want %q", i+1, term, want)
+		}
+	}
+	if _, translation := ParseLine(content.Lines[0]); translation != "银行；河岸" {
+		t.Errorf("翻译 = %q", translation)
+	}
+}
+
+// 测试按空格拆分的判断：词性、音标和中文翻译不算拆开了短语
+func TestLooksLikeSplitPhrase(t *testing.T) {
+	tests := []struct {
+		term, translation string
+		want              bool
+	}{
+		{"thank", "you 谢谢", true},
+		{"The", "climbers reached the summit.", true},
+		{"спасибо", "большое 非常感谢", true},
+		{"apple", "苹果", false},
+		{"apple", "n. 苹果", false},
+		{"apple", "/ˈæpl/ 苹果", false},
+		{"学校", "学校", false},
+	}
+	for _, tt := range tests {
+		if got := LooksLikeSplitPhrase(tt.term, tt.translation); got != tt.want {
+			t.Errorf("LooksLikeSplitPhrase(%q, %q) = %v, want %v", tt.term, tt.translation, got, tt.want)
+		}
+	}
+}
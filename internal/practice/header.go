@@ -0,0 +1,197 @@
+package practice
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 资源文件头的前缀，必须位于文件第一行，例如：
+// # mllt: separator=" ->> " format=line lang=english title="Excuse me!" level=B1
+const headerPrefix = "# mllt:"
+
+// 资源文件的格式
+const (
+	FormatLine = "line" // 每行一个条目
+	FormatPair = "pair" // 原文、翻译各占一行，空行分隔不同的条目
+)
+
+// ResourceHeader 资源文件头声明的格式和元数据
+type ResourceHeader struct {
+	// 原文和翻译之间的分隔符，声明后只按它拆分，不再猜测
+	Separator string
+	// 文件格式：line 或 pair，声明后不再尝试另一种格式
+	Format string
+	// 资源的语言
+	Language string
+	// 标题，在资源列表中代替文件名显示
+	Title string
+	// 难度等级，如 A2、B1、四级
+	Level string
+}
+
+// IsResourceHeader 判断一行是否为资源文件头
+func IsResourceHeader(line string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(strings.TrimSpace(line), "\ufeff"), headerPrefix)
+}
+
+// ParseResourceHeader 解析资源文件头，line 不是文件头时返回 nil。
+// 值可以用双引号括起来（支持 Go 的转义写法，如"\t"），分隔符也可以写作 tab 或 space；
+// 未知的键会被忽略，便于以后增加新的元数据。
+func ParseResourceHeader(line string) (*ResourceHeader, error) {
+	if !IsResourceHeader(line) {
+		return nil, nil
+	}
+	rest := strings.TrimPrefix(strings.TrimSpace(line), "\ufeff")
+	rest = strings.TrimPrefix(rest, headerPrefix)
+
+	header := &ResourceHeader{}
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			break
+		}
+
+		index := strings.IndexByte(rest, '=')
+		if index <= 0 || strings.ContainsAny(rest[:index], " \t\"") {
+			return nil, fmt.Errorf("无效的文件头: 缺少\"键=值\"（%s）", rest)
+		}
+		key := strings.ToLower(rest[:index])
+		rest = rest[index+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := closingQuote(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("无效的文件头: %s 的值缺少结束引号", key)
+			}
+			unquoted, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("无效的文件头: %s 的值无法解析: %w", key, err)
+			}
+			value, rest = unquoted, rest[end+1:]
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+
+		if err := header.set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return header, nil
+}
+
+// String 将文件头写回"# mllt: ..."形式，未设置的字段省略，含空白或引号的值用双引号括起来
+func (h ResourceHeader) String() string {
+	fields := []string{headerPrefix}
+	for _, field := range []struct{ key, value string }{
+		{"separator", h.Separator},
+		{"format", h.Format},
+		{"lang", h.Language},
+		{"title", h.Title},
+		{"level", h.Level},
+	} {
+		if field.value == "" {
+			continue
+		}
+		value := field.value
+		if strings.ContainsAny(value, " \t\"\\") {
+			value = strconv.Quote(value)
+		}
+		fields = append(fields, field.key+"="+value)
+	}
+	return strings.Join(fields, " ")
+}
+
+// closingQuote 返回与开头的双引号配对的结束引号位置，跳过转义的引号
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// set 设置文件头中的一个字段
+func (h *ResourceHeader) set(key, value string) error {
+	switch key {
+	case "separator", "sep":
+		switch strings.ToLower(value) {
+		case "tab":
+			value = "\t"
+		case "space":
+			value = " "
+		}
+		if value == "" {
+			return fmt.Errorf("无效的文件头: 分隔符不能为空")
+		}
+		h.Separator = value
+	case "format":
+		switch strings.ToLower(value) {
+		case FormatLine:
+			h.Format = FormatLine
+		case FormatPair, "pairs", "newline":
+			h.Format = FormatPair
+		default:
+			return fmt.Errorf("无效的文件头: 不支持的格式 %s（可选: %s, %s）", value, FormatLine, FormatPair)
+		}
+	case "lang", "language":
+		h.Language = value
+	case "title":
+		h.Title = value
+	case "level":
+		h.Level = value
+	}
+	return nil
+}
+
+// normalizeLine 按声明的分隔符拆分条目，统一转换为" ->> "格式，没有翻译时写作"原文 ->>"，
+// 这样后续的 ParseLine 不会再按空格等分隔符拆开多个单词的原文。
+// 声明了" | "分隔符时整个文件是多字段格式，没有标签的条目按多字段解析后转换。
+func (h *ResourceHeader) normalizeLine(line string) string {
+	if h.Separator == "" || isFieldLine(line) {
+		// 带标签的多字段条目本身就是确定的
+		return line
+	}
+	term, translation := line, ""
+	if h.Separator == entryFieldSeparator {
+		entry := parseFieldEntry(line)
+		term, translation = entry.Term, entry.Translation()
+	} else if index := strings.Index(line, h.Separator); index >= 0 {
+		term, translation = line[:index], line[index+len(h.Separator):]
+	}
+
+	term, translation = strings.TrimSpace(term), strings.TrimSpace(translation)
+	if translation == "" {
+		return term + " ->>"
+	}
+	return term + " ->> " + translation
+}
+
+// ReadResourceHeader 读取资源文件头，文件不存在或没有文件头时返回 nil
+func ReadResourceHeader(resourceType, fileName string) (*ResourceHeader, error) {
+	file, err := os.Open(GetResourcePath(resourceType, fileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("打开文件失败: %w", err)
+	}
+	defer file.Close()
+
+	firstLine, err := bufio.NewReader(file).ReadString('\n')
+	if firstLine == "" && err != nil {
+		return nil, nil
+	}
+	return ParseResourceHeader(firstLine)
+}
@@ -0,0 +1,85 @@
+package practice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 测试解析文件头：带引号的值、转义、分隔符关键字以及无效的声明
+func TestParseResourceHeader(t *testing.T) {
+	header, err := ParseResourceHeader(`# mllt: separator=" ->> " format=line lang=english title="Excuse me! \"NCE\"" level=B1 author=someone`)
+	if err != nil {
+		t.Fatalf("解析文件头失败: %v", err)
+	}
+	want := &ResourceHeader{Separator: " ->> ", Format: FormatLine, Language: "english", Title: `Excuse me! "NCE"`, Level: "B1"}
+	if !reflect.DeepEqual(header, want) {
+		t.Errorf("文件头 = %+v, want %+v", header, want)
+	}
+
+	if header, err := ParseResourceHeader("\ufeff# mllt: separator=tab"); err != nil || header.Separator != "\t" {
+		t.Errorf("separator=tab 应解析为制表符，实际 %+v, %v", header, err)
+	}
+	if header, err := ParseResourceHeader(`# mllt: separator="\t" format=newline`); err != nil || header.Separator != "\t" || header.Format != FormatPair {
+		t.Errorf("转义的分隔符和格式别名解析错误，实际 %+v, %v", header, err)
+	}
+	if header, err := ParseResourceHeader("# 普通注释"); header != nil || err != nil {
+		t.Errorf("不是文件头时应返回 nil，实际 %+v, %v", header, err)
+	}
+
+	for _, line := range []string{
+		`# mllt: format=csv`,
+		`# mllt: title="未结束`,
+		`# mllt: separator=""`,
+		`# mllt: level`,
+	} {
+		if _, err := ParseResourceHeader(line); err == nil {
+			t.Errorf("%q 应返回错误", line)
+		}
+	}
+}
+
+// 测试声明了分隔符和格式后按声明解析，不再猜测
+func TestParseResourceWithHeader(t *testing.T) {
+	data := "# mllt: separator=\" - \" format=line title=\"常用短语\"\nlook into sth - 调查\n\nthank you - 谢谢\nfigure out\n"
+	content, err := ParseResource(Phrases, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	wantLines := []string{"look into sth ->> 调查", "thank you ->> 谢谢", "figure out ->>"}
+	if !reflect.DeepEqual(content.Lines, wantLines) || !reflect.DeepEqual(content.LineNumbers, []int{2, 4, 5}) {
+		t.Errorf("解析结果 = %q %v", content.Lines, content.LineNumbers)
+	}
+	if content.Header == nil || content.Header.Title != "常用短语" || content.NewlineFormat {
+		t.Errorf("文件头 = %+v，换行分隔格式 = %v", content.Header, content.NewlineFormat)
+	}
+
+	// 没有翻译的条目不会再按空格拆开
+	if term, translation := ParseLine(content.Lines[2]); term != "figure out" || translation != "" {
+		t.Errorf("ParseLine(%q) = %q, %q", content.Lines[2], term, translation)
+	}
+	if key := EntryKey(content.Lines[0]); key != "look into sth" {
+		t.Errorf("EntryKey = %q, want look into sth", key)
+	}
+
+	// 只声明分隔符时条目都在同一行，连续两行不会被当作换行分隔格式的一对
+	content, err = ParseResource(Phrases, strings.NewReader("# mllt: separator=\" - \"\nlook into sth - 调查\nlook after - 照顾\n"))
+	if err != nil || !reflect.DeepEqual(content.Lines, []string{"look into sth ->> 调查", "look after ->> 照顾"}) {
+		t.Errorf("只声明分隔符的解析结果 = %+v, %v", content, err)
+	}
+
+	// 声明 format=line 时，即使内容看起来像换行分隔格式也逐行解析
+	content, err = ParseResource(Sentences, strings.NewReader("# mllt: format=line\nHello\n你好\n"))
+	if err != nil || len(content.Lines) != 2 || content.NewlineFormat {
+		t.Errorf("format=line 解析结果 = %+v, %v", content, err)
+	}
+
+	// 声明 format=pair 时，单词也按换行分隔格式解析，不符合时返回错误
+	content, err = ParseResource(Words, strings.NewReader("# mllt: format=pair\napple\n苹果\n\nbanana\n香蕉\n"))
+	if err != nil || !reflect.DeepEqual(content.Lines, []string{"apple ->> 苹果", "banana ->> 香蕉"}) || !reflect.DeepEqual(content.LineNumbers, []int{2, 5}) {
+		t.Errorf("format=pair 解析结果 = %+v, %v", content, err)
+	}
+	if _, err := ParseResource(Words, strings.NewReader("# mllt: format=pair\napple\n苹果\n多余的行\n")); err == nil {
+		t.Error("不符合 format=pair 的文件应返回错误")
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/ajilisiwei/mllt-cli/internal/config"
 )
 
-// PhrasePractice 短语练习
-func PhrasePractice(fileName string) error {
+// PhrasePractice 短语练习，reverse 为 true 时看原文输入翻译
+func PhrasePractice(fileName string, reverse bool) error {
 	// 读取短语列表
 	phrases, err := ReadResourceFile(Phrases, fileName)
 	if err != nil {
@@ -27,8 +27,8 @@ func PhrasePractice(fileName string) error {
 	fmt.Println("输入 'q' 退出练习。")
 
 	// 获取配置
-	nextOneOrder := config.AppConfig.NextOneOrder
-	showTranslation := config.AppConfig.ShowTranslation
+	nextOneOrder := config.AppConfig.OrderFor(Phrases)
+	showTranslation := config.AppConfig.ShowTranslationFor(Phrases)
 
 	// 开始练习
 	index := 0
@@ -40,7 +40,11 @@ func PhrasePractice(fileName string) error {
 		phrase, translation := ParseLine(phraseLine)
 
 		// 显示短语
-		fmt.Printf("请输入: %s\n", phrase)
+		if ExpectsTranslation(phraseLine, reverse) {
+			fmt.Printf("请输入翻译: %s\n", phrase)
+		} else {
+			fmt.Printf("请输入: %s\n", phrase)
+		}
 
 		// 读取用户输入
 		input, _ := reader.ReadString('\n')
@@ -53,7 +57,7 @@ func PhrasePractice(fileName string) error {
 		}
 
 		// 检查输入是否正确
-		if input == phrase {
+		if CheckAnswer(input, phraseLine, Phrases, reverse) {
 			fmt.Println("正确！")
 			if showTranslation && translation != "" {
 				fmt.Printf("翻译: %s\n", translation)
@@ -3,6 +3,8 @@ package practice
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ajilisiwei/mllt-cli/internal/config"
@@ -131,3 +133,44 @@ func TestReadResourceFile(t *testing.T) {
 		t.Errorf("文件内容不匹配，期望 %s，实际 %s", expectedLine, lines[0])
 	}
 }
+
+// 测试解析资源时记录行号，以及换行分隔格式回退到逐行解析的位置
+func TestParseResource(t *testing.T) {
+	data := "Hello\n你好\n\nCan you make the tea? ->> 你能沏茶吗？\n\nYes. ->> 是的。\n"
+	content, err := ParseResource(Sentences, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if content.NewlineFormat || content.FallbackLine != 4 || content.FallbackEntries != 1 {
+		t.Errorf("回退信息 = %+v", content)
+	}
+	if !reflect.DeepEqual(content.LineNumbers, []int{1, 2, 4, 6}) {
+		t.Errorf("行号 = %v, want [1 2 4 6]", content.LineNumbers)
+	}
+
+	content, err = ParseResource(Sentences, strings.NewReader("Hello\n你好\n\nGood morning\n早上好\n"))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	want := []string{"Hello ->> 你好", "Good morning ->> 早上好"}
+	if !content.NewlineFormat || !reflect.DeepEqual(content.Lines, want) || !reflect.DeepEqual(content.LineNumbers, []int{1, 4}) {
+		t.Errorf("换行分隔格式解析结果 = %+v", content)
+	}
+}
+
+// 测试 LineSeparator 与 ParseLine 使用相同的分隔符
+func TestLineSeparator(t *testing.T) {
+	tests := map[string]string{
+		"thank you ->> 谢谢": SeparatorArrow,
+		"apple\t苹果":        SeparatorTab,
+		"apple 苹果":         SeparatorSpace,
+		"apple/苹果":         "/",
+		"abandon | 释义: 遗弃": SeparatorField,
+		"apple":            SeparatorNone,
+	}
+	for line, want := range tests {
+		if got := LineSeparator(line); got != want {
+			t.Errorf("LineSeparator(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
@@ -9,8 +9,8 @@ import (
 	"github.com/ajilisiwei/mllt-cli/internal/config"
 )
 
-// SentencePractice 句子练习
-func SentencePractice(fileName string) error {
+// SentencePractice 句子练习，reverse 为 true 时看原文输入翻译
+func SentencePractice(fileName string, reverse bool) error {
 	// 读取句子列表
 	sentences, err := ReadResourceFile(Sentences, fileName)
 	if err != nil {
@@ -27,8 +27,8 @@ func SentencePractice(fileName string) error {
 	fmt.Println("输入 'q' 退出练习。")
 
 	// 获取配置
-	nextOneOrder := config.AppConfig.NextOneOrder
-	showTranslation := config.AppConfig.ShowTranslation
+	nextOneOrder := config.AppConfig.OrderFor(Sentences)
+	showTranslation := config.AppConfig.ShowTranslationFor(Sentences)
 
 	// 开始练习
 	index := 0
@@ -40,7 +40,11 @@ func SentencePractice(fileName string) error {
 		sentence, translation := ParseLine(sentenceLine)
 
 		// 显示句子
-		fmt.Printf("请输入: %s\n", sentence)
+		if ExpectsTranslation(sentenceLine, reverse) {
+			fmt.Printf("请输入翻译: %s\n", sentence)
+		} else {
+			fmt.Printf("请输入: %s\n", sentence)
+		}
 
 		// 读取用户输入
 		input, _ := reader.ReadString('\n')
@@ -53,7 +57,7 @@ func SentencePractice(fileName string) error {
 		}
 
 		// 检查输入是否正确
-		if input == sentence {
+		if CheckAnswer(input, sentenceLine, Sentences, reverse) {
 			fmt.Println("正确！")
 			if showTranslation && translation != "" {
 				fmt.Printf("翻译: %s\n", translation)
@@ -9,8 +9,8 @@ import (
 	"github.com/ajilisiwei/mllt-cli/internal/config"
 )
 
-// WordPractice 单词练习
-func WordPractice(fileName string) error {
+// WordPractice 单词练习，reverse 为 true 时看原文输入翻译
+func WordPractice(fileName string, reverse bool) error {
 	// 读取单词列表
 	words, err := ReadResourceFile(Words, fileName)
 	if err != nil {
@@ -27,8 +27,8 @@ func WordPractice(fileName string) error {
 	fmt.Println("输入 'q' 退出练习。")
 
 	// 获取配置
-	nextOneOrder := config.AppConfig.NextOneOrder
-	showTranslation := config.AppConfig.ShowTranslation
+	nextOneOrder := config.AppConfig.OrderFor(Words)
+	showTranslation := config.AppConfig.ShowTranslationFor(Words)
 
 	// 开始练习
 	index := 0
@@ -40,7 +40,11 @@ func WordPractice(fileName string) error {
 		word, translation := ParseLine(wordLine)
 
 		// 显示单词
-		fmt.Printf("请输入: %s\n", word)
+		if ExpectsTranslation(wordLine, reverse) {
+			fmt.Printf("请输入翻译: %s\n", word)
+		} else {
+			fmt.Printf("请输入: %s\n", word)
+		}
 
 		// 读取用户输入
 		input, _ := reader.ReadString('\n')
@@ -53,7 +57,7 @@ func WordPractice(fileName string) error {
 		}
 
 		// 检查输入是否正确
-		if input == word {
+		if CheckAnswer(input, wordLine, Words, reverse) {
 			fmt.Println("正确！")
 			if showTranslation && translation != "" {
 				fmt.Printf("翻译: %s\n", translation)
@@ -0,0 +1,122 @@
+package resume
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// Checkpoint 记录一个资源文件中途退出时的练习进度，下次可以从这里继续
+type Checkpoint struct {
+	ResourceType   string    `json:"resource_type"`
+	FileName       string    `json:"file_name"`
+	Remaining      []string  `json:"remaining"`       // 尚未完成的项目（资源文件中的原始行），按练习顺序排列
+	Completed      int       `json:"completed"`       // 已完成的项目数
+	Correct        int       `json:"correct"`         // 累计答对次数
+	Incorrect      int       `json:"incorrect"`       // 累计答错次数
+	ElapsedSeconds int64     `json:"elapsed_seconds"` // 累计练习用时
+	OrderMode      string    `json:"order_mode,omitempty"`
+	SavedAt        time.Time `json:"saved_at"`
+	// 累计的按键统计，继续练习完成后与本次的按键合并，用于判断课程是否通过
+	Typing *statistics.TypingStats `json:"typing,omitempty"`
+}
+
+// Elapsed 返回累计练习用时
+func (c Checkpoint) Elapsed() time.Duration {
+	return time.Duration(c.ElapsedSeconds) * time.Second
+}
+
+// Restore 按资源文件当前的项目恢复练习顺序：已完成的项目按原顺序排在前面，
+// 未完成的项目按保存时的顺序排在后面。资源文件修改后，已删除的项目会被忽略，
+// 新增的项目视为已完成。没有可以继续的项目时 ok 为 false。
+func (c Checkpoint) Restore(items []string) (order []int, completed int, ok bool) {
+	positions := make(map[string][]int, len(items))
+	for i, item := range items {
+		key := strings.TrimSpace(item)
+		positions[key] = append(positions[key], i)
+	}
+
+	used := make([]bool, len(items))
+	remaining := make([]int, 0, len(c.Remaining))
+	for _, item := range c.Remaining {
+		key := strings.TrimSpace(item)
+		indexes := positions[key]
+		if len(indexes) == 0 {
+			continue
+		}
+		remaining = append(remaining, indexes[0])
+		used[indexes[0]] = true
+		positions[key] = indexes[1:]
+	}
+	if len(remaining) == 0 {
+		return nil, 0, false
+	}
+
+	order = make([]int, 0, len(items))
+	for i := range items {
+		if !used[i] {
+			order = append(order, i)
+		}
+	}
+	completed = len(order)
+	return append(order, remaining...), completed, true
+}
+
+// Load 读取资源文件的练习进度，没有保存过进度时返回 nil
+func Load(resourceType, fileName string) (*Checkpoint, error) {
+	data, err := os.ReadFile(checkpointPath(resourceType, fileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("读取练习进度失败: %w", err)
+	}
+
+	checkpoint := &Checkpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("解析练习进度失败: %w", err)
+	}
+	if len(checkpoint.Remaining) == 0 {
+		return nil, nil
+	}
+	return checkpoint, nil
+}
+
+// Save 保存资源文件的练习进度，覆盖之前的进度
+func Save(checkpoint *Checkpoint) error {
+	path := checkpointPath(checkpoint.ResourceType, checkpoint.FileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("创建进度目录失败: %w", err)
+	}
+
+	data, err := json.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Remove 删除资源文件的练习进度，进度不存在时不报错
+func Remove(resourceType, fileName string) error {
+	if err := os.Remove(checkpointPath(resourceType, fileName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("删除练习进度失败: %w", err)
+	}
+	return nil
+}
+
+// checkpointPath 返回资源文件对应的进度文件路径，文件夹作为子目录保留，
+// 避免导入目录时用"_"连接的文件夹名（如 unit1_day1/words 和 unit1/day1_words）对应到同一个文件
+func checkpointPath(resourceType, fileName string) string {
+	folderDir, name := practice.SplitResourceIdentifier(fileName)
+	if name == "" {
+		name = "default"
+	}
+	return filepath.Join(practice.GetUserDataDir(), "progress", config.AppConfig.CurrentLanguage, resourceType, folderDir, name+".json")
+}
@@ -0,0 +1,93 @@
+package resume
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+)
+
+// 测试按当前的项目恢复练习顺序，资源文件修改后忽略已删除的项目
+func TestCheckpointRestore(t *testing.T) {
+	checkpoint := Checkpoint{Remaining: []string{"d", "b", "x"}}
+
+	order, completed, ok := checkpoint.Restore([]string{"a", "b", "c", "d", "e"})
+	if !ok {
+		t.Fatal("还有未完成的项目时应可以继续")
+	}
+	if want := []int{0, 2, 4, 3, 1}; !reflect.DeepEqual(order, want) || completed != 3 {
+		t.Errorf("恢复顺序 = %v（已完成 %d），want %v（已完成 3）", order, completed, want)
+	}
+
+	// 重复的行分别对应各自的位置
+	checkpoint = Checkpoint{Remaining: []string{"a", "a"}}
+	if order, completed, _ := checkpoint.Restore([]string{"a", "b", "a"}); !reflect.DeepEqual(order, []int{1, 0, 2}) || completed != 1 {
+		t.Errorf("重复行恢复顺序 = %v（已完成 %d）", order, completed)
+	}
+
+	if _, _, ok := (Checkpoint{Remaining: []string{"x"}}).Restore([]string{"a"}); ok {
+		t.Error("未完成的项目都已删除时不应继续")
+	}
+}
+
+// 测试保存、读取和删除练习进度
+func TestSaveLoadRemove(t *testing.T) {
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	if checkpoint, err := Load("articles", "NCE-3/3-001_A_puma_at_large"); err != nil || checkpoint != nil {
+		t.Fatalf("没有进度时 = %v, %v", checkpoint, err)
+	}
+
+	saved := &Checkpoint{
+		ResourceType:   "articles",
+		FileName:       "NCE-3/3-001_A_puma_at_large",
+		Remaining:      []string{"line 37", "line 38"},
+		Completed:      36,
+		Correct:        30,
+		Incorrect:      8,
+		ElapsedSeconds: 600,
+	}
+	if err := Save(saved); err != nil {
+		t.Fatalf("保存进度失败: %v", err)
+	}
+	loaded, err := Load("articles", "NCE-3/3-001_A_puma_at_large")
+	if err != nil || loaded == nil {
+		t.Fatalf("读取进度失败: %v", err)
+	}
+	if loaded.Completed != 36 || loaded.Elapsed().Minutes() != 10 || !reflect.DeepEqual(loaded.Remaining, saved.Remaining) {
+		t.Errorf("读取的进度 = %+v", loaded)
+	}
+
+	if err := Remove("articles", "NCE-3/3-001_A_puma_at_large"); err != nil {
+		t.Fatalf("删除进度失败: %v", err)
+	}
+	if checkpoint, _ := Load("articles", "NCE-3/3-001_A_puma_at_large"); checkpoint != nil {
+		t.Error("删除后不应再读取到进度")
+	}
+	if err := Remove("articles", "NCE-3/3-001_A_puma_at_large"); err != nil {
+		t.Errorf("删除不存在的进度不应报错: %v", err)
+	}
+}
+
+// 测试文件夹和文件名用"_"连接后相同的资源分别保存进度
+func TestCheckpointPathKeepsFolder(t *testing.T) {
+	if err := config.LoadConfig(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	t.Chdir(t.TempDir())
+
+	for _, fileName := range []string{"unit1/day1_words", "unit1_day1/words"} {
+		if err := Save(&Checkpoint{ResourceType: "words", FileName: fileName, Remaining: []string{fileName}}); err != nil {
+			t.Fatalf("保存进度失败: %v", err)
+		}
+	}
+	for _, fileName := range []string{"unit1/day1_words", "unit1_day1/words"} {
+		loaded, err := Load("words", fileName)
+		if err != nil || loaded == nil || loaded.FileName != fileName {
+			t.Errorf("%s 读取的进度 = %+v, %v", fileName, loaded, err)
+		}
+	}
+}
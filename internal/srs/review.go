@@ -0,0 +1,98 @@
+package srs
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/bookmark"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// ReviewTypes 参与记忆计划的资源类型（文章不使用艾宾浩斯模式）
+var ReviewTypes = []string{practice.Words, practice.Phrases, practice.Sentences}
+
+// DueItem 表示一个已到期、需要复习的条目
+type DueItem struct {
+	ResourceType string
+	FileName     string
+	Item         string
+	State        ItemState
+}
+
+// Exists 判断资源文件是否已经建立了记忆计划
+func Exists(resourceType, fileName string) bool {
+	_, err := os.Stat(schedulePath(resourceType, fileName))
+	return err == nil
+}
+
+// CollectDue 汇总当前语言下所有资源文件中已到期的条目，按到期时间先后排序。
+// 只读取已有的记忆计划，不会为从未用艾宾浩斯模式练习过的文件创建计划。
+func CollectDue(now time.Time) ([]DueItem, error) {
+	var due []DueItem
+
+	for _, resourceType := range ReviewTypes {
+		files, err := practice.GetResourceFiles(resourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fileName := range files {
+			if bookmark.IsSpecialList(fileName) || !Exists(resourceType, fileName) {
+				continue
+			}
+
+			schedule, err := readSchedule(schedulePath(resourceType, fileName), false)
+			if err != nil {
+				continue
+			}
+
+			items, err := practice.ReadResourceFile(resourceType, fileName)
+			if err != nil {
+				continue
+			}
+
+			for _, item := range dueItems(schedule, items, now) {
+				due = append(due, DueItem{
+					ResourceType: resourceType,
+					FileName:     fileName,
+					Item:         item,
+					State:        schedule.getState(item),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].State.DueAt.Before(due[j].State.DueAt)
+	})
+
+	return due, nil
+}
+
+// dueItems 返回资源中已到期的条目，跳过从未复习过的新条目和已删除的条目
+func dueItems(schedule *Schedule, items []string, now time.Time) []string {
+	var due []string
+	seen := make(map[string]struct{})
+
+	for _, item := range items {
+		trimmed := strings.TrimSpace(item)
+		if trimmed == "" {
+			continue
+		}
+		key := schedule.keyFor(trimmed)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		state, ok := schedule.Items[key]
+		if !ok || state.DueAt.IsZero() || state.DueAt.After(now) {
+			continue
+		}
+		due = append(due, trimmed)
+	}
+
+	return due
+}
@@ -3,6 +3,7 @@ package srs
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -13,7 +14,8 @@ import (
 	"github.com/ajilisiwei/mllt-cli/internal/practice"
 )
 
-var intervals = []time.Duration{
+// legacyIntervals 是 v1 版本使用的固定复习阶梯，仅用于迁移旧的记忆计划。
+var legacyIntervals = []time.Duration{
 	0,
 	5 * time.Minute,
 	30 * time.Minute,
@@ -26,31 +28,140 @@ var intervals = []time.Duration{
 	30 * 24 * time.Hour,
 }
 
+const (
+	// scheduleVersion 当前记忆计划文件的格式版本
+	scheduleVersion = 2
+
+	defaultEase = 2.5
+	minEase     = 1.3
+	maxEase     = 3.5
+
+	// maxHistory 每个条目保留的复习记录条数
+	maxHistory = 20
+
+	day         = 24 * time.Hour
+	maxInterval = 365 * day
+	// relearnInterval 答错后重新学习的间隔
+	relearnInterval = time.Minute
+)
+
+// Grade 表示一次复习的评分，参考 SM-2 / FSRS 的四档评分。
+type Grade int
+
+const (
+	GradeAgain Grade = iota + 1 // 重来：没有记住
+	GradeHard                   // 困难：勉强记住
+	GradeGood                   // 良好：正常记住
+	GradeEasy                   // 简单：轻松记住
+)
+
+// String 返回评分的英文标识。
+func (g Grade) String() string {
+	switch g {
+	case GradeAgain:
+		return "again"
+	case GradeHard:
+		return "hard"
+	case GradeGood:
+		return "good"
+	case GradeEasy:
+		return "easy"
+	default:
+		return "unknown"
+	}
+}
+
+// Label 返回评分的中文名称。
+func (g Grade) Label() string {
+	switch g {
+	case GradeAgain:
+		return "重来"
+	case GradeHard:
+		return "困难"
+	case GradeGood:
+		return "良好"
+	case GradeEasy:
+		return "简单"
+	default:
+		return "未知"
+	}
+}
+
+// Valid 判断评分是否为合法的四档之一。
+func (g Grade) Valid() bool {
+	return g >= GradeAgain && g <= GradeEasy
+}
+
+// ReviewLog 记录单次复习的结果。
+type ReviewLog struct {
+	At              time.Time `json:"at"`
+	Grade           Grade     `json:"grade"`
+	IntervalSeconds int64     `json:"interval_seconds"`
+	Ease            float64   `json:"ease"`
+}
+
 // ItemState 表示单个练习项的记忆状态。
 type ItemState struct {
+	// Stage 连续记住的次数，答错时归零
 	Stage int       `json:"stage"`
 	DueAt time.Time `json:"due_at"`
+	// Ease 难度系数，越小代表越难，复习间隔增长越慢
+	Ease float64 `json:"ease,omitempty"`
+	// IntervalSeconds 当前复习间隔（秒）
+	IntervalSeconds int64 `json:"interval_seconds,omitempty"`
+	// Lapses 已掌握后又遗忘的次数
+	Lapses int `json:"lapses,omitempty"`
+	// Reviews 累计复习次数
+	Reviews      int         `json:"reviews,omitempty"`
+	LastReviewAt time.Time   `json:"last_review_at"`
+	History      []ReviewLog `json:"history,omitempty"`
+}
+
+// Interval 返回当前复习间隔。
+func (s ItemState) Interval() time.Duration {
+	return time.Duration(s.IntervalSeconds) * time.Second
 }
 
 // Schedule 表示某个资源文件的记忆计划。
 type Schedule struct {
+	Version  int                  `json:"version"`
 	Items    map[string]ItemState `json:"items"`
 	filePath string               `json:"-"`
 }
 
 // Load 根据资源类型和文件名加载记忆计划，并确保所有条目存在。
 func Load(resourceType, fileName string, items []string) (*Schedule, error) {
-	baseDir := practice.GetUserDataDir()
-	currentLanguage := config.AppConfig.CurrentLanguage
-
-	safeFileName := sanitizeFileName(fileName)
-	path := filepath.Join(baseDir, "srs", currentLanguage, resourceType)
-	if err := os.MkdirAll(path, 0755); err != nil {
+	path := schedulePath(resourceType, fileName)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return nil, fmt.Errorf("创建SRS目录失败: %w", err)
 	}
 
-	path = filepath.Join(path, safeFileName+".json")
+	schedule, err := readSchedule(path, true)
+	if err != nil {
+		return nil, err
+	}
 
+	schedule.ensureItems(items)
+	if err := schedule.Save(); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Dir 返回某种语言下某类资源的记忆计划目录
+func Dir(language, resourceType string) string {
+	return filepath.Join(practice.GetUserDataDir(), "srs", language, resourceType)
+}
+
+// schedulePath 返回资源文件对应的记忆计划文件路径
+func schedulePath(resourceType, fileName string) string {
+	return filepath.Join(Dir(config.AppConfig.CurrentLanguage, resourceType), sanitizeFileName(fileName)+".json")
+}
+
+// readSchedule 读取并迁移记忆计划文件，文件不存在时返回空计划。
+// backup 为 true 时，升级旧版本文件前会保留一份备份。
+func readSchedule(path string, backup bool) (*Schedule, error) {
 	schedule := &Schedule{
 		Items:    make(map[string]ItemState),
 		filePath: path,
@@ -61,15 +172,23 @@ func Load(resourceType, fileName string, items []string) (*Schedule, error) {
 			if err := json.Unmarshal(data, schedule); err != nil {
 				return nil, fmt.Errorf("解析SRS文件失败: %w", err)
 			}
+			if backup && schedule.Version < scheduleVersion {
+				// 升级前保留一份旧文件，避免迁移出错时丢失已有的记忆计划
+				backupPath := path + ".bak"
+				if _, err := os.Stat(backupPath); os.IsNotExist(err) {
+					if err := os.WriteFile(backupPath, data, 0644); err != nil {
+						return nil, fmt.Errorf("备份SRS文件失败: %w", err)
+					}
+				}
+			}
 		}
 		schedule.filePath = path
 	}
-
-	schedule.ensureItems(items)
-	if err := schedule.Save(); err != nil {
-		return nil, err
+	if schedule.Items == nil {
+		schedule.Items = make(map[string]ItemState)
 	}
 
+	schedule.migrate()
 	return schedule, nil
 }
 
@@ -93,6 +212,7 @@ func (s *Schedule) Order(items []string) []int {
 		index int
 		due   time.Time
 		stage int
+		ease  float64
 	}
 
 	entries := make([]entry, 0, len(items))
@@ -103,6 +223,7 @@ func (s *Schedule) Order(items []string) []int {
 			index: idx,
 			due:   state.DueAt,
 			stage: state.Stage,
+			ease:  state.Ease,
 		})
 	}
 
@@ -111,6 +232,11 @@ func (s *Schedule) Order(items []string) []int {
 		ai := entries[i]
 		aj := entries[j]
 
+		// 同一时间到期时，难度系数更低（更难）的条目优先
+		if ai.stage == aj.stage && ai.ease != aj.ease && ai.due.Equal(aj.due) {
+			return ai.ease < aj.ease
+		}
+
 		di := ai.due
 		dj := aj.due
 
@@ -163,24 +289,181 @@ func (s *Schedule) Order(items []string) []int {
 	return ordered
 }
 
-// RecordResult 根据练习结果更新记忆计划。
+// RecordResult 根据练习结果更新记忆计划，正确视为"良好"，错误视为"重来"。
 func (s *Schedule) RecordResult(item string, correct bool) error {
+	if correct {
+		return s.RecordGrade(item, GradeGood)
+	}
+	return s.RecordGrade(item, GradeAgain)
+}
+
+// RecordGrade 根据复习评分更新记忆计划。
+func (s *Schedule) RecordGrade(item string, grade Grade) error {
 	if s == nil {
 		return nil
 	}
+	if !grade.Valid() {
+		return fmt.Errorf("无效的评分: %d", grade)
+	}
 
 	state := s.getState(item)
-	if correct {
-		if state.Stage < len(intervals)-1 {
-			state.Stage++
+	state = nextState(state, grade, time.Now())
+	s.setState(item, state)
+	return s.Save()
+}
+
+// State 返回条目当前的记忆状态。
+func (s *Schedule) State(item string) ItemState {
+	if s == nil {
+		return newItemState()
+	}
+	return s.getState(item)
+}
+
+// nextState 按改进的 SM-2 算法计算一次复习后的记忆状态：
+// 答错时重置连续次数并降低难度系数；答对时前两次使用固定的学习间隔，
+// 之后按上一次间隔乘以难度系数增长，"困难"增长较慢，"简单"额外加速。
+func nextState(state ItemState, grade Grade, now time.Time) ItemState {
+	if state.Ease <= 0 {
+		state.Ease = defaultEase
+	}
+	state.Ease = adjustEase(state.Ease, grade)
+
+	previous := state.Interval()
+	var interval time.Duration
+
+	switch {
+	case grade == GradeAgain:
+		if state.Stage > 0 {
+			state.Lapses++
 		}
-	} else {
 		state.Stage = 0
+		interval = relearnInterval
+	case state.Stage == 0:
+		switch grade {
+		case GradeHard:
+			interval = 5 * time.Minute
+		case GradeGood:
+			interval = 10 * time.Minute
+		default:
+			interval = 4 * day
+		}
+		state.Stage++
+	case state.Stage == 1:
+		switch grade {
+		case GradeHard:
+			interval = 12 * time.Hour
+		case GradeGood:
+			interval = day
+		default:
+			interval = 4 * day
+		}
+		state.Stage++
+	default:
+		base := previous
+		if base < day {
+			base = day
+		}
+		var factor float64
+		switch grade {
+		case GradeHard:
+			factor = 1.2
+		case GradeGood:
+			factor = state.Ease
+		default:
+			factor = state.Ease * 1.3
+		}
+		interval = time.Duration(float64(base) * factor)
+		if grade != GradeHard && interval < base+day {
+			interval = base + day
+		}
+		state.Stage++
 	}
 
-	state.DueAt = time.Now().Add(intervals[state.Stage])
-	s.setState(item, state)
-	return s.Save()
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+
+	state.IntervalSeconds = int64(interval / time.Second)
+	state.DueAt = now.Add(interval)
+	state.LastReviewAt = now
+	state.Reviews++
+	state.History = append(state.History, ReviewLog{
+		At:              now,
+		Grade:           grade,
+		IntervalSeconds: state.IntervalSeconds,
+		Ease:            state.Ease,
+	})
+	if len(state.History) > maxHistory {
+		state.History = state.History[len(state.History)-maxHistory:]
+	}
+
+	return state
+}
+
+// adjustEase 按 SM-2 公式调整难度系数，四档评分分别对应质量分 2~5。
+func adjustEase(ease float64, grade Grade) float64 {
+	quality := float64(grade) + 1
+	ease += 0.1 - (5-quality)*(0.08+(5-quality)*0.02)
+	ease = math.Max(minEase, math.Min(maxEase, ease))
+	return math.Round(ease*100) / 100
+}
+
+// DeriveGrade 根据答对前的错误次数和输入用时推断复习评分。
+// 答错两次及以上视为"重来"，答错一次或明显偏慢视为"困难"，
+// 一次答对且速度较快视为"简单"，其余为"良好"。length 为期望输入的字符数。
+func DeriveGrade(wrongAttempts int, elapsed time.Duration, length int) Grade {
+	switch {
+	case wrongAttempts >= 2:
+		return GradeAgain
+	case wrongAttempts == 1:
+		return GradeHard
+	}
+
+	if length < 1 {
+		length = 1
+	}
+	fast := time.Second + time.Duration(length)*300*time.Millisecond
+	slow := 3*time.Second + time.Duration(length)*time.Second
+
+	switch {
+	case elapsed > slow:
+		return GradeHard
+	case elapsed <= fast:
+		return GradeEasy
+	default:
+		return GradeGood
+	}
+}
+
+// migrate 将旧版本（固定阶梯）的记忆计划升级为当前格式，保留原有的到期时间与阶段。
+func (s *Schedule) migrate() {
+	if s.Version >= scheduleVersion {
+		return
+	}
+
+	for key, state := range s.Items {
+		if state.Ease <= 0 {
+			state.Ease = defaultEase
+		}
+		if state.IntervalSeconds == 0 && state.Stage > 0 {
+			stage := state.Stage
+			if stage >= len(legacyIntervals) {
+				stage = len(legacyIntervals) - 1
+			}
+			state.IntervalSeconds = int64(legacyIntervals[stage] / time.Second)
+		}
+		if state.Reviews == 0 {
+			state.Reviews = state.Stage
+		}
+		s.Items[key] = state
+	}
+
+	s.Version = scheduleVersion
+}
+
+func newItemState() ItemState {
+	return ItemState{Stage: 0, Ease: defaultEase}
 }
 
 // RemoveItem 从记忆计划中移除指定条目。
@@ -201,18 +484,13 @@ func (s *Schedule) ensureItems(items []string) {
 	for _, item := range items {
 		key := s.keyFor(item)
 		if _, exists := s.Items[key]; !exists {
-			s.Items[key] = ItemState{Stage: 0}
+			s.Items[key] = newItemState()
 		}
 	}
 }
 
 func (s *Schedule) keyFor(item string) string {
-	primary, _ := practice.ParseLine(item)
-	key := strings.TrimSpace(primary)
-	if key == "" {
-		key = strings.TrimSpace(item)
-	}
-	return key
+	return practice.EntryKey(item)
 }
 
 func (s *Schedule) getState(item string) ItemState {
@@ -220,7 +498,7 @@ func (s *Schedule) getState(item string) ItemState {
 	if state, ok := s.Items[key]; ok {
 		return state
 	}
-	return ItemState{Stage: 0}
+	return newItemState()
 }
 
 func (s *Schedule) setState(item string, state ItemState) {
@@ -0,0 +1,145 @@
+package srs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// 测试连续答对时复习间隔逐步增长
+func TestNextStateGrowsIntervalOnSuccess(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	state := newItemState()
+
+	var last time.Duration
+	for i := 0; i < 5; i++ {
+		state = nextState(state, GradeGood, now)
+		if state.Interval() <= last {
+			t.Fatalf("第 %d 次答对后间隔未增长: %v <= %v", i+1, state.Interval(), last)
+		}
+		last = state.Interval()
+	}
+
+	if state.Stage != 5 {
+		t.Errorf("期望连续次数为 5，实际 %d", state.Stage)
+	}
+	if state.Reviews != 5 || len(state.History) != 5 {
+		t.Errorf("复习记录不正确: reviews=%d history=%d", state.Reviews, len(state.History))
+	}
+	if !state.DueAt.Equal(now.Add(state.Interval())) {
+		t.Errorf("到期时间不正确: %v", state.DueAt)
+	}
+}
+
+// 测试不同评分对间隔和难度系数的影响
+func TestNextStateGrades(t *testing.T) {
+	now := time.Now()
+	base := ItemState{Stage: 3, Ease: defaultEase, IntervalSeconds: int64(10 * day / time.Second)}
+
+	hard := nextState(base, GradeHard, now)
+	good := nextState(base, GradeGood, now)
+	easy := nextState(base, GradeEasy, now)
+
+	if !(hard.Interval() < good.Interval() && good.Interval() < easy.Interval()) {
+		t.Errorf("间隔应满足 困难 < 良好 < 简单: %v %v %v", hard.Interval(), good.Interval(), easy.Interval())
+	}
+	if !(hard.Ease < good.Ease && good.Ease < easy.Ease) {
+		t.Errorf("难度系数应满足 困难 < 良好 < 简单: %v %v %v", hard.Ease, good.Ease, easy.Ease)
+	}
+
+	again := nextState(base, GradeAgain, now)
+	if again.Stage != 0 || again.Lapses != 1 {
+		t.Errorf("答错后应重置连续次数并增加遗忘次数: stage=%d lapses=%d", again.Stage, again.Lapses)
+	}
+	if again.Interval() != relearnInterval {
+		t.Errorf("答错后间隔应为 %v，实际 %v", relearnInterval, again.Interval())
+	}
+	if again.Ease >= base.Ease {
+		t.Errorf("答错后难度系数应降低: %v", again.Ease)
+	}
+}
+
+// 测试难度系数不会低于下限
+func TestAdjustEaseLowerBound(t *testing.T) {
+	ease := defaultEase
+	for i := 0; i < 20; i++ {
+		ease = adjustEase(ease, GradeAgain)
+	}
+	if ease != minEase {
+		t.Errorf("难度系数应停留在下限 %v，实际 %v", minEase, ease)
+	}
+}
+
+// 测试旧版本记忆计划的迁移
+func TestMigrateLegacySchedule(t *testing.T) {
+	due := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	legacy := []byte(`{"items":{"apple":{"stage":4,"due_at":"2024-05-01T12:00:00Z"},"banana":{"stage":0,"due_at":"0001-01-01T00:00:00Z"}}}`)
+
+	var schedule Schedule
+	if err := json.Unmarshal(legacy, &schedule); err != nil {
+		t.Fatalf("解析旧版本记忆计划失败: %v", err)
+	}
+	schedule.migrate()
+
+	if schedule.Version != scheduleVersion {
+		t.Errorf("迁移后版本应为 %d，实际 %d", scheduleVersion, schedule.Version)
+	}
+
+	apple := schedule.Items["apple"]
+	if apple.Stage != 4 || !apple.DueAt.Equal(due) {
+		t.Errorf("迁移后应保留阶段与到期时间: %+v", apple)
+	}
+	if apple.Ease != defaultEase {
+		t.Errorf("迁移后难度系数应为默认值，实际 %v", apple.Ease)
+	}
+	if apple.Interval() != legacyIntervals[4] {
+		t.Errorf("迁移后间隔应为旧阶梯对应值 %v，实际 %v", legacyIntervals[4], apple.Interval())
+	}
+
+	banana := schedule.Items["banana"]
+	if banana.Interval() != 0 || !banana.DueAt.IsZero() {
+		t.Errorf("未复习条目迁移后应保持新条目状态: %+v", banana)
+	}
+}
+
+// 测试根据错误次数和用时推断评分
+func TestDeriveGrade(t *testing.T) {
+	tests := []struct {
+		name    string
+		wrong   int
+		elapsed time.Duration
+		length  int
+		want    Grade
+	}{
+		{"答错三次后答对", 3, time.Second, 5, GradeAgain},
+		{"答错一次后答对", 1, time.Second, 5, GradeHard},
+		{"快速答对", 0, 2 * time.Second, 5, GradeEasy},
+		{"正常答对", 0, 5 * time.Second, 5, GradeGood},
+		{"很慢答对", 0, 20 * time.Second, 5, GradeHard},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeriveGrade(tt.wrong, tt.elapsed, tt.length); got != tt.want {
+				t.Errorf("DeriveGrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// 测试筛选已到期条目
+func TestDueItems(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	schedule := &Schedule{Items: map[string]ItemState{
+		"apple":  {Stage: 2, DueAt: now.Add(-time.Hour)},
+		"banana": {Stage: 3, DueAt: now.Add(time.Hour)},
+		"cherry": newItemState(),
+		"stale":  {Stage: 1, DueAt: now.Add(-day)},
+	}}
+	items := []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃", "apple ->> 苹果"}
+
+	due := dueItems(schedule, items, now)
+	if len(due) != 1 || due[0] != "apple ->> 苹果" {
+		t.Errorf("期望只有 apple 到期，实际 %v", due)
+	}
+}
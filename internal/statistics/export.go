@@ -0,0 +1,334 @@
+package statistics
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 导出格式
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+const dateLayout = "2006-01-02"
+
+// csvHeader CSV 导出的列，按键错误分布无法用单列表示，不包含在 CSV 中
+var csvHeader = []string{
+	"timestamp", "date", "language", "resource_type", "file_name", "total", "correct", "incorrect",
+	"accuracy", "duration_seconds", "order_mode", "completed",
+	"keystrokes", "backspaces", "correct_chars", "wrong_chars", "active_seconds", "gross_wpm", "net_wpm",
+}
+
+// ParseDate 解析 YYYY-MM-DD 格式的日期，空字符串表示不限制
+func ParseDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if _, err := time.Parse(dateLayout, value); err != nil {
+		return "", fmt.Errorf("日期格式无效（应为 YYYY-MM-DD）: %s", value)
+	}
+	return value, nil
+}
+
+// GetSessionsInRange 返回 [from, to] 日期范围内的练习记录（含首尾），按时间先后排列。
+// from 或 to 为空时表示该端不限制。
+func GetSessionsInRange(from, to string) ([]SessionRecord, error) {
+	dir, err := statsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []SessionRecord
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		date := strings.TrimSuffix(entry.Name(), ".json")
+		if (from != "" && date < from) || (to != "" && date > to) {
+			continue
+		}
+		dayRecords, err := GetSessionsByDate(date)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, dayRecords...)
+	}
+
+	sortByTime(records)
+	return records, nil
+}
+
+// CheckFormat 检查导出格式是否受支持，空字符串视为 json
+func CheckFormat(format string) error {
+	switch strings.ToLower(format) {
+	case FormatCSV, FormatJSON, "":
+		return nil
+	}
+	return fmt.Errorf("不支持的导出格式: %s（可选 csv、json）", format)
+}
+
+// Export 将日期范围内的练习记录按指定格式写出，返回导出的记录数
+func Export(w io.Writer, format, from, to string) (int, error) {
+	if err := CheckFormat(format); err != nil {
+		return 0, err
+	}
+	records, err := GetSessionsInRange(from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	if strings.ToLower(format) == FormatCSV {
+		err = WriteCSV(w, records)
+	} else {
+		err = WriteJSON(w, records)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// WriteJSON 以 JSON 数组写出练习记录
+func WriteJSON(w io.Writer, records []SessionRecord) error {
+	if records == nil {
+		records = []SessionRecord{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(records)
+}
+
+// WriteCSV 以 CSV 写出练习记录，第一行为表头
+func WriteCSV(w io.Writer, records []SessionRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		typing := TypingStats{}
+		if record.Typing != nil {
+			typing = *record.Typing
+		}
+		row := []string{
+			record.Timestamp.Format(time.RFC3339Nano),
+			record.Timestamp.Local().Format(dateLayout),
+			record.Language,
+			record.ResourceType,
+			record.FileName,
+			strconv.Itoa(record.Total),
+			strconv.Itoa(record.Correct),
+			strconv.Itoa(record.Incorrect),
+			strconv.FormatFloat(record.Accuracy, 'f', 2, 64),
+			strconv.FormatInt(record.DurationSeconds, 10),
+			record.OrderMode,
+			strconv.FormatBool(record.Completed),
+			strconv.Itoa(typing.Keystrokes),
+			strconv.Itoa(typing.Backspaces),
+			strconv.Itoa(typing.CorrectChars),
+			strconv.Itoa(typing.WrongChars),
+			strconv.FormatFloat(typing.ActiveSeconds, 'f', 2, 64),
+			strconv.FormatFloat(typing.GrossWPM, 'f', 2, 64),
+			strconv.FormatFloat(typing.NetWPM, 'f', 2, 64),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadExport 读取导出文件，扩展名为 .csv 时按 CSV 解析，否则按 JSON 解析
+func ReadExport(path string) ([]SessionRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("打开导入文件失败: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return ReadCSV(file)
+	}
+	return ReadJSON(file)
+}
+
+// ReadJSON 解析 JSON 格式的导出数据
+func ReadJSON(r io.Reader) ([]SessionRecord, error) {
+	var records []SessionRecord
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, fmt.Errorf("解析 JSON 导入文件失败: %w", err)
+	}
+	return records, nil
+}
+
+// ReadCSV 解析 CSV 格式的导出数据，按表头定位各列
+func ReadCSV(r io.Reader) ([]SessionRecord, error) {
+	reader := csv.NewReader(r)
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析 CSV 导入文件失败: %w", err)
+	}
+	if len(rows) == 0 {
+		return []SessionRecord{}, nil
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["timestamp"]; !ok {
+		return nil, fmt.Errorf("CSV 导入文件缺少 timestamp 列")
+	}
+
+	records := make([]SessionRecord, 0, len(rows)-1)
+	for lineNo, row := range rows[1:] {
+		field := func(name string) string {
+			if idx, ok := columns[name]; ok && idx < len(row) {
+				return strings.TrimSpace(row[idx])
+			}
+			return ""
+		}
+
+		timestamp, err := time.Parse(time.RFC3339Nano, field("timestamp"))
+		if err != nil {
+			return nil, fmt.Errorf("CSV 第 %d 行时间格式无效: %w", lineNo+2, err)
+		}
+
+		record := SessionRecord{
+			Timestamp:       timestamp,
+			Language:        field("language"),
+			ResourceType:    field("resource_type"),
+			FileName:        field("file_name"),
+			Total:           atoi(field("total")),
+			Correct:         atoi(field("correct")),
+			Incorrect:       atoi(field("incorrect")),
+			Accuracy:        atof(field("accuracy")),
+			DurationSeconds: int64(atoi(field("duration_seconds"))),
+			OrderMode:       field("order_mode"),
+			Completed:       field("completed") == "true",
+		}
+
+		typing := TypingStats{
+			Keystrokes:    atoi(field("keystrokes")),
+			Backspaces:    atoi(field("backspaces")),
+			CorrectChars:  atoi(field("correct_chars")),
+			WrongChars:    atoi(field("wrong_chars")),
+			ActiveSeconds: atof(field("active_seconds")),
+			GrossWPM:      atof(field("gross_wpm")),
+			NetWPM:        atof(field("net_wpm")),
+		}
+		if typing.Keystrokes > 0 || typing.Backspaces > 0 {
+			record.Typing = &typing
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ImportSessions 将其他设备导出的练习记录合并到本地统计中。
+// 时间、资源类型和文件名都相同的记录视为重复，不会重复写入。返回新增和跳过的记录数。
+func ImportSessions(records []SessionRecord) (int, int, error) {
+	dir, err := statsDir()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	byDate := make(map[string][]SessionRecord)
+	for _, record := range records {
+		date := record.Timestamp.Local().Format(dateLayout)
+		byDate[date] = append(byDate[date], record)
+	}
+
+	added, skipped := 0, 0
+	for date, incoming := range byDate {
+		path := filepath.Join(dir, date+".json")
+
+		var existing []SessionRecord
+		if data, err := os.ReadFile(path); err == nil && len(data) > 0 {
+			if err := json.Unmarshal(data, &existing); err != nil {
+				return added, skipped, fmt.Errorf("解析统计文件失败: %w", err)
+			}
+		}
+
+		merged, newCount := mergeRecords(existing, incoming)
+		added += newCount
+		skipped += len(incoming) - newCount
+		if newCount == 0 {
+			continue
+		}
+
+		data, err := json.MarshalIndent(merged, "", "  ")
+		if err != nil {
+			return added, skipped, err
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return added, skipped, fmt.Errorf("写入统计文件失败: %w", err)
+		}
+	}
+
+	return added, skipped, nil
+}
+
+// mergeRecords 合并两组记录并去重，返回按时间排序的结果和新增数量
+func mergeRecords(existing, incoming []SessionRecord) ([]SessionRecord, int) {
+	seen := make(map[string]struct{}, len(existing)+len(incoming))
+	merged := make([]SessionRecord, 0, len(existing)+len(incoming))
+	for _, record := range existing {
+		seen[recordKey(record)] = struct{}{}
+		merged = append(merged, record)
+	}
+
+	added := 0
+	for _, record := range incoming {
+		key := recordKey(record)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		merged = append(merged, record)
+		added++
+	}
+
+	sortByTime(merged)
+	return merged, added
+}
+
+// recordKey 生成用于去重的记录标识：时间（精确到纳秒，统一为 UTC）+ 资源类型 + 文件名
+func recordKey(record SessionRecord) string {
+	return record.Timestamp.UTC().Format(time.RFC3339Nano) + "|" + record.ResourceType + "|" + record.FileName
+}
+
+func sortByTime(records []SessionRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+}
+
+func atoi(value string) int {
+	n, _ := strconv.Atoi(value)
+	return n
+}
+
+func atof(value string) float64 {
+	f, _ := strconv.ParseFloat(value, 64)
+	return f
+}
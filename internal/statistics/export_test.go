@@ -0,0 +1,90 @@
+package statistics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// 测试 CSV 导出后可以完整导入
+func TestCSVRoundTrip(t *testing.T) {
+	records := []SessionRecord{
+		{
+			Timestamp:       time.Date(2024, 3, 1, 8, 30, 15, 123456789, time.UTC),
+			ResourceType:    "words",
+			FileName:        "四级单词, 第一部分",
+			Total:           10,
+			Correct:         8,
+			Incorrect:       2,
+			Accuracy:        80,
+			DurationSeconds: 95,
+			OrderMode:       "random",
+			Completed:       true,
+			Typing:          &TypingStats{Keystrokes: 60, Backspaces: 3, CorrectChars: 50, WrongChars: 4, ActiveSeconds: 60, GrossWPM: 12, NetWPM: 10},
+		},
+		{
+			Timestamp:    time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+			ResourceType: "phrases",
+			FileName:     "greetings",
+			Total:        3,
+			Correct:      3,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, records); err != nil {
+		t.Fatalf("导出 CSV 失败: %v", err)
+	}
+
+	parsed, err := ReadCSV(&buf)
+	if err != nil {
+		t.Fatalf("解析 CSV 失败: %v", err)
+	}
+	if len(parsed) != len(records) {
+		t.Fatalf("期望 %d 条记录，实际 %d", len(records), len(parsed))
+	}
+
+	first := parsed[0]
+	if !first.Timestamp.Equal(records[0].Timestamp) || first.FileName != records[0].FileName || !first.Completed {
+		t.Errorf("第一条记录不一致: %+v", first)
+	}
+	if first.Typing == nil || first.Typing.NetWPM != 10 || first.Typing.Keystrokes != 60 {
+		t.Errorf("按键数据不一致: %+v", first.Typing)
+	}
+	if parsed[1].Typing != nil {
+		t.Errorf("没有按键数据的记录不应生成 typing 字段")
+	}
+}
+
+// 测试合并时按时间、类型和文件名去重
+func TestMergeRecordsDeduplicates(t *testing.T) {
+	base := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	existing := []SessionRecord{
+		{Timestamp: base, ResourceType: "words", FileName: "a"},
+	}
+	incoming := []SessionRecord{
+		{Timestamp: base.In(time.FixedZone("CST", 8*3600)), ResourceType: "words", FileName: "a"},
+		{Timestamp: base, ResourceType: "words", FileName: "b"},
+		{Timestamp: base.Add(-time.Hour), ResourceType: "words", FileName: "a"},
+	}
+
+	merged, added := mergeRecords(existing, incoming)
+	if added != 2 || len(merged) != 3 {
+		t.Fatalf("期望新增 2 条、合计 3 条，实际新增 %d、合计 %d", added, len(merged))
+	}
+	if !merged[0].Timestamp.Equal(base.Add(-time.Hour)) {
+		t.Errorf("合并结果应按时间排序: %v", merged[0].Timestamp)
+	}
+}
+
+// 测试导出格式在写文件前即可校验
+func TestCheckFormat(t *testing.T) {
+	for _, format := range []string{"csv", "JSON", ""} {
+		if err := CheckFormat(format); err != nil {
+			t.Errorf("格式 %q 应受支持: %v", format, err)
+		}
+	}
+	if err := CheckFormat("xml"); err == nil {
+		t.Errorf("格式 xml 应被拒绝")
+	}
+}
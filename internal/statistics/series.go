@@ -0,0 +1,74 @@
+package statistics
+
+import (
+	"sort"
+	"time"
+)
+
+// TypeDuration 表示某种资源类型的累计练习时长
+type TypeDuration struct {
+	ResourceType string
+	Seconds      int64
+}
+
+// GetDailySeries 返回截至 today 的最近 days 天的每日汇总（按日期先后排列），
+// 没有练习记录的日子也会包含在内，数值为 0。
+func GetDailySeries(days int, today time.Time) ([]DailySummary, error) {
+	if days <= 0 {
+		return []DailySummary{}, nil
+	}
+
+	summaries, err := GetDailySummaries()
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]DailySummary, len(summaries))
+	for _, summary := range summaries {
+		byDate[summary.Date] = summary
+	}
+
+	series := make([]DailySummary, 0, days)
+	start := today.In(time.Local).AddDate(0, 0, -(days - 1))
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i).Format(dateLayout)
+		summary, ok := byDate[date]
+		if !ok {
+			summary = DailySummary{Date: date}
+		}
+		series = append(series, summary)
+	}
+
+	return series, nil
+}
+
+// GetDurationByType 返回日期范围内各资源类型的练习时长，按 order 中的顺序排列，
+// 不在 order 中的类型按名称追加在后面。
+func GetDurationByType(from, to string, order []string) ([]TypeDuration, error) {
+	records, err := GetSessionsInRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int64)
+	for _, record := range records {
+		totals[record.ResourceType] += record.DurationSeconds
+	}
+
+	result := make([]TypeDuration, 0, len(totals))
+	for _, resourceType := range order {
+		result = append(result, TypeDuration{ResourceType: resourceType, Seconds: totals[resourceType]})
+		delete(totals, resourceType)
+	}
+
+	var extra []string
+	for resourceType := range totals {
+		extra = append(extra, resourceType)
+	}
+	sort.Strings(extra)
+	for _, resourceType := range extra {
+		result = append(result, TypeDuration{ResourceType: resourceType, Seconds: totals[resourceType]})
+	}
+
+	return result, nil
+}
@@ -9,31 +9,37 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ajilisiwei/mllt-cli/internal/config"
 	"github.com/ajilisiwei/mllt-cli/internal/practice"
 )
 
 // SessionRecord 记录一次练习的统计数据
 type SessionRecord struct {
-	Timestamp       time.Time `json:"timestamp"`
-	ResourceType    string    `json:"resource_type"`
-	FileName        string    `json:"file_name"`
-	Total           int       `json:"total"`
-	Correct         int       `json:"correct"`
-	Incorrect       int       `json:"incorrect"`
-	Accuracy        float64   `json:"accuracy"`
-	DurationSeconds int64     `json:"duration_seconds"`
-	OrderMode       string    `json:"order_mode"`
-	Completed       bool      `json:"completed"`
+	Timestamp       time.Time    `json:"timestamp"`
+	Language        string       `json:"language,omitempty"` // 练习的语言，旧版本的记录为空
+	ResourceType    string       `json:"resource_type"`
+	FileName        string       `json:"file_name"`
+	Total           int          `json:"total"`
+	Correct         int          `json:"correct"`
+	Incorrect       int          `json:"incorrect"`
+	Accuracy        float64      `json:"accuracy"`
+	DurationSeconds int64        `json:"duration_seconds"`
+	OrderMode       string       `json:"order_mode"`
+	Completed       bool         `json:"completed"`
+	Typing          *TypingStats `json:"typing,omitempty"`
 }
 
 // DailySummary 汇总某一天的统计数据
 type DailySummary struct {
-	Date         string
-	SessionCount int
-	Total        int
-	Correct      int
-	Incorrect    int
-	Accuracy     float64
+	Date            string
+	SessionCount    int
+	Total           int
+	Correct         int
+	Incorrect       int
+	Accuracy        float64
+	DurationSeconds int64   // 当天的练习总时长（秒）
+	NetWPM          float64 // 当天的平均净打字速度，无按键数据时为 0
+	CharAccuracy    float64 // 当天的按键准确率，无按键数据时为 0
 }
 
 func statsDir() (string, error) {
@@ -45,12 +51,15 @@ func statsDir() (string, error) {
 	return dir, nil
 }
 
-// LogSession 记录一次练习结果
+// LogSession 记录一次练习结果，没有指定语言时记为当前语言
 func LogSession(record SessionRecord) error {
 	dir, err := statsDir()
 	if err != nil {
 		return err
 	}
+	if record.Language == "" {
+		record.Language = config.AppConfig.CurrentLanguage
+	}
 
 	date := record.Timestamp.Local().Format("2006-01-02")
 	path := filepath.Join(dir, date+".json")
@@ -102,16 +111,27 @@ func GetDailySummaries() ([]DailySummary, error) {
 		}
 
 		summary := DailySummary{Date: date}
+		var typing TypingStats
 		for _, record := range records {
 			summary.SessionCount++
 			summary.Total += record.Total
 			summary.Correct += record.Correct
 			summary.Incorrect += record.Incorrect
+			summary.DurationSeconds += record.DurationSeconds
+			if record.Typing != nil {
+				typing.Keystrokes += record.Typing.Keystrokes
+				typing.CorrectChars += record.Typing.CorrectChars
+				typing.WrongChars += record.Typing.WrongChars
+				typing.ActiveSeconds += record.Typing.ActiveSeconds
+			}
 		}
 
 		if summary.Total > 0 {
 			summary.Accuracy = float64(summary.Correct) / float64(summary.Total) * 100
 		}
+		typing.ComputeSpeed()
+		summary.NetWPM = typing.NetWPM
+		summary.CharAccuracy = typing.CharAccuracy()
 
 		summaries = append(summaries, summary)
 	}
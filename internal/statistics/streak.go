@@ -0,0 +1,141 @@
+package statistics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+)
+
+// GoalProgress 表示某一天的目标完成情况
+type GoalProgress struct {
+	config.DailyGoalConfig
+	DoneItems   int     // 当天答对的项目数
+	DoneMinutes float64 // 当天的练习分钟数
+}
+
+// Reached 判断当天是否完成了全部已设置的目标
+func (p GoalProgress) Reached() bool {
+	if !p.Enabled() {
+		return false
+	}
+	if p.Items > 0 && p.DoneItems < p.Items {
+		return false
+	}
+	if p.Minutes > 0 && p.DoneMinutes < float64(p.Minutes) {
+		return false
+	}
+	return true
+}
+
+// Add 返回叠加了本次练习数据后的进度
+func (p GoalProgress) Add(items int, duration time.Duration) GoalProgress {
+	p.DoneItems += items
+	p.DoneMinutes += duration.Minutes()
+	return p
+}
+
+// Streak 表示连续打卡情况
+type Streak struct {
+	Current     int    // 当前连续天数（今天尚未打卡时从昨天往前计算）
+	Longest     int    // 历史最长连续天数
+	TodayDone   bool   // 今天是否已打卡
+	LastDate    string // 最近一次打卡的日期
+	QualifyDays int    // 累计打卡天数
+}
+
+// GetGoalProgress 返回指定日期（YYYY-MM-DD）的目标完成情况
+func GetGoalProgress(date string, goal config.DailyGoalConfig) (GoalProgress, error) {
+	progress := GoalProgress{DailyGoalConfig: goal}
+
+	records, err := GetSessionsByDate(date)
+	if err != nil {
+		return progress, err
+	}
+
+	for _, record := range records {
+		progress.DoneItems += record.Correct
+		progress.DoneMinutes += float64(record.DurationSeconds) / 60
+	}
+	return progress, nil
+}
+
+// GetStreak 根据每日统计计算连续打卡天数。
+// 设置了目标时，只有完成目标的日子才算打卡；未设置目标时，有练习记录即算打卡。
+func GetStreak(goal config.DailyGoalConfig, today time.Time) (Streak, error) {
+	summaries, err := GetDailySummaries()
+	if err != nil {
+		return Streak{}, err
+	}
+
+	days := make(map[string]bool, len(summaries))
+	for _, summary := range summaries {
+		progress := GoalProgress{
+			DailyGoalConfig: goal,
+			DoneItems:       summary.Correct,
+			DoneMinutes:     float64(summary.DurationSeconds) / 60,
+		}
+		if goal.Enabled() {
+			days[summary.Date] = progress.Reached()
+		} else {
+			days[summary.Date] = summary.Total > 0
+		}
+	}
+
+	return CalculateStreak(days, today), nil
+}
+
+// CalculateStreak 根据每天是否打卡计算当前和最长连续天数
+func CalculateStreak(days map[string]bool, today time.Time) Streak {
+	var streak Streak
+
+	var dates []time.Time
+	for date, qualified := range days {
+		if !qualified {
+			continue
+		}
+		parsed, err := time.ParseInLocation(dateLayout, date, time.Local)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, parsed)
+	}
+	if len(dates) == 0 {
+		return streak
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	streak.QualifyDays = len(dates)
+	streak.LastDate = dates[len(dates)-1].Format(dateLayout)
+
+	run := 0
+	for i, date := range dates {
+		if i > 0 && isNextDay(dates[i-1], date) {
+			run++
+		} else {
+			run = 1
+		}
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	todayStr := today.In(time.Local).Format(dateLayout)
+	streak.TodayDone = days[todayStr]
+
+	// 从今天（或今天未打卡时从昨天）开始往前数连续打卡的天数
+	cursor := today.In(time.Local)
+	if !streak.TodayDone {
+		cursor = cursor.AddDate(0, 0, -1)
+	}
+	for days[cursor.Format(dateLayout)] {
+		streak.Current++
+		cursor = cursor.AddDate(0, 0, -1)
+	}
+
+	return streak
+}
+
+func isNextDay(prev, next time.Time) bool {
+	return prev.AddDate(0, 0, 1).Format(dateLayout) == next.Format(dateLayout)
+}
@@ -0,0 +1,54 @@
+package statistics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+)
+
+// 测试连续打卡天数的计算
+func TestCalculateStreak(t *testing.T) {
+	today := time.Date(2024, 3, 10, 15, 0, 0, 0, time.Local)
+	days := map[string]bool{
+		"2024-03-01": true,
+		"2024-03-02": true,
+		"2024-03-03": true,
+		"2024-03-04": true,
+		"2024-03-05": false,
+		"2024-03-08": true,
+		"2024-03-09": true,
+	}
+
+	streak := CalculateStreak(days, today)
+	if streak.Current != 2 || streak.TodayDone {
+		t.Errorf("今天未打卡时应从昨天往前计算: %+v", streak)
+	}
+	if streak.Longest != 4 || streak.QualifyDays != 6 || streak.LastDate != "2024-03-09" {
+		t.Errorf("最长连续天数或累计天数不正确: %+v", streak)
+	}
+
+	days["2024-03-10"] = true
+	streak = CalculateStreak(days, today)
+	if streak.Current != 3 || !streak.TodayDone {
+		t.Errorf("今天已打卡时应包含今天: %+v", streak)
+	}
+
+	if empty := CalculateStreak(map[string]bool{}, today); empty.Current != 0 || empty.Longest != 0 {
+		t.Errorf("没有记录时连续天数应为 0: %+v", empty)
+	}
+}
+
+// 测试目标完成判断
+func TestGoalProgressReached(t *testing.T) {
+	progress := GoalProgress{DailyGoalConfig: config.DailyGoalConfig{Items: 100, Minutes: 10}, DoneItems: 120, DoneMinutes: 9.5}
+	if progress.Reached() {
+		t.Error("时长未达标时不应视为完成")
+	}
+	if !progress.Add(0, 30*time.Second).Reached() {
+		t.Error("补足时长后应视为完成")
+	}
+	if (GoalProgress{}).Reached() {
+		t.Error("未设置目标时不应视为完成")
+	}
+}
@@ -0,0 +1,145 @@
+package statistics
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// charsPerWord 计算 WPM 时每个"单词"折合的字符数（打字测试通用标准）
+const charsPerWord = 5
+
+// TypingStats 记录一次练习的按键级统计数据
+type TypingStats struct {
+	Keystrokes    int            `json:"keystrokes"`           // 输入的字符按键数
+	Backspaces    int            `json:"backspaces"`           // 退格次数
+	CorrectChars  int            `json:"correct_chars"`        // 已答对内容的字符数
+	WrongChars    int            `json:"wrong_chars"`          // 输入时与期望字符不一致的次数
+	ActiveSeconds float64        `json:"active_seconds"`       // 实际打字用时（每题从首次按键到提交）
+	GrossWPM      float64        `json:"gross_wpm"`            // 毛速度：全部按键折合的每分钟单词数
+	NetWPM        float64        `json:"net_wpm"`              // 净速度：答对字符折合的每分钟单词数
+	KeyErrors     map[string]int `json:"key_errors,omitempty"` // 期望字符 -> 输错次数
+}
+
+// ComputeSpeed 根据按键数与用时计算毛速度和净速度
+func (t *TypingStats) ComputeSpeed() {
+	t.GrossWPM = 0
+	t.NetWPM = 0
+	if t.ActiveSeconds <= 0 {
+		return
+	}
+	t.GrossWPM = float64(t.Keystrokes) * 60 / (charsPerWord * t.ActiveSeconds)
+	t.NetWPM = float64(t.CorrectChars) * 60 / (charsPerWord * t.ActiveSeconds)
+}
+
+// CombineTyping 合并两段练习的按键统计并重新计算速度，例如中途退出前后的两部分；其中一个为 nil 时返回另一个的副本
+func CombineTyping(a, b *TypingStats) *TypingStats {
+	if a == nil && b == nil {
+		return nil
+	}
+	combined := &TypingStats{}
+	for _, part := range []*TypingStats{a, b} {
+		if part == nil {
+			continue
+		}
+		combined.Keystrokes += part.Keystrokes
+		combined.Backspaces += part.Backspaces
+		combined.CorrectChars += part.CorrectChars
+		combined.WrongChars += part.WrongChars
+		combined.ActiveSeconds += part.ActiveSeconds
+		for key, count := range part.KeyErrors {
+			if combined.KeyErrors == nil {
+				combined.KeyErrors = make(map[string]int)
+			}
+			combined.KeyErrors[key] += count
+		}
+	}
+	combined.ComputeSpeed()
+	return combined
+}
+
+// CharAccuracy 返回按键准确率（百分比）
+func (t TypingStats) CharAccuracy() float64 {
+	if t.Keystrokes == 0 {
+		return 0
+	}
+	accuracy := float64(t.Keystrokes-t.WrongChars) / float64(t.Keystrokes) * 100
+	if accuracy < 0 {
+		return 0
+	}
+	return accuracy
+}
+
+// KeyErrorCount 表示某个字符的累计输错次数
+type KeyErrorCount struct {
+	Key   string
+	Count int
+}
+
+// GetAllSessions 返回所有日期的练习记录，按时间倒序排列
+func GetAllSessions() ([]SessionRecord, error) {
+	dir, err := statsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []SessionRecord
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		records, err := GetSessionsByDate(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, records...)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Timestamp.After(all[j].Timestamp)
+	})
+
+	return all, nil
+}
+
+// AggregateKeyErrors 汇总多次练习中各字符的输错次数
+func AggregateKeyErrors(records []SessionRecord) map[string]int {
+	totals := make(map[string]int)
+	for _, record := range records {
+		if record.Typing == nil {
+			continue
+		}
+		for key, count := range record.Typing.KeyErrors {
+			totals[key] += count
+		}
+	}
+	return totals
+}
+
+// TopKeyErrors 按输错次数从多到少返回前 limit 个字符，limit <= 0 时返回全部
+func TopKeyErrors(errors map[string]int, limit int) []KeyErrorCount {
+	counts := make([]KeyErrorCount, 0, len(errors))
+	for key, count := range errors {
+		if count > 0 {
+			counts = append(counts, KeyErrorCount{Key: key, Count: count})
+		}
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Key < counts[j].Key
+	})
+
+	if limit > 0 && len(counts) > limit {
+		counts = counts[:limit]
+	}
+	return counts
+}
@@ -0,0 +1,94 @@
+// Package textnorm 提供与语言相关的文本规范化，用于单词匹配模式下比较用户输入与答案。
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+	"golang.org/x/text/width"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+)
+
+// Unicode 规范化形式
+const (
+	FormNFC  = "nfc"  // 只合并等价字符，保留全角、半角、上标等兼容字符
+	FormNFKC = "nfkc" // 同时折叠全角/半角等兼容字符，例如"ｶ"→"カ"、"Ａ"→"A"、"①"→"1"
+)
+
+// Options 文本规范化选项
+type Options struct {
+	Form           string // Unicode 规范化形式：nfc 或 nfkc
+	FoldDiacritics bool   // 是否去掉变音符号，例如"café"→"cafe"
+	IgnoreSpaces   bool   // 是否忽略所有空白，适用于不以空格分词的语言
+}
+
+// DefaultOptions 返回语言的默认规范化选项：
+// 日语和中文不以空格分词，默认忽略空白；其余语言保留单词之间的空格。
+func DefaultOptions(language string) Options {
+	options := Options{Form: FormNFKC}
+	switch strings.ToLower(language) {
+	case "japanese", "chinese":
+		options.IgnoreSpaces = true
+	}
+	return options
+}
+
+// ForLanguage 返回语言的规范化选项，配置文件 normalization 中设置了该语言时以配置为准
+func ForLanguage(language string) Options {
+	setting, ok := config.AppConfig.Normalization[strings.ToLower(language)]
+	if !ok {
+		return DefaultOptions(language)
+	}
+	return Options{
+		Form:           setting.Form,
+		FoldDiacritics: setting.FoldDiacritics,
+		IgnoreSpaces:   setting.IgnoreSpaces,
+	}
+}
+
+// Normalize 规范化文本：按 Form 做 Unicode 规范化（nfkc 同时折叠全角/半角），
+// 转为小写，去掉标点和符号，并合并多余的空白。
+// 保留所有语言的字母、数字和组合符号，因此假名、汉字、带重音的字母和西里尔字母都不会被删除。
+func Normalize(text string, options Options) string {
+	if options.Form == FormNFC {
+		text = norm.NFC.String(text)
+	} else {
+		text = norm.NFKC.String(width.Fold.String(text))
+	}
+	if options.FoldDiacritics {
+		text = foldDiacritics(text)
+	}
+	text = strings.ToLower(text)
+
+	var builder strings.Builder
+	pendingSpace := false
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			if pendingSpace && builder.Len() > 0 {
+				builder.WriteRune(' ')
+			}
+			pendingSpace = false
+			builder.WriteRune(r)
+		case unicode.IsSpace(r):
+			pendingSpace = !options.IgnoreSpaces
+		}
+	}
+	return builder.String()
+}
+
+// foldDiacritics 分解字符后去掉非间距组合符号（重音、变音等），再重新组合。
+// 注意日语的浊音符号也属于组合符号，对日语开启后"が"会变成"か"。
+func foldDiacritics(text string) string {
+	decomposed := norm.NFD.String(text)
+	var builder strings.Builder
+	for _, r := range decomposed {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return norm.NFC.String(builder.String())
+}
@@ -0,0 +1,65 @@
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+)
+
+// 测试各种文字的规范化结果，确保非 ASCII 字母不会被删除
+func TestNormalize(t *testing.T) {
+	english := DefaultOptions("english")
+	japanese := DefaultOptions("japanese")
+	folding := Options{Form: FormNFKC, FoldDiacritics: true}
+
+	tests := []struct {
+		name     string
+		input    string
+		options  Options
+		expected string
+	}{
+		{"英文标点与大小写", "It's a beautiful day, isn't it?", english, "its a beautiful day isnt it"},
+		{"保留重音字母", "Café crème", english, "café crème"},
+		{"合并等价的组合字符", "Café", english, "café"},
+		{"去掉变音符号", "Über café", folding, "uber cafe"},
+		{"西里尔字母", "Привет, мир!", english, "привет мир"},
+		{"日语忽略空格和标点", "こんにちは、 世界。", japanese, "こんにちは世界"},
+		{"半角片假名折叠", "ｶﾀｶﾅ", japanese, "カタカナ"},
+		{"全角字母和数字折叠", "ＡＢＣ１２３", english, "abc123"},
+		{"日语保留浊音", "がっこう", japanese, "がっこう"},
+		{"NFC 保留兼容字符", "①", Options{Form: FormNFC}, "①"},
+		{"NFC 不折叠全角和半角", "ｶＡ", Options{Form: FormNFC}, "ｶａ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.input, tt.options); got != tt.expected {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	// 不同的日语输入不能因规范化后都为空而被视为相同
+	if Normalize("ねこ", japanese) == Normalize("いぬ", japanese) {
+		t.Error("不同的日语文本规范化后不应相同")
+	}
+}
+
+// 测试配置中的语言设置优先于内置默认值
+func TestForLanguage(t *testing.T) {
+	original := config.AppConfig.Normalization
+	defer func() { config.AppConfig.Normalization = original }()
+
+	config.AppConfig.Normalization = nil
+	if !ForLanguage("japanese").IgnoreSpaces {
+		t.Error("日语默认应忽略空白")
+	}
+
+	config.AppConfig.Normalization = map[string]config.NormalizationConfig{
+		"french": {Form: FormNFC, FoldDiacritics: true},
+	}
+	options := ForLanguage("French")
+	if options.Form != FormNFC || !options.FoldDiacritics || options.IgnoreSpaces {
+		t.Errorf("应使用配置中的法语设置，实际 %+v", options)
+	}
+}
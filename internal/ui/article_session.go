@@ -0,0 +1,626 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ajilisiwei/mllt-cli/internal/bookmark"
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/course"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/resume"
+	"github.com/ajilisiwei/mllt-cli/internal/sound"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// 连续输入时，文章中的换行位置可以用空格或回车输入
+const articleLineBreak = '\n'
+
+// 文章窗口默认显示的行数，终端高度未知时使用
+const defaultArticleRows = 10
+
+// newPracticeModel 创建资源文件的练习界面：文章默认使用连续输入，其余资源逐项练习。
+// 连续输入会显示整篇原文，默写和填空模式下文章也逐行练习。
+func newPracticeModel(resourceType, fileName string) tea.Model {
+	if resourceType == practice.Articles && !bookmark.IsSpecialList(fileName) &&
+		config.AppConfig.Articles.ArticleLayout() == config.ArticleLayoutFlow &&
+		normalizePracticeMode(config.AppConfig.PracticeMode) == practiceModeCopy {
+		return NewArticleSession(fileName)
+	}
+	return NewPracticeSession(resourceType, fileName)
+}
+
+// ArticleSession 文章连续输入练习：显示整篇文章，光标逐字前进，
+// 输错的字符就地标出且不阻塞输入，可以退格修改，文章随进度滚动
+type ArticleSession struct {
+	fileName        string
+	displayFileName string
+	items           []string // 资源文件中的原始行，与 lines 一一对应
+	lines           []string // 每行需要输入的正文
+	translations    []string // 每行的翻译，与 lines 一一对应
+	target          []rune   // 全文，行与行之间用 articleLineBreak 连接
+	lineStarts      []int    // 每行在 target 中的起始位置
+	typed           []rune   // 已输入的字符
+	foldCase        bool     // 比较时是否忽略大小写
+	progress        progress.Model
+	typing          *typingTracker
+	goalBase        statistics.GoalProgress
+	startTime       time.Time
+	endTime         time.Time
+	width           int
+	height          int
+	state           string // 状态："practicing", "finished"
+	result          string
+	feedback        string // 统计记录失败等提示
+	statsLogged     bool
+	quitting        bool
+	// 断点续练支持
+	resumeLine int               // 本次从第几行开始输入，之前的行显示为已输入
+	resumeBase resume.Checkpoint // 继续练习时之前已完成的进度
+	// 课程模式支持：完成课程中的一课后的结果
+	courseOutcome *course.Outcome
+}
+
+// NewArticleSession 创建文章连续输入练习，跳过已标记的句子
+func NewArticleSession(fileName string) *ArticleSession {
+	items, err := practice.ReadResourceFile(practice.Articles, fileName)
+	if err != nil {
+		items = []string{}
+	}
+	return newArticleSessionModel(fileName, filterExcludedItems(practice.Articles, items))
+}
+
+// newArticleSessionModel 按文章的各行创建连续输入练习
+func newArticleSessionModel(fileName string, items []string) *ArticleSession {
+	matchMode := config.AppConfig.MatchModeFor(practice.Articles)
+	session := &ArticleSession{
+		fileName:        fileName,
+		displayFileName: practice.FormatResourceDisplayName(fileName),
+		foldCase:        matchMode == "word_match",
+		progress:        progress.New(progress.WithDefaultGradient()),
+		typing:          newTypingTracker(matchMode == "word_match"),
+		goalBase:        loadTodayGoalProgress(),
+		startTime:       time.Now(),
+		state:           "practicing",
+	}
+	for _, item := range items {
+		text, translation := practice.ArticleText(item)
+		if text == "" {
+			continue
+		}
+		if len(session.target) > 0 {
+			session.target = append(session.target, articleLineBreak)
+		}
+		session.lineStarts = append(session.lineStarts, len(session.target))
+		session.target = append(session.target, []rune(text)...)
+		session.items = append(session.items, item)
+		session.lines = append(session.lines, text)
+		session.translations = append(session.translations, translation)
+	}
+
+	if len(session.lines) == 0 {
+		session.state = "finished"
+		session.endTime = time.Now()
+		session.result = emptyListMessage(fileName)
+	}
+	return session
+}
+
+// Init 初始化模型
+func (m ArticleSession) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m *ArticleSession) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.progress.Width = msg.Width - 20
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			m.quitting = true
+			sound.StopAllSounds()
+			m.logStatistics(false)
+			m.saveCheckpoint()
+			return m, tea.Quit
+		case tea.KeyEsc:
+			sound.StopAllSounds()
+			m.logStatistics(false)
+			m.saveCheckpoint()
+			return m.exitModel(), nil
+		}
+
+		if m.state == "finished" {
+			if msg.Type == tea.KeyEnter {
+				return m.exitModel(), nil
+			}
+			return m, nil
+		}
+
+		m.playKeyboardSound(msg)
+		now := time.Now()
+		switch msg.Type {
+		case tea.KeyBackspace:
+			m.deleteBack(1)
+		case tea.KeyCtrlW:
+			m.deleteBack(m.previousWordLength())
+		case tea.KeyEnter:
+			// 回车只用于输入换行位置，行中间的回车忽略
+			if m.expected() == articleLineBreak {
+				m.typeRune(articleLineBreak, now)
+			}
+		case tea.KeySpace:
+			m.typeRune(' ', now)
+		case tea.KeyRunes:
+			for _, r := range msg.Runes {
+				m.typeRune(r, now)
+			}
+		}
+	}
+	return m, nil
+}
+
+// typeRune 在光标处输入一个字符，输错时照常前进，输完全文后结束练习
+func (m *ArticleSession) typeRune(r rune, now time.Time) {
+	if m.state != "practicing" || len(m.typed) >= len(m.target) {
+		return
+	}
+	expected := m.expected()
+	if expected == articleLineBreak && r == ' ' {
+		r = articleLineBreak
+	}
+	m.typing.observeRune(expected, m.sameChar(r, expected), now)
+	m.typed = append(m.typed, r)
+	if len(m.typed) == len(m.target) {
+		m.finishSession()
+	} else if expected == articleLineBreak {
+		// 每输完一行保存一次进度，终端被直接关闭时也能继续
+		m.saveCheckpoint()
+	}
+}
+
+// deleteBack 删除光标前的 n 个字符，计为一次退格
+func (m *ArticleSession) deleteBack(n int) {
+	if n <= 0 || len(m.typed) == 0 {
+		return
+	}
+	// 继续练习时不能删除之前已完成的行
+	if n > len(m.typed)-m.offset() {
+		n = len(m.typed) - m.offset()
+	}
+	if n <= 0 {
+		return
+	}
+	m.typed = m.typed[:len(m.typed)-n]
+	m.typing.observeBackspace()
+}
+
+// previousWordLength 返回 Ctrl+W 要删除的字符数：光标前的空白和一个单词
+func (m *ArticleSession) previousWordLength() int {
+	i := len(m.typed)
+	for i > 0 && unicode.IsSpace(m.typed[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(m.typed[i-1]) {
+		i--
+	}
+	return len(m.typed) - i
+}
+
+// expected 返回光标处期望输入的字符，已输完时返回 0
+func (m ArticleSession) expected() rune {
+	if len(m.typed) >= len(m.target) {
+		return 0
+	}
+	return m.target[len(m.typed)]
+}
+
+// sameChar 比较输入的字符和期望的字符，排版引号、破折号和不换行空格可以用键盘上的字符代替
+func (m ArticleSession) sameChar(typed, expected rune) bool {
+	typed, expected = plainRune(typed), plainRune(expected)
+	if typed == expected {
+		return true
+	}
+	return m.foldCase && unicode.ToLower(typed) == unicode.ToLower(expected)
+}
+
+// plainRune 将排版字符转换为键盘上可以直接输入的字符
+func plainRune(r rune) rune {
+	switch r {
+	case '‘', '’', '′':
+		return '\''
+	case '“', '”', '″':
+		return '"'
+	case '–', '—':
+		return '-'
+	case '\u00a0':
+		return ' '
+	}
+	return r
+}
+
+// correctAt 判断第 i 个已输入的字符是否正确
+func (m ArticleSession) correctAt(i int) bool {
+	return m.sameChar(m.typed[i], m.target[i])
+}
+
+// offset 返回本次开始输入的位置，继续练习时之前的行不计入本次的统计
+func (m ArticleSession) offset() int {
+	if m.resumeLine > 0 && m.resumeLine < len(m.lineStarts) {
+		return m.lineStarts[m.resumeLine]
+	}
+	return 0
+}
+
+// correctChars 返回本次输入的字符中正确的数量
+func (m ArticleSession) correctChars() int {
+	count := 0
+	for i := m.offset(); i < len(m.typed); i++ {
+		if m.correctAt(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// currentLine 返回光标所在的行
+func (m ArticleSession) currentLine() int {
+	line := 0
+	for i, start := range m.lineStarts {
+		if start <= len(m.typed) {
+			line = i
+		}
+	}
+	return line
+}
+
+// lineResults 返回本次输完（包括行末的换行）的行数和其中没有输错的行数
+func (m ArticleSession) lineResults() (int, int) {
+	finished, correct := 0, 0
+	for i := m.resumeLine; i < len(m.lineStarts); i++ {
+		start, end := m.lineStarts[i], len(m.target)
+		if i+1 < len(m.lineStarts) {
+			end = m.lineStarts[i+1] - 1
+			if len(m.typed) <= end {
+				break
+			}
+		} else if len(m.typed) < end {
+			break
+		}
+		finished++
+		lineCorrect := true
+		for j := start; j < end; j++ {
+			if !m.correctAt(j) {
+				lineCorrect = false
+				break
+			}
+		}
+		if lineCorrect {
+			correct++
+		}
+	}
+	return finished, correct
+}
+
+// exitModel 返回练习菜单
+func (m *ArticleSession) exitModel() tea.Model {
+	var menu tea.Model = NewPracticeMenu()
+	if m.width > 0 && m.height > 4 {
+		updatedModel, _ := menu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		return updatedModel
+	}
+	return menu
+}
+
+func (m *ArticleSession) playKeyboardSound(msg tea.KeyMsg) {
+	if !config.AppConfig.InputKeyboardSound {
+		return
+	}
+	char := rune(0)
+	if len(msg.Runes) > 0 {
+		char = msg.Runes[0]
+	}
+	sound.PlayTypingSound(char)
+}
+
+func (m *ArticleSession) finishSession() {
+	if m.state == "finished" {
+		return
+	}
+	m.state = "finished"
+	m.endTime = time.Now()
+	sound.StopAllSounds()
+	m.logStatistics(true)
+	m.result = m.calculateResult()
+	_ = resume.Remove(practice.Articles, m.fileName)
+}
+
+// logStatistics 记录练习统计：每行计为一个项目，没有输错的行计为正确
+func (m *ArticleSession) logStatistics(completed bool) {
+	if m.statsLogged {
+		return
+	}
+	// 继续练习时之前的行已预先填入，本次没有输入时不记录
+	if len(m.typed) <= m.offset() && !completed {
+		return
+	}
+	m.statsLogged = true
+
+	now := time.Now()
+	if !m.endTime.IsZero() {
+		now = m.endTime
+	}
+	m.typing.commitChars(m.correctChars(), now)
+
+	total, correct := m.lineResults()
+	accuracy := 0.0
+	if total > 0 {
+		accuracy = float64(correct) / float64(total) * 100
+	}
+	duration := now.Sub(m.startTime)
+	if duration < 0 {
+		duration = 0
+	}
+
+	record := statistics.SessionRecord{
+		Timestamp:       time.Now(),
+		ResourceType:    practice.Articles,
+		FileName:        m.fileName,
+		Total:           total,
+		Correct:         correct,
+		Incorrect:       total - correct,
+		Accuracy:        accuracy,
+		DurationSeconds: int64(duration.Seconds()),
+		OrderMode:       "sequential",
+		Completed:       completed,
+		Typing:          m.typing.stats(),
+	}
+	if err := statistics.LogSession(record); err != nil {
+		m.feedback = fmt.Sprintf("记录统计数据失败: %v", err)
+	}
+	outcome, err := course.RecordSession(courseRecord(record, m.resumeBase))
+	if err != nil {
+		m.feedback = fmt.Sprintf("更新课程进度失败: %v", err)
+	}
+	m.courseOutcome = outcome
+}
+
+// calculateResult 生成练习完成后的结果信息
+func (m ArticleSession) calculateResult() string {
+	// 继续练习时显示整篇文章的累计结果
+	duration := m.resumeBase.Elapsed() + m.endTime.Sub(m.startTime)
+	durationStr := fmt.Sprintf("%d分%d秒", int(duration.Minutes()), int(duration.Seconds())%60)
+
+	total, correct := m.lineResults()
+	total += m.resumeBase.Correct + m.resumeBase.Incorrect
+	correct += m.resumeBase.Correct
+	typing := m.typing.stats()
+	if typing == nil {
+		typing = &statistics.TypingStats{}
+	}
+
+	return fmt.Sprintf(
+		"练习时间: %s\n全对的行: %d/%d\n字符: %d/%d 正确\n净速度: %.1f WPM · 毛速度: %.1f WPM\n按键准确率: %.1f%% · 退格 %d 次",
+		durationStr, correct, total, m.correctChars(), len(m.target)-m.offset(),
+		typing.NetWPM, typing.GrossWPM, typing.CharAccuracy(), typing.Backspaces,
+	)
+}
+
+// liveWPM 返回从第一次按键到现在的净速度
+func (m ArticleSession) liveWPM(now time.Time) float64 {
+	if m.typing == nil || m.typing.itemFirstKey.IsZero() {
+		return 0
+	}
+	minutes := now.Sub(m.typing.itemFirstKey).Minutes()
+	if minutes <= 0 {
+		return 0
+	}
+	return float64(m.correctChars()) / 5 / minutes
+}
+
+// liveAccuracy 返回目前的按键准确率，还没有按键时为 100%
+func (m ArticleSession) liveAccuracy() float64 {
+	typing := m.typing.stats()
+	if typing == nil || typing.Keystrokes == 0 {
+		return 100
+	}
+	return typing.CharAccuracy()
+}
+
+// View 渲染视图
+func (m ArticleSession) View() string {
+	if m.quitting {
+		return "练习已中断！"
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderTitle(fmt.Sprintf("%s练习 - %s", getResourceTypeTitle(practice.Articles), m.displayFileName)) + "\n\n")
+
+	if m.state == "finished" {
+		if len(m.lines) == 0 {
+			s.WriteString(RenderHighlight("暂无练习内容") + "\n\n")
+		} else {
+			s.WriteString(RenderSuccess("练习完成！") + "\n\n")
+		}
+		s.WriteString(RenderText(m.result) + "\n\n")
+		s.WriteString(renderCourseOutcome(m.courseOutcome))
+		if m.feedback != "" {
+			s.WriteString(RenderError(m.feedback) + "\n\n")
+		}
+		s.WriteString(RenderText("按 Enter 或 Esc 返回练习菜单") + "\n")
+		return s.String()
+	}
+
+	now := time.Now()
+	line := m.currentLine()
+	progressValue := float64(len(m.typed)) / float64(len(m.target))
+	s.WriteString(RenderText(fmt.Sprintf("第 %d/%d 行 · 字符 %d/%d · %.0f WPM · 准确率 %.1f%%",
+		line+1, len(m.lines), len(m.typed), len(m.target), m.liveWPM(now), m.liveAccuracy())) + "\n")
+	s.WriteString(m.progress.ViewAs(progressValue) + "\n")
+	if m.goalBase.Enabled() {
+		_, correct := m.lineResults()
+		goalProgress := m.goalBase.Add(correct, now.Sub(m.startTime))
+		if goalProgress.Reached() {
+			s.WriteString(RenderSuccess(formatGoalProgress(goalProgress)) + "\n")
+		} else {
+			s.WriteString(RenderText(formatGoalProgress(goalProgress)) + "\n")
+		}
+	}
+	s.WriteString("\n")
+
+	s.WriteString(m.renderText() + "\n\n")
+
+	if config.AppConfig.ShowTranslationFor(practice.Articles) && m.translations[line] != "" {
+		s.WriteString(RenderText("翻译: "+m.translations[line]) + "\n\n")
+	}
+	if m.feedback != "" {
+		s.WriteString(RenderError(m.feedback) + "\n\n")
+	}
+	s.WriteString(RenderText("直接输入，行末按空格或 Enter 换行，Backspace 退格，Ctrl+W 删除单词，Esc 退出练习") + "\n")
+	return s.String()
+}
+
+// articleRow 文章显示时的一行，[start, end) 为它在全文中的范围
+type articleRow struct {
+	start, end int
+}
+
+// layoutRows 按显示宽度把文章切分为显示行，尽量在空格处换行，行末的换行符归入该行
+func (m ArticleSession) layoutRows(width int) []articleRow {
+	var rows []articleRow
+	for i, start := range m.lineStarts {
+		end := len(m.target)
+		if i+1 < len(m.lineStarts) {
+			end = m.lineStarts[i+1]
+		}
+
+		rowStart, rowWidth, lastSpace := start, 0, -1
+		for pos := start; pos < end; pos++ {
+			r := m.target[pos]
+			if r == articleLineBreak {
+				break
+			}
+			charWidth := lipgloss.Width(string(r))
+			if rowWidth+charWidth > width && pos > rowStart {
+				breakAt := pos
+				if lastSpace >= rowStart {
+					breakAt = lastSpace + 1
+				}
+				rows = append(rows, articleRow{start: rowStart, end: breakAt})
+				rowStart, lastSpace = breakAt, -1
+				rowWidth = 0
+				for j := rowStart; j < pos; j++ {
+					rowWidth += lipgloss.Width(string(m.target[j]))
+				}
+			}
+			if r == ' ' {
+				lastSpace = pos
+			}
+			rowWidth += charWidth
+		}
+		rows = append(rows, articleRow{start: rowStart, end: end})
+	}
+	return rows
+}
+
+// renderText 渲染光标附近的文章内容：已输入的正确字符、输错的字符（显示期望的字符）、光标和未输入的字符
+func (m ArticleSession) renderText() string {
+	width := m.width - 4
+	if width <= 0 {
+		width = 76
+	}
+	visible := defaultArticleRows
+	if m.height > 0 {
+		visible = m.height - 16
+		if visible < 3 {
+			visible = 3
+		}
+	}
+
+	rows := m.layoutRows(width)
+	cursor := len(m.typed)
+	cursorRow := len(rows) - 1
+	for i, row := range rows {
+		if cursor < row.end {
+			cursorRow = i
+			break
+		}
+	}
+
+	// 光标保持在窗口的前三分之一，文章随进度向上滚动
+	first := cursorRow - visible/3
+	if first > len(rows)-visible {
+		first = len(rows) - visible
+	}
+	if first < 0 {
+		first = 0
+	}
+	last := first + visible
+	if last > len(rows) {
+		last = len(rows)
+	}
+
+	rendered := make([]string, 0, last-first)
+	for _, row := range rows[first:last] {
+		rendered = append(rendered, m.renderRow(row))
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// renderRow 渲染一个显示行，连续相同样式的字符合并渲染
+func (m ArticleSession) renderRow(row articleRow) string {
+	var b, run strings.Builder
+	var runStyle *lipgloss.Style
+	flush := func() {
+		if run.Len() > 0 && runStyle != nil {
+			b.WriteString(runStyle.Render(run.String()))
+		}
+		run.Reset()
+	}
+
+	cursor := len(m.typed)
+	for pos := row.start; pos < row.end; pos++ {
+		style := &ArticlePendingStyle
+		text := string(m.target[pos])
+		switch {
+		case pos < cursor && m.correctAt(pos):
+			style = &ArticleTypedStyle
+		case pos < cursor:
+			style = &ArticleWrongStyle
+			switch m.target[pos] {
+			case ' ':
+				text = "·"
+			case articleLineBreak:
+				text = "↵"
+			}
+		case pos == cursor:
+			style = &ArticleCursorStyle
+		}
+		if text == string(articleLineBreak) {
+			// 换行位置只在光标停留或输错时显示
+			if style != &ArticleCursorStyle {
+				continue
+			}
+			text = " "
+		}
+
+		if style != runStyle {
+			flush()
+			runStyle = style
+		}
+		run.WriteString(text)
+	}
+	flush()
+	return b.String()
+}
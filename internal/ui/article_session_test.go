@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// newTestArticleSession 创建文章连续输入练习，统计数据写入临时目录
+func newTestArticleSession(t *testing.T, items ...string) *ArticleSession {
+	setupSessionTestDir(t)
+	return newArticleSessionModel("NCE-1/1-001_Excuse_me", items)
+}
+
+// typeText 逐个按键输入文本，空格和回车按对应的按键发送
+func typeText(m *ArticleSession, text string) {
+	for _, r := range text {
+		switch r {
+		case ' ':
+			m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+		case '\n':
+			m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		default:
+			m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+}
+
+// 测试全文的拼接：整句没有翻译的行不会被按空格拆开，翻译单独保存
+func TestNewArticleSessionTarget(t *testing.T) {
+	m := newTestArticleSession(t, "Excuse me! ->> 对不起！", "Is this your handbag?", "Pardon? ->> 什么？")
+
+	if got := string(m.target); got != "Excuse me!\nIs this your handbag?\nPardon?" {
+		t.Errorf("全文 = %q", got)
+	}
+	if m.translations[0] != "对不起！" || m.translations[1] != "" {
+		t.Errorf("翻译 = %q", m.translations)
+	}
+	if len(m.lineStarts) != 3 || m.lineStarts[1] != 11 {
+		t.Errorf("行起始位置 = %v", m.lineStarts)
+	}
+}
+
+// 测试输错时光标照常前进，退格后可以修改，Ctrl+W 删除一个单词
+func TestArticleSessionTypingAndBackspace(t *testing.T) {
+	m := newTestArticleSession(t, "Excuse me! ->> 对不起！", "Yes? ->> 什么事？")
+
+	typeText(m, "Exc")
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if len(m.typed) != 4 || m.correctAt(3) {
+		t.Fatalf("输错后应前进并标记错误，已输入 %q", string(m.typed))
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	typeText(m, "use mee")
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlW})
+	if got := string(m.typed); got != "Excuse " {
+		t.Fatalf("Ctrl+W 后已输入 %q", got)
+	}
+	if m.typing.backspaces != 2 || m.typing.wrongChars != 2 {
+		t.Errorf("退格 %d 次、错误 %d 次，want 2、2", m.typing.backspaces, m.typing.wrongChars)
+	}
+
+	// 行末用空格代替换行
+	typeText(m, "me! ")
+	if m.currentLine() != 1 || m.typed[len(m.typed)-1] != articleLineBreak {
+		t.Errorf("空格应输入换行，当前第 %d 行", m.currentLine()+1)
+	}
+	if m.state != "practicing" {
+		t.Errorf("未输完全文时状态 = %s", m.state)
+	}
+}
+
+// 测试输完全文后结束练习，按行统计全对的行数
+func TestArticleSessionFinish(t *testing.T) {
+	m := newTestArticleSession(t, "Excuse me! ->> 对不起！", "Yes? ->> 什么事？", "Pardon?")
+	m.foldCase = false
+
+	// 行中间的回车被忽略
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText(m, "excuse me!\nYes!\nPardon?")
+
+	if m.state != "finished" {
+		t.Fatalf("输完全文后状态 = %s", m.state)
+	}
+	if total, correct := m.lineResults(); total != 3 || correct != 1 {
+		t.Errorf("行统计 = %d/%d, want 1/3", correct, total)
+	}
+	if !strings.Contains(m.result, "全对的行: 1/3") {
+		t.Errorf("结果信息 = %q", m.result)
+	}
+}
+
+// 测试排版字符与键盘字符等价
+func TestArticleSessionPlainRunes(t *testing.T) {
+	m := newTestArticleSession(t, "It’s “fine” — really")
+	typeText(m, `It's "fine" - really`)
+
+	if m.state != "finished" || m.correctChars() != len(m.target) {
+		t.Errorf("排版字符应接受键盘输入，正确 %d/%d", m.correctChars(), len(m.target))
+	}
+}
+
+// 测试显示行在空格处折行，行末换行符归入该行
+func TestArticleSessionLayoutRows(t *testing.T) {
+	m := newTestArticleSession(t, "one two three ->> 一二三", "four")
+
+	rows := m.layoutRows(8)
+	want := []string{"one two ", "three\n", "four"}
+	if len(rows) != len(want) {
+		t.Fatalf("显示行 = %v", rows)
+	}
+	for i, row := range rows {
+		if got := string(m.target[row.start:row.end]); got != want[i] {
+			t.Errorf("第 %d 个显示行 = %q, want %q", i+1, got, want[i])
+		}
+	}
+}
+
+// 测试只有抄写模式下文章使用连续输入，默写和填空模式逐行练习，不显示整篇原文
+func TestNewPracticeModelArticleMode(t *testing.T) {
+	setupSessionTestDir(t)
+	config.AppConfig.Articles.Layout = config.ArticleLayoutFlow
+
+	for mode, flow := range map[string]bool{
+		practiceModeCopy:      true,
+		practiceModeDictation: false,
+		practiceModeCloze:     false,
+	} {
+		config.AppConfig.PracticeMode = mode
+		_, isFlow := newPracticeModel(practice.Articles, "NCE-1/1-001_Excuse_me").(*ArticleSession)
+		if isFlow != flow {
+			t.Errorf("%s 模式下连续输入 = %v，期望 %v", mode, isFlow, flow)
+		}
+	}
+}
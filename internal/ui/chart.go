@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// 用于绘制图表的方块字符，从低到高
+var blockRunes = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// sparkRunes 迷你折线图使用的字符，不包含空白
+var sparkRunes = blockRunes[1:]
+
+// renderSparkline 绘制单行迷你折线图，values 中的负数表示没有数据，显示为"·"。
+// minValue、maxValue 为纵轴范围。
+func renderSparkline(values []float64, minValue, maxValue float64) string {
+	var builder strings.Builder
+	span := maxValue - minValue
+	for _, value := range values {
+		if value < 0 {
+			builder.WriteRune('·')
+			continue
+		}
+		level := len(sparkRunes) - 1
+		if span > 0 {
+			ratio := (value - minValue) / span
+			level = int(math.Round(ratio * float64(len(sparkRunes)-1)))
+		}
+		if level < 0 {
+			level = 0
+		}
+		if level >= len(sparkRunes) {
+			level = len(sparkRunes) - 1
+		}
+		builder.WriteRune(sparkRunes[level])
+	}
+	return builder.String()
+}
+
+// renderColumnChart 绘制多行柱状图，每个值占一列，height 为图表行数，
+// 每行用八分之一方块细分，返回的各行自上而下排列。
+func renderColumnChart(values []float64, height int) []string {
+	if height <= 0 {
+		return nil
+	}
+
+	maxValue := 0.0
+	for _, value := range values {
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+
+	steps := len(blockRunes) - 1
+	rows := make([]string, height)
+	for row := 0; row < height; row++ {
+		// row 0 为最上面一行
+		base := float64(height-1-row) * float64(steps)
+		var builder strings.Builder
+		for _, value := range values {
+			filled := 0.0
+			if maxValue > 0 {
+				filled = value / maxValue * float64(height*steps)
+			}
+			// 有数据的列至少显示一格，避免与没有练习的日子混淆
+			if value > 0 && filled < 1 {
+				filled = 1
+			}
+			level := int(math.Round(filled - base))
+			if level < 0 {
+				level = 0
+			}
+			if level > steps {
+				level = steps
+			}
+			builder.WriteRune(blockRunes[level])
+		}
+		rows[row] = builder.String()
+	}
+	return rows
+}
+
+// barRow 表示横向条形图的一行
+type barRow struct {
+	label string
+	value float64
+	text  string // 显示在条形后面的数值说明
+}
+
+// renderBarChart 绘制横向条形图，width 为条形的最大宽度
+func renderBarChart(rows []barRow, width int) []string {
+	if width <= 0 {
+		width = 1
+	}
+
+	maxValue := 0.0
+	labelWidth := 0
+	for _, row := range rows {
+		if row.value > maxValue {
+			maxValue = row.value
+		}
+		if w := lipgloss.Width(row.label); w > labelWidth {
+			labelWidth = w
+		}
+	}
+
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		length := 0
+		if maxValue > 0 {
+			length = int(math.Round(row.value / maxValue * float64(width)))
+		}
+		if row.value > 0 && length == 0 {
+			length = 1
+		}
+		padding := strings.Repeat(" ", labelWidth-lipgloss.Width(row.label))
+		lines = append(lines, fmt.Sprintf("%s%s │%s %s", row.label, padding, strings.Repeat("█", length), row.text))
+	}
+	return lines
+}
+
+// bucketValues 将数据按 size 个一组合并，用于在窄终端中压缩图表宽度。
+// average 为 true 时取组内有效值（非负数）的平均值，否则求和。
+func bucketValues(values []float64, size int, average bool) []float64 {
+	if size <= 1 {
+		return values
+	}
+
+	buckets := make([]float64, 0, (len(values)+size-1)/size)
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+		sum, count := 0.0, 0
+		for _, value := range values[start:end] {
+			if value < 0 {
+				continue
+			}
+			sum += value
+			count++
+		}
+		switch {
+		case count == 0 && average:
+			buckets = append(buckets, -1)
+		case average:
+			buckets = append(buckets, sum/float64(count))
+		default:
+			buckets = append(buckets, sum)
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+)
+
+// 测试迷你折线图对缺失数据和取值范围的处理
+func TestRenderSparkline(t *testing.T) {
+	got := renderSparkline([]float64{0, -1, 50, 100}, 0, 100)
+	if got != "▁·▅█" {
+		t.Errorf("renderSparkline() = %q", got)
+	}
+}
+
+// 测试按组合并数据
+func TestBucketValues(t *testing.T) {
+	values := []float64{1, 2, 3, -1, -1}
+
+	sum := bucketValues(values, 2, false)
+	if len(sum) != 3 || sum[0] != 3 || sum[1] != 3 || sum[2] != 0 {
+		t.Errorf("求和合并结果不正确: %v", sum)
+	}
+
+	avg := bucketValues(values, 2, true)
+	if len(avg) != 3 || avg[0] != 1.5 || avg[1] != 3 || avg[2] != -1 {
+		t.Errorf("平均合并结果不正确: %v", avg)
+	}
+}
+
+// 测试柱状图的最高列填满、空值留白
+func TestRenderColumnChart(t *testing.T) {
+	rows := renderColumnChart([]float64{0, 4, 8}, 2)
+	if len(rows) != 2 {
+		t.Fatalf("期望 2 行，实际 %d", len(rows))
+	}
+	if rows[0] != "  █" || rows[1] != " ██" {
+		t.Errorf("柱状图不正确: %q", rows)
+	}
+}
@@ -0,0 +1,413 @@
+package ui
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// 每道选择题最多的选项数，对应 1-4 键
+const choiceOptionCount = 4
+
+// 答对但用时超过该值时评为"困难"
+const choiceSlowAnswer = 10 * time.Second
+
+// ChoiceSession 选择题练习会话：显示单词或短语，从正确释义和同一文件中其他条目的释义里选择，按 1-4 作答。
+// 选择题只练习认读，不需要打字，结果同样写入记忆计划和统计数据。
+type ChoiceSession struct {
+	resourceType    string
+	fileName        string
+	displayFileName string
+	items           []string // 带释义的条目
+	meanings        []string // 每个条目作为选项显示的释义
+	practiceOrder   []int
+	completedCount  int
+	options         []string // 当前题目的选项
+	answer          int      // 正确选项的下标
+	wrongChoice     int      // 答错时选择的选项，-1 表示还未作答
+	correct         int
+	incorrect       int
+	state           string // "practicing" 或 "finished"
+	startTime       time.Time
+	endTime         time.Time
+	itemStartTime   time.Time
+	orderMode       string
+	srsEnabled      bool
+	srsSchedule     *srs.Schedule
+	lastGrade       srs.Grade
+	lastCorrect     string // 上一题答对的条目，用于提示
+	feedback        string // 记录失败等提示
+	result          string
+	statsLogged     bool
+	quitting        bool
+	rng             *rand.Rand
+	progress        progress.Model
+	width           int
+	height          int
+}
+
+// NewChoiceSession 创建单词或短语的选择题练习，练习顺序与打字练习的设置一致
+func NewChoiceSession(resourceType, fileName string) *ChoiceSession {
+	normalizedItems, meanings := choiceItems(readPracticeItems(resourceType, fileName))
+	practiceOrder, orderMode, schedule := practiceOrderFor(resourceType, fileName, normalizedItems)
+
+	session := newChoiceSessionModel(resourceType, fileName, normalizedItems, meanings, practiceOrder, orderMode)
+	session.srsSchedule = schedule
+	session.srsEnabled = schedule != nil
+	return session
+}
+
+// newChoiceSessionModel 按给定的条目和练习顺序创建选择题会话
+func newChoiceSessionModel(resourceType, fileName string, items, meanings []string, practiceOrder []int, orderMode string) *ChoiceSession {
+	m := &ChoiceSession{
+		resourceType:    resourceType,
+		fileName:        fileName,
+		displayFileName: practice.FormatResourceDisplayName(fileName),
+		items:           items,
+		meanings:        meanings,
+		practiceOrder:   practiceOrder,
+		wrongChoice:     -1,
+		state:           "practicing",
+		startTime:       time.Now(),
+		orderMode:       orderMode,
+		rng:             rand.New(rand.NewSource(time.Now().UnixNano())),
+		progress:        progress.New(progress.WithDefaultGradient()),
+	}
+
+	if !hasChoiceDistractors(meanings) {
+		// 只有一种释义时无法组成选择题
+		m.state = "finished"
+		m.endTime = m.startTime
+		m.result = "选择题需要至少两个释义不同的条目，当前资源无法练习。按 Enter 或 Esc 返回练习菜单。"
+		if len(items) == 0 {
+			m.result = emptyListMessage(fileName)
+		}
+		return m
+	}
+	m.prepareOptions()
+	return m
+}
+
+// choiceItems 返回可以出题的条目及其释义：释义不含音标和例句，避免直接提示答案；没有释义的条目被跳过
+func choiceItems(items []string) ([]string, []string) {
+	kept := make([]string, 0, len(items))
+	meanings := make([]string, 0, len(items))
+	for _, item := range items {
+		entry := practice.ParseEntry(item)
+		meaning := strings.TrimSpace(entry.Definition())
+		if entry.Term == "" || meaning == "" {
+			continue
+		}
+		kept = append(kept, item)
+		meanings = append(meanings, meaning)
+	}
+	return kept, meanings
+}
+
+// hasChoiceDistractors 判断释义中是否至少有两种不同的内容
+func hasChoiceDistractors(meanings []string) bool {
+	for _, meaning := range meanings {
+		if meaning != meanings[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareOptions 为当前条目生成选项：正确释义加上最多三个同一文件中其他条目的不同释义，随机排列
+func (m *ChoiceSession) prepareOptions() {
+	m.options, m.answer, m.wrongChoice = nil, 0, -1
+	index := m.currentIndex()
+	if index < 0 {
+		return
+	}
+
+	correct := m.meanings[index]
+	seen := map[string]bool{correct: true}
+	var distractors []string
+	for _, i := range m.rng.Perm(len(m.meanings)) {
+		if meaning := m.meanings[i]; !seen[meaning] {
+			seen[meaning] = true
+			distractors = append(distractors, meaning)
+			if len(distractors) == choiceOptionCount-1 {
+				break
+			}
+		}
+	}
+
+	m.options = append(distractors, correct)
+	m.rng.Shuffle(len(m.options), func(i, j int) {
+		m.options[i], m.options[j] = m.options[j], m.options[i]
+	})
+	for i, option := range m.options {
+		if option == correct {
+			m.answer = i
+		}
+	}
+	m.itemStartTime = time.Now()
+}
+
+// currentIndex 返回当前题目在条目中的下标，练习结束时返回 -1
+func (m ChoiceSession) currentIndex() int {
+	if m.completedCount < 0 || m.completedCount >= len(m.practiceOrder) {
+		return -1
+	}
+	index := m.practiceOrder[m.completedCount]
+	if index < 0 || index >= len(m.items) {
+		return -1
+	}
+	return index
+}
+
+// Init 初始化模型
+func (m ChoiceSession) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m *ChoiceSession) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch typedMsg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = typedMsg.Width
+		m.height = typedMsg.Height
+		m.progress.Width = typedMsg.Width - 20
+		return m, nil
+
+	case tea.KeyMsg:
+		key := typedMsg.String()
+		switch key {
+		case "ctrl+c":
+			m.quitting = true
+			m.logStatistics(false)
+			return m, tea.Quit
+		case "esc":
+			m.logStatistics(false)
+			return m.exitModel(), nil
+		}
+
+		if m.state == "finished" {
+			if key == "enter" {
+				return m.exitModel(), nil
+			}
+			return m, nil
+		}
+
+		// 答错后显示正确答案，按 Enter 或空格进入下一题
+		if m.wrongChoice >= 0 {
+			if key == "enter" || key == " " {
+				m.nextItem()
+			}
+			return m, nil
+		}
+		if choice, err := strconv.Atoi(key); err == nil && choice >= 1 && choice <= len(m.options) {
+			m.choose(choice - 1)
+		}
+	}
+	return m, nil
+}
+
+// choose 处理选择的选项：答对直接进入下一题，答错显示正确答案
+func (m *ChoiceSession) choose(choice int) {
+	index := m.currentIndex()
+	if index < 0 {
+		return
+	}
+	item := m.items[index]
+
+	if choice == m.answer {
+		m.correct++
+		term := practice.ParseEntry(item).Term
+		m.recordSpacedRepetition(item, choiceGrade(time.Since(m.itemStartTime)))
+		m.lastCorrect = term
+		m.nextItem()
+		return
+	}
+
+	m.incorrect++
+	m.recordSpacedRepetition(item, srs.GradeAgain)
+	m.lastCorrect = ""
+	m.wrongChoice = choice
+}
+
+// choiceGrade 返回答对一道选择题的复习评分。认出答案比回忆并输入容易，
+// 因此不按打字速度评为"简单"：答对记为"良好"，明显偏慢时记为"困难"。
+func choiceGrade(elapsed time.Duration) srs.Grade {
+	if elapsed > choiceSlowAnswer {
+		return srs.GradeHard
+	}
+	return srs.GradeGood
+}
+
+// nextItem 进入下一题，全部完成时结束练习
+func (m *ChoiceSession) nextItem() {
+	m.completedCount++
+	if m.completedCount >= len(m.practiceOrder) {
+		m.finishSession()
+		return
+	}
+	m.prepareOptions()
+}
+
+func (m *ChoiceSession) recordSpacedRepetition(item string, grade srs.Grade) {
+	if !m.srsEnabled || m.srsSchedule == nil {
+		return
+	}
+	if err := m.srsSchedule.RecordGrade(item, grade); err == nil {
+		m.lastGrade = grade
+	}
+}
+
+func (m *ChoiceSession) finishSession() {
+	if m.state == "finished" {
+		return
+	}
+
+	m.state = "finished"
+	m.endTime = time.Now()
+	m.options = nil
+	m.result = m.calculateResult()
+	m.logStatistics(true)
+}
+
+// calculateResult 计算练习结果
+func (m ChoiceSession) calculateResult() string {
+	duration := m.endTime.Sub(m.startTime)
+	durationStr := fmt.Sprintf("%d分%d秒", int(duration.Minutes()), int(duration.Seconds())%60)
+
+	total := m.correct + m.incorrect
+	accuracy := 0.0
+	if total > 0 {
+		accuracy = float64(m.correct) / float64(total) * 100
+	}
+
+	return fmt.Sprintf("练习时间: %s\n正确数量: %d\n错误数量: %d\n正确率: %.1f%%",
+		durationStr, m.correct, m.incorrect, accuracy)
+}
+
+// logStatistics 记录本次选择题练习的统计数据，选择题没有打字数据
+func (m *ChoiceSession) logStatistics(completed bool) {
+	if m.statsLogged {
+		return
+	}
+
+	total := m.correct + m.incorrect
+	if total == 0 && !completed {
+		return
+	}
+
+	duration := time.Since(m.startTime)
+	if !m.endTime.IsZero() {
+		duration = m.endTime.Sub(m.startTime)
+	}
+	if duration < 0 {
+		duration = 0
+	}
+
+	accuracy := 0.0
+	if total > 0 {
+		accuracy = float64(m.correct) / float64(total) * 100
+	}
+
+	record := statistics.SessionRecord{
+		Timestamp:       time.Now(),
+		ResourceType:    m.resourceType,
+		FileName:        m.fileName,
+		Total:           total,
+		Correct:         m.correct,
+		Incorrect:       m.incorrect,
+		Accuracy:        accuracy,
+		DurationSeconds: int64(duration.Seconds()),
+		OrderMode:       m.orderMode,
+		Completed:       completed,
+	}
+	if err := statistics.LogSession(record); err != nil {
+		m.feedback = fmt.Sprintf("记录统计数据失败: %v", err)
+	}
+	m.statsLogged = true
+}
+
+// exitModel 返回退出练习后进入的练习菜单
+func (m *ChoiceSession) exitModel() tea.Model {
+	menu := NewPracticeMenu()
+	if m.width > 0 && m.height > 4 {
+		updatedModel, _ := menu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		return updatedModel
+	}
+	return menu
+}
+
+// View 渲染视图
+func (m ChoiceSession) View() string {
+	if m.quitting {
+		return "练习已中断！"
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderTitle(fmt.Sprintf("%s选择题 - %s", getResourceTypeTitle(m.resourceType), m.displayFileName)) + "\n\n")
+
+	if m.state == "finished" {
+		if len(m.items) == 0 || m.correct+m.incorrect == 0 {
+			s.WriteString(RenderHighlight("暂无练习内容") + "\n\n")
+		} else {
+			s.WriteString(RenderSuccess("练习完成！") + "\n\n")
+		}
+		s.WriteString(RenderText(m.result) + "\n\n")
+		if m.feedback != "" {
+			s.WriteString(RenderError(m.feedback) + "\n\n")
+		}
+		s.WriteString(RenderText("按 Enter 或 Esc 返回练习菜单") + "\n")
+		return s.String()
+	}
+
+	total := len(m.practiceOrder)
+	s.WriteString(RenderText(fmt.Sprintf("进度: %d/%d", m.completedCount+1, total)) + "\n")
+	s.WriteString(m.progress.ViewAs(float64(m.completedCount)/float64(total)) + "\n\n")
+
+	entry := practice.ParseEntry(m.items[m.currentIndex()])
+	s.WriteString(RenderHighlight("请选择正确的释义:") + "\n")
+	s.WriteString(RenderText(entry.Term) + "\n")
+	if entry.Phonetic != "" {
+		s.WriteString(PhoneticStyle.Render(entry.Phonetic) + "\n")
+	}
+	s.WriteString("\n")
+
+	for i, option := range m.options {
+		line := fmt.Sprintf("%d. %s", i+1, option)
+		switch {
+		case m.wrongChoice >= 0 && i == m.answer:
+			s.WriteString(RenderSuccess("✔ "+line) + "\n")
+		case i == m.wrongChoice:
+			s.WriteString(RenderError("✘ "+line) + "\n")
+		default:
+			s.WriteString(RenderText("  "+line) + "\n")
+		}
+	}
+	s.WriteString("\n")
+
+	if m.wrongChoice >= 0 {
+		s.WriteString(RenderError(fmt.Sprintf("❌ 选择错误！正确答案是 %d", m.answer+1)) + "\n\n")
+		s.WriteString(RenderText("按 Enter 或空格进入下一题，按 Esc 退出练习") + "\n")
+		return s.String()
+	}
+	if m.lastCorrect != "" {
+		s.WriteString(RenderSuccess("✔ 上一题答对了: "+m.lastCorrect) + "\n")
+		if m.srsEnabled && m.lastGrade.Valid() {
+			s.WriteString(RenderText("上一题评分: "+m.lastGrade.Label()) + "\n")
+		}
+		s.WriteString("\n")
+	}
+	if m.feedback != "" {
+		s.WriteString(RenderError(m.feedback) + "\n\n")
+	}
+	s.WriteString(RenderText(fmt.Sprintf("按 1-%d 选择答案，按 Esc 退出练习", len(m.options))) + "\n")
+	return s.String()
+}
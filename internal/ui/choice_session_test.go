@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// newTestChoiceSession 按顺序创建单词选择题练习，使用固定的随机数
+func newTestChoiceSession(t *testing.T, lines ...string) *ChoiceSession {
+	setupSessionTestDir(t)
+
+	items, meanings := choiceItems(lines)
+	m := newChoiceSessionModel(practice.Words, "choice", items, meanings, sequentialOrder(len(items)), "sequential")
+	m.rng = rand.New(rand.NewSource(1))
+	m.prepareOptions()
+	return m
+}
+
+// pressKey 模拟按下一个字符键
+func pressKey(m *ChoiceSession, key string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+}
+
+// 测试选项由正确释义和同一文件中其他条目的不同释义组成，没有释义的条目不出题
+func TestChoiceOptions(t *testing.T) {
+	m := newTestChoiceSession(t,
+		"apple ->> /ˈæpl/ n. 苹果",
+		"banana ->> n. 香蕉",
+		"cherry ->> n. 樱桃",
+		"grape ->> n. 葡萄",
+		"melon ->> n. 甜瓜",
+		"pear",
+		"peach ->> n. 香蕉",
+	)
+
+	if len(m.items) != 6 {
+		t.Fatalf("没有释义的条目应被跳过: %v", m.items)
+	}
+	if len(m.options) != choiceOptionCount || m.options[m.answer] != "n. 苹果" {
+		t.Fatalf("选项 = %v，正确答案 %d", m.options, m.answer)
+	}
+	seen := make(map[string]bool)
+	for _, option := range m.options {
+		if seen[option] || strings.Contains(option, "/ˈæpl/") {
+			t.Errorf("选项不应重复或包含音标: %v", m.options)
+		}
+		seen[option] = true
+	}
+
+	two := newTestChoiceSession(t, "yes ->> 是", "no ->> 不")
+	if len(two.options) != 2 {
+		t.Errorf("只有两种释义时应有两个选项: %v", two.options)
+	}
+	same := newTestChoiceSession(t, "hi ->> 你好", "hello ->> 你好")
+	if same.state != "finished" || !strings.Contains(same.View(), "至少两个释义不同的条目") {
+		t.Errorf("释义都相同时无法出题: %q", same.View())
+	}
+}
+
+// 测试按 1-4 作答：答对进入下一题，答错显示正确答案，全部完成后记录统计
+func TestChoiceAnswering(t *testing.T) {
+	m := newTestChoiceSession(t, "apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃")
+
+	wrong := (m.answer + 1) % len(m.options)
+	pressKey(m, "9")
+	if m.incorrect != 0 || m.wrongChoice != -1 {
+		t.Fatal("超出选项范围的按键应被忽略")
+	}
+	pressKey(m, string(rune('1'+wrong)))
+	if m.incorrect != 1 || m.completedCount != 0 {
+		t.Fatalf("答错后应停留在当前题: 错误 %d，已完成 %d", m.incorrect, m.completedCount)
+	}
+	if view := m.View(); !strings.Contains(view, "正确答案是") {
+		t.Errorf("答错后应显示正确答案: %q", view)
+	}
+	pressKey(m, string(rune('1'+m.answer)))
+	if m.correct != 0 {
+		t.Fatal("显示答案时不应再次计分")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	for m.state != "finished" {
+		pressKey(m, string(rune('1'+m.answer)))
+	}
+	if m.correct != 2 || m.incorrect != 1 {
+		t.Fatalf("答对 %d，答错 %d", m.correct, m.incorrect)
+	}
+
+	records, err := statistics.GetAllSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Total != 3 || records[0].Correct != 2 || !records[0].Completed {
+		t.Errorf("统计记录 = %+v", records)
+	}
+}
+
+// 测试答对选择题不按打字速度评为"简单"：很快答对记为"良好"，明显偏慢时记为"困难"
+func TestChoiceGrade(t *testing.T) {
+	m := newTestChoiceSession(t, "apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃")
+	schedule, err := srs.Load(practice.Words, "choice", m.items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.srsSchedule = schedule
+	m.srsEnabled = true
+
+	pressKey(m, string(rune('1'+m.answer)))
+	if m.lastGrade != srs.GradeGood {
+		t.Errorf("很快答对的评分 = %v, want %v", m.lastGrade, srs.GradeGood)
+	}
+
+	if grade := choiceGrade(choiceSlowAnswer + time.Second); grade != srs.GradeHard {
+		t.Errorf("偏慢答对的评分 = %v, want %v", grade, srs.GradeHard)
+	}
+}
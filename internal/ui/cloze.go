@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// 填空题中空缺位置的显示
+const clozeBlankMark = "_____"
+
+// isCloze 判断当前会话是否为填空模式：只有句子和文章支持，反向练习不叠加填空
+func (m PracticeSession) isCloze() bool {
+	return m.practiceMode == practiceModeCloze && !m.reverse && practice.SupportsCloze(m.currentResourceType())
+}
+
+// hasClozeBlanks 判断当前项目是否按填空作答。少于两个单词的行无法挖空，按抄写练习。
+func (m PracticeSession) hasClozeBlanks() bool {
+	return m.isCloze() && len(m.clozeBlanks) > 0
+}
+
+// prepareCloze 为当前项目选择挖空的单词，切换项目或练习模式时调用
+func (m *PracticeSession) prepareCloze() {
+	m.clozeBlanks, m.clozeFilled = nil, nil
+	if !m.isCloze() || m.state != "practicing" {
+		return
+	}
+	item := m.getCurrentRawItem()
+	if item == "" {
+		return
+	}
+
+	if m.clozeRand == nil {
+		m.clozeRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	// 没有翻译的整句不会被按空格拆开
+	text, _ := practice.ArticleText(item)
+	if m.clozeVocabulary == nil && config.AppConfig.Cloze.BlankSource() == config.ClozeSourceWordLists {
+		m.clozeVocabulary = practice.ClozeVocabulary()
+	}
+	m.clozeBlanks = practice.PickClozeBlanks(text, config.AppConfig.Cloze.BlankCount(), m.clozeVocabulary, m.clozeRand)
+	m.clozeFilled = make([]bool, len(m.clozeBlanks))
+}
+
+// remainingClozeAnswers 返回还没有填对的空的答案，按在句子中的顺序
+func (m PracticeSession) remainingClozeAnswers() []string {
+	answers := make([]string, 0, len(m.clozeBlanks))
+	for i, blank := range m.clozeBlanks {
+		if !m.clozeFilled[i] {
+			answers = append(answers, blank.Answer)
+		}
+	}
+	return answers
+}
+
+// scoreClozeAnswer 按顺序将输入的单词与还没有填对的空逐个比较，每个空计一次答对或答错。
+// 填对的空在之后的作答中不再需要输入，全部填对时返回 true。
+func (m *PracticeSession) scoreClozeAnswer(userInput string) bool {
+	matchMode := m.matchMode()
+	if matchMode == "" {
+		matchMode = "exact_match"
+	}
+
+	words := strings.Fields(userInput)
+	next := 0
+	allCorrect := true
+	for i, blank := range m.clozeBlanks {
+		if m.clozeFilled[i] {
+			continue
+		}
+		typed := ""
+		if next < len(words) {
+			typed = words[next]
+		}
+		next++
+
+		if typed != "" && m.matchesAnswer(typed, blank.Answer, matchMode) {
+			m.clozeFilled[i] = true
+			m.correct++
+		} else {
+			m.incorrect++
+			allCorrect = false
+		}
+	}
+	return allCorrect
+}
+
+// clozeInputLabel 返回填空模式下输入框上方的说明
+func (m PracticeSession) clozeInputLabel() string {
+	if remaining := len(m.remainingClozeAnswers()); remaining > 1 {
+		return fmt.Sprintf("请按顺序输入空缺的 %d 个单词，用空格分隔:", remaining)
+	}
+	return "请输入空缺的单词:"
+}
+
+// renderClozePrompt 渲染填空模式下的题目：挖空后的句子（已填对的空显示答案）、翻译和提示
+func (m PracticeSession) renderClozePrompt(item string) string {
+	text, translation := practice.ArticleText(item)
+	var sentence strings.Builder
+	last, number := 0, 0
+	for i, blank := range m.clozeBlanks {
+		sentence.WriteString(text[last:blank.Start])
+		if m.clozeFilled[i] {
+			sentence.WriteString(blank.Answer)
+		} else {
+			number++
+			sentence.WriteString(fmt.Sprintf("(%d)%s", number, clozeBlankMark))
+		}
+		last = blank.End
+	}
+	sentence.WriteString(text[last:])
+
+	var s strings.Builder
+	s.WriteString(RenderHighlight("请填空:") + "\n")
+	s.WriteString(RenderText(m.wrapText(sentence.String(), m.width-4)) + "\n")
+	if translation = strings.TrimSpace(translation); translation != "" && m.getShowTranslationConfig() {
+		s.WriteString(RenderText(m.wrapText(translation, m.width-4)) + "\n")
+	}
+	if m.hintLevel > hintNone {
+		s.WriteString(RenderText("提示: "+maskHint(strings.Join(m.remainingClozeAnswers(), " "), m.hintLevel)) + "\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// newTestClozeSession 创建填空模式的句子练习，挖去词表中的单词
+func newTestClozeSession(t *testing.T, items ...string) *PracticeSession {
+	setupSessionTestDir(t)
+	config.AppConfig.PracticeMode = practiceModeCloze
+	config.AppConfig.PracticeDirection = practice.DirectionForward
+	config.AppConfig.Cloze = config.ClozeConfig{Source: config.ClozeSourceWordLists, Blanks: 2}
+
+	session := newSessionModel(practice.Sentences, "cloze", items, sequentialOrder(len(items)), "sequential")
+	session.clozeVocabulary = map[string]bool{"coat": true, "umbrella": true}
+	session.prepareCloze()
+	return session
+}
+
+// submitAnswer 输入答案并提交
+func submitAnswer(m *PracticeSession, answer string) {
+	m.textInput.SetValue(answer)
+	m.handleAnswerSubmission()
+}
+
+// 测试填空按空计分：填对的空显示答案，之后只需输入剩下的空
+func TestClozeScoringPerBlank(t *testing.T) {
+	m := newTestClozeSession(t, "My coat and my umbrella please. ->> 请把我的大衣和伞拿给我。", "Here is your umbrella.")
+
+	if !m.hasClozeBlanks() || m.getExpectedInput(m.getCurrentRawItem()) != "coat umbrella" {
+		t.Fatalf("挖空 = %+v", m.clozeBlanks)
+	}
+	if view := m.View(); !strings.Contains(view, "My (1)_____ and my (2)_____ please.") {
+		t.Errorf("题目 = %q", view)
+	}
+
+	submitAnswer(m, "coat umbrela")
+	if m.correct != 1 || m.incorrect != 1 || m.completedCount != 0 {
+		t.Fatalf("答对 %d、答错 %d，已完成 %d", m.correct, m.incorrect, m.completedCount)
+	}
+	if view := m.View(); !strings.Contains(view, "My coat and my (1)_____ please.") || !strings.Contains(view, "请输入空缺的单词:") {
+		t.Errorf("填对的空应显示答案: %q", view)
+	}
+
+	submitAnswer(m, "umbrella")
+	if m.correct != 2 || m.incorrect != 1 || m.completedCount != 1 {
+		t.Fatalf("填完后答对 %d、答错 %d，已完成 %d", m.correct, m.incorrect, m.completedCount)
+	}
+	if len(m.clozeBlanks) != 1 || m.clozeBlanks[0].Answer != "umbrella" {
+		t.Errorf("下一项的挖空 = %+v", m.clozeBlanks)
+	}
+}
+
+// 测试无法挖空的行按抄写练习，单词练习不支持填空
+func TestClozeFallback(t *testing.T) {
+	m := newTestClozeSession(t, "Thanks!")
+	if m.hasClozeBlanks() || m.getExpectedInput(m.getCurrentRawItem()) != "Thanks!" {
+		t.Errorf("只有一个单词的行应按抄写练习: %+v", m.clozeBlanks)
+	}
+
+	words := newSessionModel(practice.Words, "words", []string{"apple ->> 苹果"}, []int{0}, "sequential")
+	if words.isCloze() {
+		t.Error("单词练习不应使用填空模式")
+	}
+	words.handleModeCommand()
+	words.handleModeCommand()
+	if words.practiceMode != practiceModeCopy {
+		t.Errorf("单词练习切换模式后 = %s，不应切换到填空", words.practiceMode)
+	}
+}
@@ -25,6 +25,15 @@ var (
 			Foreground(lipgloss.Color("#7D56F4")).
 			Bold(true)
 
+	// 音标样式
+	PhoneticStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#5FAFD7"))
+
+	// 例句样式
+	ExampleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888")).
+			Italic(true)
+
 	// 错误文本样式
 	ErrorStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FF0000")).
@@ -48,6 +57,24 @@ var (
 				Background(lipgloss.Color("#7D56F4")).
 				Padding(0, 3).
 				Margin(0, 1)
+
+	// 文章连续输入：已正确输入的字符
+	ArticleTypedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#DDDDDD"))
+
+	// 文章连续输入：尚未输入的字符
+	ArticlePendingStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#666666"))
+
+	// 文章连续输入：输错的字符，显示期望的字符
+	ArticleWrongStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FF0000")).
+				Underline(true)
+
+	// 文章连续输入：光标所在的字符
+	ArticleCursorStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(lipgloss.Color("#7D56F4"))
 )
 
 // RenderTitle 渲染标题
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/ajilisiwei/mllt-cli/internal/course"
+)
+
+// lessonMarks 课程中各状态的课在文件列表中的标记
+var lessonMarks = map[string]string{
+	course.LessonCompleted: "✔ ",
+	course.LessonCurrent:   "▶ ",
+	course.LessonPending:   "",
+	course.LessonLocked:    "🔒 ",
+}
+
+// orderCourseFiles 按课程顺序排列文件夹中的资源，不在课程中的资源排在最后
+func orderCourseFiles(c *course.Course, files []string) []string {
+	ordered := make([]string, 0, len(files))
+	inCourse := make(map[string]bool, len(c.Lessons))
+	for _, lesson := range c.Lessons {
+		ordered = append(ordered, lesson.Name)
+		inCourse[lesson.Name] = true
+	}
+	for _, file := range files {
+		if !inCourse[file] {
+			ordered = append(ordered, file)
+		}
+	}
+	return ordered
+}
+
+// lessonLabels 在资源的标题前加上课的状态标记，并在描述中说明成绩或解锁条件
+func lessonLabels(lesson *course.Lesson, title, description string) (string, string) {
+	title = lessonMarks[lesson.Status] + title
+	switch lesson.Status {
+	case course.LessonCompleted:
+		description = fmt.Sprintf("已通过 · 最高准确率 %.1f%% · %s", lesson.Result.BestAccuracy, description)
+	case course.LessonCurrent:
+		description = "当前课 · " + description
+	case course.LessonLocked:
+		description = "完成上一课后解锁"
+	}
+	return title, description
+}
+
+// courseFolderDescription 返回课程文件夹在列表中的描述
+func courseFolderDescription(c *course.Course) string {
+	description := fmt.Sprintf("课程 · 已完成 %d/%d 课", c.CompletedCount(), len(c.Lessons))
+	if current := c.Current(); current != nil {
+		description += " · 下一课 " + current.Name
+	}
+	return description
+}
+
+// renderCourseOutcome 渲染完成一课后的课程进度
+func renderCourseOutcome(outcome *course.Outcome) string {
+	if outcome == nil {
+		return ""
+	}
+	if !outcome.Passed {
+		score := fmt.Sprintf("准确率 %.1f%%", outcome.Accuracy)
+		if outcome.WPM >= 0 {
+			score += fmt.Sprintf("，速度 %.1f WPM", outcome.WPM)
+		}
+		return RenderHighlight(fmt.Sprintf("本课未通过（%s），通过标准：%s", score, outcome.Pass)) + "\n\n"
+	}
+	if outcome.Next == "" {
+		return RenderSuccess("本课已通过，课程全部完成！") + "\n\n"
+	}
+	return RenderSuccess("本课已通过，下一课："+outcome.Next) + "\n\n"
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/course"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/resume"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// setupCourseFolder 在临时目录中创建带课程清单的资源文件夹，返回该文件夹
+func setupCourseFolder(t *testing.T, resourceType string) practice.ResourceFolder {
+	setupSessionTestDir(t)
+
+	dir := filepath.Join("resources", config.AppConfig.CurrentLanguage, resourceType, "NCE-1")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"1-001_Excuse_me.txt": "Excuse me! ->> 对不起！\n",
+		"1-003_Sorry_sir.txt": "Sorry sir. ->> 对不起，先生。\n",
+		"extra.txt":           "Hello. ->> 你好。\n",
+		course.ManifestFile:   `{"title": "新概念英语第一册", "lessons": ["1-003_Sorry_sir", "1-001_Excuse_me"], "enforce": true}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	folders, err := practice.GetResourceFolders(resourceType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, folder := range folders {
+		if folder.DirName == "NCE-1" {
+			return folder
+		}
+	}
+	t.Fatal("找不到测试文件夹")
+	return practice.ResourceFolder{}
+}
+
+// 测试课程文件夹按课程顺序列出，当前课可练习，锁定的课不能进入
+func TestResourceFilesMenuCourse(t *testing.T) {
+	folder := setupCourseFolder(t, practice.Articles)
+
+	menu := NewResourceFilesMenu(practice.Articles, folder)
+	items := menu.list.Items()
+	if len(items) != 4 {
+		t.Fatalf("列表项 = %d", len(items))
+	}
+	current, locked, extra := items[0].(MenuItem), items[1].(MenuItem), items[2].(MenuItem)
+	if !strings.HasPrefix(current.title, "▶ ") || !strings.Contains(current.title, "1-003_Sorry_sir") || current.action == nil {
+		t.Errorf("第一项应为可练习的当前课: %+v", current)
+	}
+	if !strings.HasPrefix(locked.title, "🔒 ") || locked.action != nil {
+		t.Errorf("第二项应为锁定的课: %+v", locked)
+	}
+	if !strings.Contains(extra.title, "extra") || extra.action == nil {
+		t.Errorf("不在课程中的资源应排在最后且可练习: %+v", extra)
+	}
+
+	// 没有要求按顺序学习时，之后的课照常排序但可以直接练习
+	manifest := filepath.Join("resources", config.AppConfig.CurrentLanguage, practice.Articles, "NCE-1", course.ManifestFile)
+	if err := os.WriteFile(manifest, []byte(`{"lessons": ["1-003_Sorry_sir", "1-001_Excuse_me"]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	pending := NewResourceFilesMenu(practice.Articles, folder).list.Items()[1].(MenuItem)
+	if strings.HasPrefix(pending.title, "🔒 ") || !strings.Contains(pending.title, "1-001_Excuse_me") || pending.action == nil {
+		t.Errorf("不锁定的课程中之后的课应可练习: %+v", pending)
+	}
+}
+
+// 测试选择题的文件列表同样锁定尚未解锁的课
+func TestChoiceFilesMenuCourse(t *testing.T) {
+	folder := setupCourseFolder(t, practice.Words)
+
+	items := newResourceFilesMenu(practice.Words, folder, true).list.Items()
+	current, locked := items[0].(MenuItem), items[1].(MenuItem)
+	if !strings.HasPrefix(current.title, "▶ ") || current.action == nil {
+		t.Errorf("第一项应为可练习的当前课: %+v", current)
+	}
+	if !strings.HasPrefix(locked.title, "🔒 ") || locked.action != nil {
+		t.Errorf("第二项应为锁定的课: %+v", locked)
+	}
+}
+
+// 测试完成当前课后显示课程进度，再次进入文件列表时下一课解锁
+func TestArticleSessionCourseOutcome(t *testing.T) {
+	folder := setupCourseFolder(t, practice.Articles)
+
+	m := newArticleSessionModel("NCE-1/1-003_Sorry_sir", []string{"Sorry sir. ->> 对不起，先生。"})
+	m.foldCase = false
+	typeText(m, "Sorry sir.")
+	if m.state != "finished" || m.courseOutcome == nil || !m.courseOutcome.Passed {
+		t.Fatalf("全对完成当前课后应通过: %+v", m.courseOutcome)
+	}
+	if view := m.View(); !strings.Contains(view, "下一课：1-001_Excuse_me") {
+		t.Errorf("结果中应显示下一课: %q", view)
+	}
+
+	items := NewResourceFilesMenu(practice.Articles, folder).list.Items()
+	if title := items[0].(MenuItem).title; !strings.HasPrefix(title, "✔ ") {
+		t.Errorf("通过的课 = %q", title)
+	}
+	if next := items[1].(MenuItem); !strings.HasPrefix(next.title, "▶ ") || next.action == nil {
+		t.Errorf("下一课应已解锁: %+v", next)
+	}
+}
+
+// 测试从保存的进度继续完成的文章按整课的成绩判断是否通过：退出前的错误同样计入
+func TestArticleSessionResumeCourse(t *testing.T) {
+	setupCourseFolder(t, practice.Articles)
+
+	items := []string{"Sorry sir. ->> 对不起，先生。", "Thank you. ->> 谢谢。"}
+	// resumeAndFinish 输入第一行后退出，再从保存的进度继续输入第二行
+	resumeAndFinish := func(firstLine string) *ArticleSession {
+		m := newArticleSessionModel("NCE-1/1-003_Sorry_sir", items)
+		m.foldCase = false
+		typeText(m, firstLine+"\n")
+		m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		checkpoint, err := resume.Load(practice.Articles, m.fileName)
+		if err != nil || checkpoint == nil || checkpoint.Typing == nil {
+			t.Fatalf("中途退出时应保存进度和按键统计: %+v, %v", checkpoint, err)
+		}
+
+		resumed := newArticleSessionModel(m.fileName, items)
+		resumed.foldCase = false
+		if !resumed.resume(checkpoint) {
+			t.Fatal("应可以从保存的进度继续")
+		}
+		typeText(resumed, "Thank you.")
+		if resumed.state != "finished" || resumed.courseOutcome == nil {
+			t.Fatalf("继续完成的练习应更新课程进度: %+v", resumed.courseOutcome)
+		}
+		return resumed
+	}
+
+	// 继续后的部分全对，但合并退出前的错误后准确率不足，不能通过
+	if outcome := resumeAndFinish("Sxrry xxr.").courseOutcome; outcome.Passed {
+		t.Errorf("合并退出前的错误后不应通过: %+v", outcome)
+	}
+	c, err := course.LoadForFile(practice.Articles, "NCE-1/1-003_Sorry_sir")
+	if err != nil || c == nil {
+		t.Fatalf("加载课程失败: %v", err)
+	}
+	if current := c.Current(); current == nil || current.Name != "1-003_Sorry_sir" {
+		t.Errorf("当前课 = %+v，不应解锁下一课", current)
+	}
+
+	// 整课准确率达标时通过，解锁下一课
+	if outcome := resumeAndFinish("Sorry sor.").courseOutcome; !outcome.Passed || outcome.Next != "1-001_Excuse_me" {
+		t.Errorf("整课达标时应通过并进入下一课: %+v", outcome)
+	}
+}
+
+// 测试继续练习后没有输入就退出时不记录统计
+func TestArticleSessionResumeEscWithoutTyping(t *testing.T) {
+	setupCourseFolder(t, practice.Articles)
+
+	items := []string{"Sorry sir. ->> 对不起，先生。", "Thank you. ->> 谢谢。"}
+	m := newArticleSessionModel("NCE-1/1-003_Sorry_sir", items)
+	typeText(m, "Sorry sir.\n")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	checkpoint, err := resume.Load(practice.Articles, m.fileName)
+	if err != nil || checkpoint == nil {
+		t.Fatalf("中途退出时应保存进度: %v", err)
+	}
+
+	resumed := newArticleSessionModel(m.fileName, items)
+	if !resumed.resume(checkpoint) {
+		t.Fatal("应可以从保存的进度继续")
+	}
+	resumed.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	records, err := statistics.GetAllSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Total != 1 {
+		t.Errorf("继续后没有输入不应记录统计: %+v", records)
+	}
+}
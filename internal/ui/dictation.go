@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+)
+
+// 练习模式
+const (
+	practiceModeCopy      = "copy"      // 抄写：显示原文，照着输入
+	practiceModeDictation = "dictation" // 默写：只显示翻译或提示，凭记忆输入原文
+	practiceModeCloze     = "cloze"     // 填空：挖去句子中的单词，只输入空缺的单词
+)
+
+// 默写模式的提示等级
+const (
+	hintNone        = iota // 不显示提示
+	hintFirstLetter        // 显示每个单词的首字母
+	hintLength             // 显示首字母和长度
+	maxHintLevel    = hintLength
+)
+
+// normalizePracticeMode 规范化练习模式，无效值按抄写模式处理
+func normalizePracticeMode(mode string) string {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case practiceModeDictation:
+		return practiceModeDictation
+	case practiceModeCloze:
+		return practiceModeCloze
+	}
+	return practiceModeCopy
+}
+
+// practiceModeTitle 返回练习模式的中文名称
+func practiceModeTitle(mode string) string {
+	switch mode {
+	case practiceModeDictation:
+		return "默写"
+	case practiceModeCloze:
+		return "填空"
+	}
+	return "抄写"
+}
+
+// isDictation 判断当前会话是否为默写模式。反向练习本身就是看原文答翻译，不再叠加默写。
+func (m PracticeSession) isDictation() bool {
+	return m.practiceMode == practiceModeDictation && !m.reverse
+}
+
+// expectsTranslation 判断当前会话中的条目是否要求输入翻译
+func (m PracticeSession) expectsTranslation(item string) bool {
+	return practice.ExpectsTranslation(item, m.reverse)
+}
+
+// handleHintCommand 提高当前项目的提示等级：首字母 → 长度
+func (m *PracticeSession) handleHintCommand() (tea.Model, tea.Cmd) {
+	if !m.isDictation() && !m.hasClozeBlanks() {
+		m.setCommandFeedback("提示仅在默写和填空模式下可用，输入\"> mode\"切换模式。", true)
+		return m, nil
+	}
+	if m.getCurrentRawItem() == "" {
+		m.setCommandFeedback("没有可提示的内容。", true)
+		return m, nil
+	}
+	if m.hintLevel >= maxHintLevel {
+		m.setCommandFeedback("已显示全部提示。", false)
+		return m, nil
+	}
+
+	m.hintLevel++
+	if m.hintLevel == hintFirstLetter {
+		m.setCommandFeedback("已显示首字母提示，本题评分最高为\"困难\"。", false)
+	} else {
+		m.setCommandFeedback("已显示长度提示。", false)
+	}
+	return m, nil
+}
+
+// handleModeCommand 在本次练习中依次切换抄写、默写和填空模式（仅句子和文章支持填空），不修改配置
+func (m *PracticeSession) handleModeCommand() (tea.Model, tea.Cmd) {
+	if m.reverse {
+		m.setCommandFeedback("反向练习不支持默写和填空模式。", true)
+		return m, nil
+	}
+	switch {
+	case m.practiceMode == practiceModeCopy:
+		m.practiceMode = practiceModeDictation
+	case m.practiceMode == practiceModeDictation && practice.SupportsCloze(m.currentResourceType()):
+		m.practiceMode = practiceModeCloze
+	default:
+		m.practiceMode = practiceModeCopy
+	}
+	m.hintLevel = hintNone
+	m.prepareCloze()
+	m.setCommandFeedback(fmt.Sprintf("本次练习已切换为%s模式。", practiceModeTitle(m.practiceMode)), false)
+	return m, nil
+}
+
+// capGradeForHints 使用提示后答对的评分最高为"困难"
+func (m PracticeSession) capGradeForHints(grade srs.Grade) srs.Grade {
+	if m.hintLevel > hintNone && grade > srs.GradeHard {
+		return srs.GradeHard
+	}
+	return grade
+}
+
+// renderDictationPrompt 渲染默写模式下的题目：翻译（若有）和当前等级的提示
+func (m PracticeSession) renderDictationPrompt(item string) string {
+	primary, translation := practice.ParseLine(item)
+	primary = strings.TrimSpace(primary)
+	translation = strings.TrimSpace(translation)
+	if primary == "" {
+		primary = strings.TrimSpace(item)
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderHighlight("请默写:") + "\n")
+	if translation != "" {
+		s.WriteString(RenderText(m.wrapText(translation, m.width-4)) + "\n")
+	}
+
+	// 没有翻译时至少显示长度提示，否则无从作答
+	level := m.hintLevel
+	if translation == "" && level < hintLength {
+		level = hintLength
+	}
+	if level > hintNone {
+		s.WriteString(RenderText("提示: "+maskHint(primary, level)) + "\n")
+	}
+	return s.String()
+}
+
+// maskHint 按提示等级遮盖原文：
+// 首字母等级只显示每个单词的首字母，长度等级额外用"_"标出每个字母的位置，
+// 标点和空格保持原样。
+func maskHint(text string, level int) string {
+	if level <= hintNone {
+		return ""
+	}
+
+	words := strings.Fields(text)
+	masked := make([]string, 0, len(words))
+	for _, word := range words {
+		runes := []rune(word)
+		var builder strings.Builder
+		first := true
+		for _, r := range runes {
+			if !unicode.IsLetter(r) && !unicode.IsNumber(r) {
+				if level >= hintLength {
+					builder.WriteRune(r)
+				}
+				continue
+			}
+			if first {
+				builder.WriteRune(r)
+				first = false
+				continue
+			}
+			if level >= hintLength {
+				builder.WriteRune('_')
+			}
+		}
+		if level < hintLength {
+			builder.WriteString("…")
+		}
+		masked = append(masked, builder.String())
+	}
+	return strings.Join(masked, " ")
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+)
+
+// 测试默写提示：首字母提示和长度提示，标点保持可见
+func TestMaskHint(t *testing.T) {
+	tests := []struct {
+		text     string
+		level    int
+		expected string
+	}{
+		{"good morning", hintNone, ""},
+		{"good morning", hintFirstLetter, "g… m…"},
+		{"good morning", hintLength, "g___ m______"},
+		{"It's fine.", hintLength, "I_'_ f___."},
+		{"こんにちは", hintLength, "こ____"},
+	}
+
+	for _, tt := range tests {
+		if got := maskHint(tt.text, tt.level); got != tt.expected {
+			t.Errorf("maskHint(%q, %d) = %q, 期望 %q", tt.text, tt.level, got, tt.expected)
+		}
+	}
+}
+
+// 测试使用提示后评分最高为"困难"，并在切换到下一题时清除提示
+func TestDictationHintCapsGrade(t *testing.T) {
+	session := &PracticeSession{
+		resourceType:  "words",
+		items:         []string{"apple ->> 苹果"},
+		practiceOrder: []int{0},
+		practiceMode:  normalizePracticeMode("Dictation"),
+		typing:        newTypingTracker(false),
+	}
+
+	if got := session.capGradeForHints(srs.GradeEasy); got != srs.GradeEasy {
+		t.Errorf("未使用提示时不应降低评分，实际 %v", got)
+	}
+
+	session.handleHintCommand()
+	if session.hintLevel != hintFirstLetter {
+		t.Fatalf("第一次提示应显示首字母，实际等级 %d", session.hintLevel)
+	}
+	if got := session.capGradeForHints(srs.GradeEasy); got != srs.GradeHard {
+		t.Errorf("使用提示后评分应为困难，实际 %v", got)
+	}
+	if got := session.capGradeForHints(srs.GradeAgain); got != srs.GradeAgain {
+		t.Errorf("较低的评分不应被提高，实际 %v", got)
+	}
+
+	session.resetItemTracking()
+	if session.hintLevel != hintNone {
+		t.Error("切换到下一题后应清除提示等级")
+	}
+}
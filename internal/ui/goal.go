@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// loadTodayGoalProgress 读取今天已完成的目标进度，未设置目标时返回空进度
+func loadTodayGoalProgress() statistics.GoalProgress {
+	goal := config.AppConfig.DailyGoal
+	if !goal.Enabled() {
+		return statistics.GoalProgress{}
+	}
+	progress, err := statistics.GetGoalProgress(time.Now().Format("2006-01-02"), goal)
+	if err != nil {
+		return statistics.GoalProgress{DailyGoalConfig: goal}
+	}
+	return progress
+}
+
+// formatGoalProgress 将目标进度格式化为一行文字，例如"今日目标: 120/200 项 · 12/20 分钟"
+func formatGoalProgress(progress statistics.GoalProgress) string {
+	parts := make([]string, 0, 2)
+	if progress.Items > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d 项", progress.DoneItems, progress.Items))
+	}
+	if progress.Minutes > 0 {
+		parts = append(parts, fmt.Sprintf("%d/%d 分钟", int(progress.DoneMinutes), progress.Minutes))
+	}
+
+	line := "今日目标: " + strings.Join(parts, " · ")
+	if progress.Reached() {
+		line += " ✔ 已完成"
+	}
+	return line
+}
+
+// mainMenuFooter 返回主菜单底部的目标进度和连续打卡信息，没有可展示的内容时返回空字符串
+func mainMenuFooter() string {
+	var parts []string
+
+	progress := loadTodayGoalProgress()
+	if progress.Enabled() {
+		parts = append(parts, formatGoalProgress(progress))
+	}
+
+	if streak, err := statistics.GetStreak(config.AppConfig.DailyGoal, time.Now()); err == nil && streak.Current > 0 {
+		line := fmt.Sprintf("连续打卡 %d 天", streak.Current)
+		if !streak.TodayDone {
+			line += "（今天还未打卡）"
+		}
+		parts = append(parts, line)
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/kana"
+)
+
+// kanaInputAvailable 判断当前语言是否可以使用假名输入
+func kanaInputAvailable() bool {
+	return strings.EqualFold(config.AppConfig.CurrentLanguage, "japanese")
+}
+
+// convertKanaInput 将输入框中光标之前的罗马字实时转换为假名，命令输入不转换。
+// 光标之后的内容保持不变，光标随之前文本长度的变化移动，便于回到前面修改。
+func (m *PracticeSession) convertKanaInput() {
+	value := m.textInput.Value()
+	if strings.HasPrefix(strings.TrimSpace(value), ">") {
+		return
+	}
+	runes := []rune(value)
+	position := min(m.textInput.Position(), len(runes))
+	before := string(runes[:position])
+	converted := kana.Convert(before)
+	if converted == before {
+		return
+	}
+	m.textInput.SetValue(converted + string(runes[position:]))
+	m.textInput.SetCursor(len([]rune(converted)))
+}
+
+// pendingKanaTrimmed 去掉假名输入时末尾还未转换的罗马字
+func (m PracticeSession) pendingKanaTrimmed(value string) string {
+	if !m.kanaInput {
+		return value
+	}
+	return strings.TrimRightFunc(value, func(r rune) bool {
+		return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '\''
+	})
+}
+
+// handleKanaCommand 在本次练习中开启或关闭假名输入，不修改配置
+func (m *PracticeSession) handleKanaCommand() (tea.Model, tea.Cmd) {
+	if !kanaInputAvailable() {
+		m.setCommandFeedback("假名输入仅适用于日语练习。", true)
+		return m, nil
+	}
+	m.kanaInput = !m.kanaInput
+	if m.kanaInput {
+		m.setCommandFeedback("已开启假名输入，带读音的条目可以直接输入假名读音。", false)
+	} else {
+		m.setCommandFeedback("已关闭假名输入。", false)
+	}
+	return m, nil
+}
+
+// acceptsReading 判断是否接受只输入汉字写法或假名读音。
+// 假名输入无法输入汉字，开启时总是接受假名读音。
+func (m PracticeSession) acceptsReading() bool {
+	return m.kanaInput || config.AppConfig.CurrentProfile().AcceptReading
+}
+
+// answerCandidates 返回当前答案所有可接受的写法。
+// 答案形如"食べる（たべる）"且接受读音时，完整写法、汉字写法和假名读音都算正确。
+func (m PracticeSession) answerCandidates(expected string) []string {
+	if !m.acceptsReading() {
+		return []string{expected}
+	}
+	form, reading := kana.SplitReading(expected)
+	if reading == "" {
+		return []string{expected}
+	}
+	return []string{expected, form, reading}
+}
@@ -926,8 +926,25 @@ func (m ImportView) handleImport() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	err := manage.ImportResourceForTest(m.resourceType, normalizedFolder, expandedPath)
-	if err != nil {
+	// 先试算合并结果，同名文件已存在时进入合并界面，由用户决定合并还是覆盖；句子和文章不支持合并，直接覆盖
+	if manage.SupportsMerge(m.resourceType) {
+		preview, err := manage.ImportResource(m.resourceType, normalizedFolder, expandedPath,
+			manage.ImportOptions{Conflict: manage.ConflictMerge, DryRun: true})
+		if err != nil {
+			m.message = "导入失败: " + err.Error()
+			return m, nil
+		}
+		if preview.Action == manage.ImportMerged {
+			mergeView := NewImportMergeView(m, normalizedFolder, expandedPath, preview)
+			if m.width > 0 && m.height > 0 {
+				updatedModel, _ := mergeView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+				return updatedModel, nil
+			}
+			return mergeView, nil
+		}
+	}
+
+	if _, err := manage.ImportResource(m.resourceType, normalizedFolder, expandedPath, manage.ImportOptions{}); err != nil {
 		m.message = "导入失败: " + err.Error()
 		return m, nil
 	}
@@ -995,6 +1012,178 @@ func (m ImportView) View() string {
 	return s.String()
 }
 
+// 合并界面每页显示的冲突数
+const mergeConflictPageSize = 6
+
+// ImportMergeView 导入同名文件时的合并视图：显示新增、更新、未变的条目数，
+// 并逐条对比原文相同但翻译不同的条目，由用户选择保留本地、使用导入或合并翻译
+type ImportMergeView struct {
+	importView  ImportView
+	folderDir   string
+	sourcePath  string
+	preview     *manage.ImportResult
+	resolutions map[string]string
+	cursor      int
+	message     string
+	width       int
+	height      int
+	quitting    bool
+}
+
+// NewImportMergeView 根据试算的合并结果创建合并视图，冲突默认保留本地翻译
+func NewImportMergeView(importView ImportView, folderDir, sourcePath string, preview *manage.ImportResult) *ImportMergeView {
+	resolutions := make(map[string]string, len(preview.Conflicts))
+	for _, conflict := range preview.Conflicts {
+		resolutions[conflict.Term] = conflict.Resolution
+	}
+	return &ImportMergeView{
+		importView:  importView,
+		folderDir:   folderDir,
+		sourcePath:  sourcePath,
+		preview:     preview,
+		resolutions: resolutions,
+	}
+}
+
+// Init 初始化模型
+func (m ImportMergeView) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m ImportMergeView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		conflicts := m.preview.Conflicts
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			// 返回导入界面，保留已输入的文件夹和路径
+			updatedModel, _ := m.importView.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+			return updatedModel, nil
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(conflicts)-1 {
+				m.cursor++
+			}
+		case "left", "right":
+			if len(conflicts) > 0 {
+				term := conflicts[m.cursor].Term
+				m.resolutions[term] = cycleMergeResolution(m.resolutions[term], msg.String() == "right")
+			}
+		case "1", "2", "3":
+			if len(conflicts) > 0 {
+				m.resolutions[conflicts[m.cursor].Term] = manage.MergePolicies[msg.String()[0]-'1']
+			}
+
+		case "enter":
+			return m.finishImport(manage.ImportOptions{
+				Conflict:    manage.ConflictMerge,
+				Resolutions: m.resolutions,
+			})
+		case "o", "O":
+			return m.finishImport(manage.ImportOptions{Conflict: manage.ConflictOverwrite})
+		}
+	}
+
+	return m, nil
+}
+
+// cycleMergeResolution 切换到上一个或下一个处理方式
+func cycleMergeResolution(current string, forward bool) string {
+	index := 0
+	for i, resolution := range manage.MergePolicies {
+		if resolution == current {
+			index = i
+		}
+	}
+	if forward {
+		index = (index + 1) % len(manage.MergePolicies)
+	} else {
+		index = (index + len(manage.MergePolicies) - 1) % len(manage.MergePolicies)
+	}
+	return manage.MergePolicies[index]
+}
+
+// finishImport 按选择的方式导入，成功后返回资源类型菜单
+func (m ImportMergeView) finishImport(options manage.ImportOptions) (tea.Model, tea.Cmd) {
+	if _, err := manage.ImportResource(m.importView.resourceType, m.folderDir, m.sourcePath, options); err != nil {
+		m.message = "导入失败: " + err.Error()
+		return m, nil
+	}
+
+	newModel := NewResourceTypeMenu("import")
+	if m.width > 0 && m.height > 0 {
+		updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		return updatedModel, nil
+	}
+	return newModel, nil
+}
+
+// View 渲染视图
+func (m ImportMergeView) View() string {
+	if m.quitting {
+		return "导入已取消！"
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderTitle("合并"+getResourceTypeTitle(m.importView.resourceType)) + "\n\n")
+	s.WriteString(RenderText(fmt.Sprintf("%s 已存在，按原文合并的结果：", m.preview.DisplayName())) + "\n")
+	// 试算时冲突都按保留本地计入未变，这里单独列出
+	s.WriteString(RenderText(fmt.Sprintf("新增 %d 条，补充翻译 %d 条，未变 %d 条，翻译不同 %d 条",
+		m.preview.Added, m.preview.Updated, m.preview.Unchanged-len(m.preview.Conflicts),
+		len(m.preview.Conflicts))) + "\n\n")
+
+	conflicts := m.preview.Conflicts
+	if len(conflicts) > 0 {
+		// 冲突较多时只显示光标附近的一页
+		start := 0
+		if m.cursor >= mergeConflictPageSize {
+			start = m.cursor - mergeConflictPageSize + 1
+		}
+		end := start + mergeConflictPageSize
+		if end > len(conflicts) {
+			end = len(conflicts)
+		}
+		for i := start; i < end; i++ {
+			conflict := conflicts[i]
+			marker := "  "
+			term := RenderText(conflict.Term)
+			if i == m.cursor {
+				marker = "> "
+				term = RenderHighlight(conflict.Term)
+			}
+			s.WriteString(fmt.Sprintf("%s%s  [%s]\n", marker, term, manage.MergePolicyNames[m.resolutions[conflict.Term]]))
+			s.WriteString("    本地: " + conflict.Local + "\n")
+			s.WriteString("    导入: " + conflict.Incoming + "\n")
+		}
+		s.WriteString(RenderText(fmt.Sprintf("（%d/%d）", m.cursor+1, len(conflicts))) + "\n\n")
+	}
+
+	if m.message != "" {
+		s.WriteString(RenderError(m.message) + "\n\n")
+	}
+
+	if len(conflicts) > 0 {
+		s.WriteString(RenderText("↑/↓ 选择条目，←/→ 或 1/2/3 切换保留本地/使用导入/合并翻译") + "\n")
+	}
+	s.WriteString(RenderText("按 Enter 合并，按 O 覆盖整个文件，按 Esc 返回") + "\n")
+
+	return s.String()
+}
+
 // DeleteConfirmView 删除确认视图模型
 type DeleteConfirmView struct {
 	resourceType string
@@ -1038,7 +1227,7 @@ func (m DeleteConfirmView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "y", "Y":
 			// 确认删除
-			err := manage.DeleteResourceWithoutConfirm(m.resourceType, m.resourceName)
+			_, err := manage.DeleteResource(m.resourceType, m.resourceName, nil)
 			if err != nil {
 				m.message = "删除失败: " + err.Error()
 				return m, nil
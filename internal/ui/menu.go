@@ -24,6 +24,7 @@ type MainMenu struct {
 	list     list.Model
 	choice   *MenuItem
 	quitting bool
+	footer   string // 底部的每日目标与连续打卡信息
 }
 
 // 创建新的主菜单
@@ -39,6 +40,11 @@ func NewMainMenu() *MainMenu {
 			description: "进行单词、短语、句子、文章等的打字练习",
 			action:      func() (tea.Model, error) { return NewPracticeMenu(), nil },
 		},
+		MenuItem{
+			title:       "今日复习",
+			description: reviewMenuDescription(),
+			action:      func() (tea.Model, error) { return NewReviewSession(), nil },
+		},
 		MenuItem{
 			title:       "资源管理",
 			description: "管理练习资源，包括删除和导入资源",
@@ -68,7 +74,8 @@ func NewMainMenu() *MainMenu {
 	l.Styles.Title = TitleStyle
 
 	return &MainMenu{
-		list: l,
+		list:   l,
+		footer: mainMenuFooter(),
 	}
 }
 
@@ -82,7 +89,7 @@ func (m MainMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.list.SetWidth(msg.Width)
-		m.list.SetHeight(msg.Height - 4) // 减去标题和状态栏的高度
+		m.list.SetHeight(msg.Height - 4 - m.footerHeight()) // 减去标题、状态栏和底部信息的高度
 		return m, nil
 
 	case tea.KeyMsg:
@@ -105,7 +112,7 @@ func (m MainMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, nil
 					}
 					// 传递当前窗口大小给新模型
-					width, height := m.list.Width(), m.list.Height()+4
+					width, height := m.list.Width(), m.list.Height()+4+m.footerHeight()
 					if width > 0 && height > 4 {
 						updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
 						return updatedModel, nil
@@ -127,5 +134,16 @@ func (m MainMenu) View() string {
 		return "再见！"
 	}
 
-	return m.list.View()
+	if m.footer == "" {
+		return m.list.View()
+	}
+	return m.list.View() + "\n" + RenderText(m.footer)
+}
+
+// footerHeight 返回底部信息占用的行数
+func (m MainMenu) footerHeight() int {
+	if m.footer == "" {
+		return 0
+	}
+	return 1
 }
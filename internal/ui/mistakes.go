@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/ajilisiwei/mllt-cli/internal/mistakes"
+)
+
+// mistakeSessionLimit 错题本一次练习的最大条目数
+const mistakeSessionLimit = 50
+
+// NewMistakeSession 创建错题本练习会话：按近期错误率从高到低练习当前类型下最薄弱的条目
+func NewMistakeSession(resourceType string) *PracticeSession {
+	weak, err := mistakes.WeakestItems(resourceType, mistakeSessionLimit)
+
+	marked := markedItemSet(resourceType)
+	items := make([]string, 0, len(weak))
+	sources := make([]itemSource, 0, len(weak))
+	for _, entry := range weak {
+		if _, skip := marked[entry.Item]; skip {
+			continue
+		}
+		items = append(items, entry.Item)
+		sources = append(sources, itemSource{resourceType: resourceType, fileName: entry.FileName})
+	}
+
+	practiceOrder := sequentialOrder(len(items))
+
+	session := newSessionModel(resourceType, mistakes.ListName, items, practiceOrder, "sequential")
+	session.displayFileName = mistakes.ListName
+	session.sources = sources
+
+	if len(items) == 0 {
+		session.result = "错题本为空，近期没有答错的内容。按 Enter 或 Esc 返回练习菜单。"
+		if err != nil {
+			session.result = fmt.Sprintf("读取错题记录失败: %v。按 Enter 或 Esc 返回练习菜单。", err)
+		}
+	}
+
+	return session
+}
+
+// mistakeMenuDescription 返回资源选择菜单中错题本的说明
+func mistakeMenuDescription(resourceType string) string {
+	weak, err := mistakes.WeakestItems(resourceType, 0)
+	if err != nil || len(weak) == 0 {
+		return "近期没有答错的内容"
+	}
+	return fmt.Sprintf("%d 个近期答错的条目，按错误率从高到低练习", len(weak))
+}
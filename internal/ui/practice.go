@@ -2,10 +2,14 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbletea"
+	"github.com/ajilisiwei/mllt-cli/internal/course"
+	"github.com/ajilisiwei/mllt-cli/internal/mistakes"
 	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/resume"
 )
 
 // PracticeMenu 练习菜单模型
@@ -37,6 +41,16 @@ func NewPracticeMenu() *PracticeMenu {
 			description: "进行文章打字练习",
 			action:      func() (tea.Model, error) { return NewResourceSelectionMenu(practice.Articles), nil },
 		},
+		MenuItem{
+			title:       "单词选择题",
+			description: "看单词选择正确的释义，按 1-4 作答",
+			action:      func() (tea.Model, error) { return NewChoiceSelectionMenu(practice.Words), nil },
+		},
+		MenuItem{
+			title:       "短语选择题",
+			description: "看短语选择正确的释义，按 1-4 作答",
+			action:      func() (tea.Model, error) { return NewChoiceSelectionMenu(practice.Phrases), nil },
+		},
 		MenuItem{
 			title:       "返回主菜单",
 			description: "返回到主菜单",
@@ -121,11 +135,21 @@ type ResourceSelectionMenu struct {
 	list         list.Model
 	resourceType string
 	folders      []practice.ResourceFolder
+	choice       bool // 选择题练习的文件夹列表
 	quitting     bool
 }
 
 // 创建新的资源选择菜单
 func NewResourceSelectionMenu(resourceType string) *ResourceSelectionMenu {
+	return newResourceSelectionMenu(resourceType, false)
+}
+
+// NewChoiceSelectionMenu 创建选择题练习的文件夹列表
+func NewChoiceSelectionMenu(resourceType string) *ResourceSelectionMenu {
+	return newResourceSelectionMenu(resourceType, true)
+}
+
+func newResourceSelectionMenu(resourceType string, choice bool) *ResourceSelectionMenu {
 	folders, err := practice.GetResourceFolders(resourceType)
 	if err != nil {
 		folders = []practice.ResourceFolder{}
@@ -134,7 +158,19 @@ func NewResourceSelectionMenu(resourceType string) *ResourceSelectionMenu {
 	items := []list.Item{}
 	for _, folder := range folders {
 		folderCopy := folder
-		items = append(items, ResourceFolderItem{folder: folderCopy})
+		item := ResourceFolderItem{folder: folderCopy}
+		if c, err := course.Load(resourceType, folder); err == nil && c != nil {
+			item.course = c
+		}
+		items = append(items, item)
+	}
+
+	if resourceType != practice.Articles && !choice {
+		items = append(items, MenuItem{
+			title:       mistakes.ListName,
+			description: mistakeMenuDescription(resourceType),
+			action:      func() (tea.Model, error) { return NewMistakeSession(resourceType), nil },
+		})
 	}
 
 	items = append(items, MenuItem{
@@ -145,6 +181,9 @@ func NewResourceSelectionMenu(resourceType string) *ResourceSelectionMenu {
 
 	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
 	l.Title = getResourceTypeTitle(resourceType) + "文件夹"
+	if choice {
+		l.Title = getResourceTypeTitle(resourceType) + "选择题 - 文件夹"
+	}
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.Styles.Title = TitleStyle
@@ -153,12 +192,14 @@ func NewResourceSelectionMenu(resourceType string) *ResourceSelectionMenu {
 		list:         l,
 		resourceType: resourceType,
 		folders:      folders,
+		choice:       choice,
 	}
 }
 
 // ResourceFolderItem 表示一个文件夹条目
 type ResourceFolderItem struct {
 	folder practice.ResourceFolder
+	course *course.Course // 文件夹带课程清单时的课程进度
 }
 
 func (i ResourceFolderItem) Title() string {
@@ -166,6 +207,9 @@ func (i ResourceFolderItem) Title() string {
 }
 
 func (i ResourceFolderItem) Description() string {
+	if i.course != nil {
+		return courseFolderDescription(i.course)
+	}
 	return fmt.Sprintf("包含 %d 个资源", len(i.folder.Files))
 }
 
@@ -220,7 +264,7 @@ func (m ResourceSelectionMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			switch selected := m.list.SelectedItem().(type) {
 			case ResourceFolderItem:
-				filesMenu := NewResourceFilesMenu(m.resourceType, selected.folder)
+				filesMenu := newResourceFilesMenu(m.resourceType, selected.folder, m.choice)
 				width, height := m.list.Width(), m.list.Height()+4
 				if width > 0 && height > 4 {
 					updatedModel, _ := filesMenu.Update(tea.WindowSizeMsg{Width: width, Height: height})
@@ -263,11 +307,17 @@ type ResourceFilesMenu struct {
 	list         list.Model
 	resourceType string
 	folder       practice.ResourceFolder
+	choice       bool // 选择题练习的文件列表
 	quitting     bool
 }
 
 // NewResourceFilesMenu 创建文件列表菜单
 func NewResourceFilesMenu(resourceType string, folder practice.ResourceFolder) *ResourceFilesMenu {
+	return newResourceFilesMenu(resourceType, folder, false)
+}
+
+// newResourceFilesMenu 创建文件列表菜单。选择题练习不显示断点和课程进度，选中文件后进入选择题。
+func newResourceFilesMenu(resourceType string, folder practice.ResourceFolder, choice bool) *ResourceFilesMenu {
 	items := make([]list.Item, 0, len(folder.Files)+1)
 
 	if len(folder.Files) == 0 {
@@ -277,18 +327,40 @@ func NewResourceFilesMenu(resourceType string, folder practice.ResourceFolder) *
 			action:      nil,
 		})
 	} else {
-		for _, file := range folder.Files {
+		// 带课程清单的文件夹按课程顺序排列，并标记每一课的状态
+		files := folder.Files
+		folderCourse, _ := course.Load(resourceType, folder)
+		if folderCourse != nil {
+			files = orderCourseFiles(folderCourse, files)
+		}
+		for _, file := range files {
 			fileName := file
 			identifier := practice.BuildResourceIdentifier(folder.DirName, fileName)
 			display := practice.FormatResourceDisplayName(identifier)
 			itemIdentifier := identifier
-			itemDisplay := display
+			title, description := resourceFileLabels(resourceType, identifier, display)
+			action := func() (tea.Model, error) {
+				return newPracticeEntry(resourceType, folder, itemIdentifier), nil
+			}
+			if choice {
+				action = func() (tea.Model, error) {
+					return NewChoiceSession(resourceType, itemIdentifier), nil
+				}
+			} else if checkpoint, err := resume.Load(resourceType, identifier); err == nil && checkpoint != nil {
+				description += " · 可" + checkpointPosition(resourceType, checkpoint)
+			}
+			if folderCourse != nil {
+				if lesson := folderCourse.Lesson(fileName); lesson != nil {
+					title, description = lessonLabels(lesson, title, description)
+					if lesson.Status == course.LessonLocked {
+						action = nil
+					}
+				}
+			}
 			items = append(items, MenuItem{
-				title:       itemDisplay,
-				description: "练习 " + itemDisplay,
-				action: func() (tea.Model, error) {
-					return NewPracticeSession(resourceType, itemIdentifier), nil
-				},
+				title:       title,
+				description: description,
+				action:      action,
 			})
 		}
 	}
@@ -297,12 +369,15 @@ func NewResourceFilesMenu(resourceType string, folder practice.ResourceFolder) *
 		title:       "返回文件夹列表",
 		description: "返回上一层",
 		action: func() (tea.Model, error) {
-			return NewResourceSelectionMenu(resourceType), nil
+			return newResourceSelectionMenu(resourceType, choice), nil
 		},
 	})
 
 	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
 	l.Title = fmt.Sprintf("%s - %s", getResourceTypeTitle(resourceType), folder.DisplayName)
+	if choice {
+		l.Title = fmt.Sprintf("%s选择题 - %s", getResourceTypeTitle(resourceType), folder.DisplayName)
+	}
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.Styles.Title = TitleStyle
@@ -311,7 +386,30 @@ func NewResourceFilesMenu(resourceType string, folder practice.ResourceFolder) *
 		list:         l,
 		resourceType: resourceType,
 		folder:       folder,
+		choice:       choice,
+	}
+}
+
+// resourceFileLabels 返回资源在列表中的标题和描述，文件头声明了标题、等级或语言时一并显示
+func resourceFileLabels(resourceType, identifier, display string) (string, string) {
+	header, err := practice.ReadResourceHeader(resourceType, identifier)
+	if err != nil || header == nil {
+		return display, "练习 " + display
+	}
+
+	title := display
+	if header.Title != "" {
+		title = header.Title
+	}
+	var details []string
+	if header.Level != "" {
+		details = append(details, "等级 "+header.Level)
+	}
+	if header.Language != "" {
+		details = append(details, header.Language)
 	}
+	details = append(details, "练习 "+display)
+	return title, strings.Join(details, " · ")
 }
 
 func (m ResourceFilesMenu) Init() tea.Cmd {
@@ -331,7 +429,7 @@ func (m ResourceFilesMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quitting = true
 			return m, tea.Quit
 		case "esc":
-			selection := NewResourceSelectionMenu(m.resourceType)
+			selection := newResourceSelectionMenu(m.resourceType, m.choice)
 			width, height := m.list.Width(), m.list.Height()+4
 			if width > 0 && height > 4 {
 				updatedModel, _ := selection.Update(tea.WindowSizeMsg{Width: width, Height: height})
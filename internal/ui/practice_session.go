@@ -3,9 +3,9 @@ package ui
 import (
 	"fmt"
 	"math/rand"
-	"regexp"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -14,10 +14,15 @@ import (
 
 	"github.com/ajilisiwei/mllt-cli/internal/bookmark"
 	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/course"
+	"github.com/ajilisiwei/mllt-cli/internal/kana"
+	"github.com/ajilisiwei/mllt-cli/internal/mistakes"
 	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/resume"
 	"github.com/ajilisiwei/mllt-cli/internal/sound"
 	"github.com/ajilisiwei/mllt-cli/internal/srs"
 	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+	"github.com/ajilisiwei/mllt-cli/internal/textnorm"
 )
 
 type commandOption struct {
@@ -32,6 +37,9 @@ var baseCommandOptions = []commandOption{
 	{name: "unmark", description: "取消标记当前内容"},
 	{name: "favorite", description: "收藏当前内容，可在收藏列表中查看"},
 	{name: "unfavorite", description: "取消收藏当前内容"},
+	{name: "hint", description: "默写和填空模式下显示提示：先显示首字母，再显示长度"},
+	{name: "mode", description: "在抄写、默写和填空模式之间切换（填空仅适用于句子和文章）"},
+	{name: "kana", description: "日语练习中开启或关闭罗马字转假名输入"},
 }
 
 func cloneCommandOptions(options []commandOption) []commandOption {
@@ -61,11 +69,20 @@ func sessionCommandOptions(resourceType string) []commandOption {
 		if !bookmark.SupportsMark(resourceType) && (option.name == "mark" || option.name == "unmark") {
 			continue
 		}
+		if option.name == "kana" && !kanaInputAvailable() {
+			continue
+		}
 		options = append(options, option)
 	}
 	return options
 }
 
+// itemSource 记录练习项目所属的资源文件
+type itemSource struct {
+	resourceType string
+	fileName     string
+}
+
 // PracticeSession 练习会话模型
 type PracticeSession struct {
 	resourceType    string
@@ -96,6 +113,34 @@ type PracticeSession struct {
 	srsEnabled       bool
 	srsSchedule      *srs.Schedule
 	statsLogged      bool
+	// 跨文件复习支持
+	sources   []itemSource                 // 每个项目所属的资源文件，与 items 一一对应
+	schedules map[itemSource]*srs.Schedule // 各资源文件的记忆计划
+	// 复习评分支持
+	itemStartTime     time.Time               // 当前项目开始作答的时间
+	itemWrongAttempts int                     // 当前项目答对前的错误次数
+	awaitingGrade     bool                    // 是否等待用户手动评分
+	pendingGradeItem  string                  // 等待评分的项目
+	suggestedGrade    srs.Grade               // 根据作答情况推断的评分
+	lastGrade         srs.Grade               // 上一题记录的评分
+	typing            *typingTracker          // 按键级打字统计
+	goalBase          statistics.GoalProgress // 本次练习开始前今天已完成的目标进度
+	// 默写模式支持
+	practiceMode string // 练习模式：copy、dictation 或 cloze
+	hintLevel    int    // 当前项目已显示的提示等级
+	// 填空模式支持：当前项目挖去的单词及已填对的空
+	clozeBlanks     []practice.ClozeBlank
+	clozeFilled     []bool
+	clozeVocabulary map[string]bool // 从词表中选择挖空单词时使用的单词集合
+	clozeRand       *rand.Rand
+	// 反向练习支持：看原文输入翻译
+	reverse bool
+	// 日语假名输入支持：罗马字实时转换为假名
+	kanaInput bool
+	// 断点续练支持：继续练习时之前已完成的进度
+	resumeBase resume.Checkpoint
+	// 课程模式支持：完成课程中的一课后的结果
+	courseOutcome *course.Outcome
 	// v0.5 新增：命令模式支持
 	commandOptions          []commandOption
 	filteredCommands        []commandOption
@@ -110,6 +155,36 @@ type PracticeSession struct {
 
 // 创建新的练习会话
 func NewPracticeSession(resourceType, fileName string) *PracticeSession {
+	normalizedItems := readPracticeItems(resourceType, fileName)
+	practiceOrder, orderMode, schedule := practiceOrderFor(resourceType, fileName, normalizedItems)
+
+	session := newSessionModel(resourceType, fileName, normalizedItems, practiceOrder, orderMode)
+	session.srsEnabled = schedule != nil
+	session.srsSchedule = schedule
+
+	if len(normalizedItems) == 0 {
+		session.result = emptyListMessage(fileName)
+	}
+
+	return session
+}
+
+// SessionOptions 只作用于本次练习的选项，不写入配置
+type SessionOptions struct {
+	Reverse bool // 反向练习（看原文输入翻译），为 false 时按配置的练习方向
+}
+
+// apply 将选项应用到练习界面，文章等不支持反向练习的资源保持原方向
+func (o SessionOptions) apply(model tea.Model) tea.Model {
+	if session, ok := model.(*PracticeSession); ok && o.Reverse && practice.SupportsReverse(session.resourceType) {
+		session.reverse = true
+		session.prepareCloze()
+	}
+	return model
+}
+
+// readPracticeItems 读取资源文件中需要练习的项目，去掉空行，并过滤已标记或收藏的内容（特殊列表除外）
+func readPracticeItems(resourceType, fileName string) []string {
 	items, err := practice.ReadResourceFile(resourceType, fileName)
 	if err != nil {
 		items = []string{}
@@ -123,49 +198,44 @@ func NewPracticeSession(resourceType, fileName string) *PracticeSession {
 		}
 	}
 
-	// 过滤已标记或收藏的内容（特殊列表除外）
 	if !bookmark.IsSpecialList(fileName) && len(normalizedItems) > 0 {
 		normalizedItems = filterExcludedItems(resourceType, normalizedItems)
 	}
+	return normalizedItems
+}
 
-	// 创建文本输入
-	ti := textinput.New()
-	ti.Placeholder = "输入这里..."
-	ti.Prompt = ""
-	ti.Focus()
-	ti.Width = 40
-
-	// 创建进度条
-	p := progress.New(progress.WithDefaultGradient())
-
-	// 初始化练习顺序
-	practiceOrder := make([]int, len(normalizedItems))
-	for i := range practiceOrder {
-		practiceOrder[i] = i
+// sequentialOrder 返回按原顺序练习 n 个项目的练习顺序
+func sequentialOrder(n int) []int {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
 	}
+	return order
+}
+
+// practiceOrderFor 按配置的顺序模式确定项目的练习顺序。
+// 艾宾浩斯模式下按 SRS 数据排序并返回对应的计划，加载失败时改为顺序练习；文章总是按原顺序练习。
+func practiceOrderFor(resourceType, fileName string, items []string) ([]int, string, *srs.Schedule) {
+	practiceOrder := sequentialOrder(len(items))
 
-	orderMode := strings.ToLower(config.AppConfig.NextOneOrder)
+	orderMode := strings.ToLower(config.AppConfig.OrderFor(resourceType))
 	if orderMode == "" {
 		orderMode = "random"
 	}
 
 	var schedule *srs.Schedule
-	srsEnabled := false
-
 	if len(practiceOrder) > 0 && resourceType != practice.Articles && orderMode == "ebbinghaus" {
-		if sch, err := srs.Load(resourceType, fileName, normalizedItems); err == nil {
-			ordered := sch.Order(normalizedItems)
-			if len(ordered) == len(practiceOrder) {
+		if sch, err := srs.Load(resourceType, fileName, items); err == nil {
+			if ordered := sch.Order(items); len(ordered) == len(practiceOrder) {
 				practiceOrder = ordered
 				schedule = sch
-				srsEnabled = true
 			}
 		} else {
 			orderMode = "sequential"
 		}
 	}
 
-	if !srsEnabled && len(practiceOrder) > 1 && resourceType != practice.Articles {
+	if schedule == nil && len(practiceOrder) > 1 && resourceType != practice.Articles {
 		switch orderMode {
 		case "random":
 			rand.Seed(time.Now().UnixNano())
@@ -178,25 +248,43 @@ func NewPracticeSession(resourceType, fileName string) *PracticeSession {
 			orderMode = "sequential"
 		}
 	}
+	return practiceOrder, orderMode, schedule
+}
+
+// newSessionModel 按给定的项目和练习顺序创建会话模型
+func newSessionModel(resourceType, fileName string, items []string, practiceOrder []int, orderMode string) *PracticeSession {
+	// 创建文本输入
+	ti := textinput.New()
+	ti.Placeholder = "输入这里..."
+	ti.Prompt = ""
+	ti.Focus()
+	ti.Width = 40
+
+	// 创建进度条
+	p := progress.New(progress.WithDefaultGradient())
 
 	sessionOptions := sessionCommandOptions(resourceType)
 
 	session := &PracticeSession{
 		resourceType:            resourceType,
 		fileName:                fileName,
-		items:                   normalizedItems,
+		items:                   items,
 		currentIndex:            0,
 		textInput:               ti,
 		progress:                p,
 		startTime:               time.Now(),
+		itemStartTime:           time.Now(),
 		orderMode:               orderMode,
 		state:                   "practicing",
 		practiceOrder:           practiceOrder,
 		completedCount:          0,
 		initialItemCount:        len(practiceOrder),
-		srsEnabled:              srsEnabled,
-		srsSchedule:             schedule,
 		displayFileName:         practice.FormatResourceDisplayName(fileName),
+		typing:                  newTypingTracker(config.AppConfig.MatchModeFor(resourceType) == "word_match"),
+		goalBase:                loadTodayGoalProgress(),
+		practiceMode:            normalizePracticeMode(config.AppConfig.PracticeMode),
+		reverse:                 practice.SupportsReverse(resourceType) && practice.IsReverse(),
+		kanaInput:               kanaInputAvailable() && config.AppConfig.CurrentProfile().KanaInput,
 		commandOptions:          sessionOptions,
 		filteredCommands:        cloneCommandOptions(sessionOptions),
 		selectedCommandIndex:    0,
@@ -206,24 +294,22 @@ func NewPracticeSession(resourceType, fileName string) *PracticeSession {
 		inputDefaultCursorStyle: ti.CursorStyle,
 	}
 
-	if len(normalizedItems) == 0 {
+	if len(items) == 0 {
 		session.state = "finished"
 		session.endTime = time.Now()
-		session.result = emptyListMessage(fileName)
 	}
+	// 单词和短语不支持填空，按抄写练习
+	if session.practiceMode == practiceModeCloze && !practice.SupportsCloze(resourceType) {
+		session.practiceMode = practiceModeCopy
+	}
+	session.prepareCloze()
 
 	return session
 }
 
 func filterExcludedItems(resourceType string, items []string) []string {
 	filtered := make([]string, 0, len(items))
-
-	markedSet := make(map[string]struct{})
-	if marked, err := bookmark.GetItems(resourceType, bookmark.MarkedList); err == nil {
-		for _, item := range marked {
-			markedSet[item] = struct{}{}
-		}
-	}
+	markedSet := markedItemSet(resourceType)
 
 	for _, item := range items {
 		trimmed := strings.TrimSpace(item)
@@ -239,6 +325,17 @@ func filterExcludedItems(resourceType string, items []string) []string {
 	return filtered
 }
 
+// markedItemSet 返回指定资源类型下已标记内容的集合
+func markedItemSet(resourceType string) map[string]struct{} {
+	markedSet := make(map[string]struct{})
+	if marked, err := bookmark.GetItems(resourceType, bookmark.MarkedList); err == nil {
+		for _, item := range marked {
+			markedSet[item] = struct{}{}
+		}
+	}
+	return markedSet
+}
+
 func emptyListMessage(fileName string) string {
 	if bookmark.IsSpecialList(fileName) {
 		return fmt.Sprintf("当前\"%s\"列表为空。按 Enter 或 Esc 返回练习菜单。", fileName)
@@ -264,30 +361,27 @@ func (m *PracticeSession) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		key := typedMsg.String()
 		m.playKeyboardSound(typedMsg)
+		if m.awaitingGrade && key != "ctrl+c" && key != "esc" {
+			return m.handleGradeKey(key)
+		}
 		switch key {
 		case "ctrl+c":
 			m.quitting = true
 			sound.StopAllSounds()
+			m.flushPendingReview()
 			m.logStatistics(false)
+			m.saveCheckpoint()
 			return m, tea.Quit
 		case "esc":
 			sound.StopAllSounds()
+			m.flushPendingReview()
 			m.logStatistics(false)
-			practiceMenu := NewPracticeMenu()
-			if m.width > 0 && m.height > 4 {
-				updatedModel, _ := practiceMenu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
-				return updatedModel, nil
-			}
-			return practiceMenu, nil
+			m.saveCheckpoint()
+			return m.exitModel(), nil
 		case "enter":
 			if m.state == "finished" {
 				sound.StopAllSounds()
-				practiceMenu := NewPracticeMenu()
-				if m.width > 0 && m.height > 4 {
-					updatedModel, _ := practiceMenu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
-					return updatedModel, nil
-				}
-				return practiceMenu, nil
+				return m.exitModel(), nil
 			}
 
 			trimmed := strings.TrimSpace(m.textInput.Value())
@@ -321,15 +415,40 @@ func (m *PracticeSession) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	var cmd tea.Cmd
+	before := m.textInput.Value()
 	m.textInput, cmd = m.textInput.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok && m.state == "practicing" {
+		if m.kanaInput {
+			// 假名输入时按键是罗马字，无法与答案逐字比较，只转换不统计按键
+			m.convertKanaInput()
+		} else {
+			m.typing.observe(before, m.textInput.Value(), m.getExpectedInput(m.getCurrentRawItem()), time.Now())
+		}
+	}
 	m.updateCommandDropdown()
 
 	return m, cmd
 }
 
+// exitModel 返回退出练习后要进入的菜单：今日复习回到主菜单，其余回到练习菜单
+func (m *PracticeSession) exitModel() tea.Model {
+	var menu tea.Model = NewPracticeMenu()
+	if m.isReview() {
+		menu = NewMainMenu()
+	}
+	if m.width > 0 && m.height > 4 {
+		updatedModel, _ := menu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+		return updatedModel
+	}
+	return menu
+}
+
 func (m *PracticeSession) handleAnswerSubmission() (tea.Model, tea.Cmd) {
 	m.setCommandFeedback("", false)
 	value := m.textInput.Value()
+	if m.kanaInput {
+		value = kana.Finalize(value)
+	}
 	userInput := strings.TrimSpace(value)
 	if strings.Contains(userInput, " ->> ") {
 		parts := strings.Split(userInput, " ->> ")
@@ -341,17 +460,42 @@ func (m *PracticeSession) handleAnswerSubmission() (tea.Model, tea.Cmd) {
 	originalItem := m.getCurrentRawItem()
 	expectedInput := m.getExpectedInput(originalItem)
 
-	isCorrect := m.isInputCorrect(userInput, expectedInput)
-	m.recordSpacedRepetition(originalItem, isCorrect)
+	// 填空模式按空计分，每个空单独计入答对或答错
+	cloze := m.hasClozeBlanks()
+	var isCorrect bool
+	if cloze {
+		isCorrect = m.scoreClozeAnswer(userInput)
+	} else {
+		isCorrect = m.isInputCorrect(userInput, expectedInput)
+	}
 
 	if isCorrect {
-		m.correct++
+		if !cloze {
+			m.correct++
+		}
+		m.typing.commit(expectedInput, time.Now())
+		m.recordMistakeSuccess(originalItem)
 		m.clearErrorState()
 		m.textInput.SetValue("")
 		m.updateCommandDropdown()
+
+		grade := m.capGradeForHints(srs.DeriveGrade(m.itemWrongAttempts, time.Since(m.itemStartTime), utf8.RuneCountInString(expectedInput)))
+		if m.srsEnabled && config.AppConfig.SRSGradePrompt {
+			// 等待用户确认或修改评分后再进入下一项
+			m.awaitingGrade = true
+			m.pendingGradeItem = originalItem
+			m.suggestedGrade = grade
+			return m, nil
+		}
+
+		m.recordSpacedRepetition(originalItem, grade)
 		m.advanceToNextItem()
 	} else {
-		m.incorrect++
+		if !cloze {
+			m.incorrect++
+		}
+		m.itemWrongAttempts++
+		m.recordMistake(originalItem, expectedInput, userInput)
 		m.lastInputWrong = true
 		m.wrongInput = userInput
 		m.expectedText = expectedInput
@@ -362,6 +506,45 @@ func (m *PracticeSession) handleAnswerSubmission() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleGradeKey 处理答对后的手动评分按键：1~4 选择评分，Enter 采用建议评分
+func (m *PracticeSession) handleGradeKey(key string) (tea.Model, tea.Cmd) {
+	grade := srs.Grade(0)
+	switch key {
+	case "1":
+		grade = srs.GradeAgain
+	case "2":
+		grade = srs.GradeHard
+	case "3":
+		grade = srs.GradeGood
+	case "4":
+		grade = srs.GradeEasy
+	case "enter":
+		grade = m.suggestedGrade
+	default:
+		return m, nil
+	}
+
+	m.awaitingGrade = false
+	m.recordSpacedRepetition(m.pendingGradeItem, grade)
+	m.pendingGradeItem = ""
+	m.advanceToNextItem()
+	return m, nil
+}
+
+// flushPendingReview 在中途退出时记录尚未写入的复习结果
+func (m *PracticeSession) flushPendingReview() {
+	if m.awaitingGrade {
+		m.awaitingGrade = false
+		m.recordSpacedRepetition(m.pendingGradeItem, m.suggestedGrade)
+		m.pendingGradeItem = ""
+		return
+	}
+	if m.itemWrongAttempts > 0 {
+		m.recordSpacedRepetition(m.getCurrentRawItem(), srs.GradeAgain)
+		m.itemWrongAttempts = 0
+	}
+}
+
 func (m *PracticeSession) handleCommand(raw string) (tea.Model, tea.Cmd) {
 	defer m.resetCommandInput()
 
@@ -382,12 +565,9 @@ func (m *PracticeSession) handleCommand(raw string) (tea.Model, tea.Cmd) {
 	switch commandName {
 	case "exit":
 		sound.StopAllSounds()
-		practiceMenu := NewPracticeMenu()
-		if m.width > 0 && m.height > 4 {
-			updatedModel, _ := practiceMenu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
-			return updatedModel, nil
-		}
-		return practiceMenu, nil
+		m.flushPendingReview()
+		m.saveCheckpoint()
+		return m.exitModel(), nil
 	case "help":
 		m.setCommandFeedback(m.renderCommandHelpDetail(), false)
 		return m, nil
@@ -399,6 +579,12 @@ func (m *PracticeSession) handleCommand(raw string) (tea.Model, tea.Cmd) {
 		return m.handleFavoriteCommand()
 	case "unfavorite":
 		return m.handleUnfavoriteCommand()
+	case "hint":
+		return m.handleHintCommand()
+	case "mode":
+		return m.handleModeCommand()
+	case "kana":
+		return m.handleKanaCommand()
 	default:
 		m.setCommandFeedback(fmt.Sprintf("未知命令: %s", commandText), true)
 		return m, nil
@@ -406,7 +592,7 @@ func (m *PracticeSession) handleCommand(raw string) (tea.Model, tea.Cmd) {
 }
 
 func (m *PracticeSession) handleMarkCommand() (tea.Model, tea.Cmd) {
-	if !bookmark.SupportsMark(m.resourceType) {
+	if !bookmark.SupportsMark(m.currentResourceType()) {
 		m.setCommandFeedback("当前资源类型不支持标记功能。", true)
 		return m, nil
 	}
@@ -417,7 +603,7 @@ func (m *PracticeSession) handleMarkCommand() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	added, err := bookmark.Add(m.resourceType, bookmark.MarkedList, item)
+	added, err := bookmark.Add(m.currentResourceType(), bookmark.MarkedList, item)
 	if err != nil {
 		m.setCommandFeedback(fmt.Sprintf("标记失败: %v", err), true)
 		return m, nil
@@ -435,7 +621,7 @@ func (m *PracticeSession) handleMarkCommand() (tea.Model, tea.Cmd) {
 }
 
 func (m *PracticeSession) handleUnmarkCommand() (tea.Model, tea.Cmd) {
-	if !bookmark.SupportsMark(m.resourceType) {
+	if !bookmark.SupportsMark(m.currentResourceType()) {
 		m.setCommandFeedback("当前资源类型不支持取消标记。", true)
 		return m, nil
 	}
@@ -446,7 +632,7 @@ func (m *PracticeSession) handleUnmarkCommand() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	removed, err := bookmark.Remove(m.resourceType, bookmark.MarkedList, item)
+	removed, err := bookmark.Remove(m.currentResourceType(), bookmark.MarkedList, item)
 	if err != nil {
 		m.setCommandFeedback(fmt.Sprintf("取消标记失败: %v", err), true)
 		return m, nil
@@ -474,7 +660,7 @@ func (m *PracticeSession) handleFavoriteCommand() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	added, err := bookmark.Add(m.resourceType, bookmark.FavoriteList, item)
+	added, err := bookmark.Add(m.currentResourceType(), bookmark.FavoriteList, item)
 	if err != nil {
 		m.setCommandFeedback(fmt.Sprintf("收藏失败: %v", err), true)
 		return m, nil
@@ -498,7 +684,7 @@ func (m *PracticeSession) handleUnfavoriteCommand() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	removed, err := bookmark.Remove(m.resourceType, bookmark.FavoriteList, item)
+	removed, err := bookmark.Remove(m.currentResourceType(), bookmark.FavoriteList, item)
 	if err != nil {
 		m.setCommandFeedback(fmt.Sprintf("取消收藏失败: %v", err), true)
 		return m, nil
@@ -609,18 +795,74 @@ func (m *PracticeSession) clearErrorState() {
 	m.expectedText = ""
 }
 
-func (m *PracticeSession) recordSpacedRepetition(item string, correct bool) {
-	if !m.srsEnabled || m.srsSchedule == nil || item == "" {
+func (m *PracticeSession) recordSpacedRepetition(item string, grade srs.Grade) {
+	schedule := m.currentSchedule()
+	if !m.srsEnabled || schedule == nil || item == "" {
 		return
 	}
-	_ = m.srsSchedule.RecordResult(item, correct)
+	if err := schedule.RecordGrade(item, grade); err == nil {
+		m.lastGrade = grade
+	}
 }
 
 func (m *PracticeSession) removeItemFromSRS(item string) {
-	if !m.srsEnabled || m.srsSchedule == nil || item == "" {
+	schedule := m.currentSchedule()
+	if !m.srsEnabled || schedule == nil || item == "" {
+		return
+	}
+	_ = schedule.RemoveItem(item)
+}
+
+// recordMistake 将答错的输入写入错题记录，文章不记录
+func (m *PracticeSession) recordMistake(item, expected, typed string) {
+	source := m.currentSource()
+	if item == "" || source.resourceType == practice.Articles {
+		return
+	}
+	_ = mistakes.RecordMistake(source.resourceType, source.fileName, item, expected, typed)
+}
+
+// recordMistakeSuccess 记录错题的一次答对，用于计算错误率
+func (m *PracticeSession) recordMistakeSuccess(item string) {
+	source := m.currentSource()
+	if item == "" || source.resourceType == practice.Articles {
 		return
 	}
-	_ = m.srsSchedule.RemoveItem(item)
+	_ = mistakes.RecordSuccess(source.resourceType, source.fileName, item)
+}
+
+// currentSource 返回当前项目所属的资源文件
+func (m PracticeSession) currentSource() itemSource {
+	if m.completedCount >= 0 && m.completedCount < len(m.practiceOrder) {
+		actualIndex := m.practiceOrder[m.completedCount]
+		if actualIndex >= 0 && actualIndex < len(m.sources) {
+			return m.sources[actualIndex]
+		}
+	}
+	return itemSource{resourceType: m.resourceType, fileName: m.fileName}
+}
+
+// isCrossFile 判断会话中的项目是否来自多个资源文件（今日复习、错题本）
+func (m PracticeSession) isCrossFile() bool {
+	return m.sources != nil
+}
+
+// isReview 判断是否为今日复习会话
+func (m PracticeSession) isReview() bool {
+	return m.resourceType == reviewResourceType
+}
+
+// currentResourceType 返回当前项目的资源类型，跨文件复习时按项目来源区分
+func (m PracticeSession) currentResourceType() string {
+	return m.currentSource().resourceType
+}
+
+// currentSchedule 返回当前项目对应的记忆计划
+func (m PracticeSession) currentSchedule() *srs.Schedule {
+	if m.schedules != nil {
+		return m.schedules[m.currentSource()]
+	}
+	return m.srsSchedule
 }
 
 func (m *PracticeSession) removeCurrentItemFromSession(item string) {
@@ -635,12 +877,13 @@ func (m *PracticeSession) removeCurrentItemFromSession(item string) {
 	}
 
 	actualIndex := m.practiceOrder[m.completedCount]
-	if m.srsEnabled && item != "" {
-		_ = m.srsSchedule.RemoveItem(item)
-	}
+	m.removeItemFromSRS(item)
 	if actualIndex >= 0 && actualIndex < len(m.items) {
 		m.items = append(m.items[:actualIndex], m.items[actualIndex+1:]...)
 	}
+	if actualIndex >= 0 && actualIndex < len(m.sources) {
+		m.sources = append(m.sources[:actualIndex], m.sources[actualIndex+1:]...)
+	}
 
 	m.practiceOrder = append(m.practiceOrder[:m.completedCount], m.practiceOrder[m.completedCount+1:]...)
 	for i := range m.practiceOrder {
@@ -648,6 +891,7 @@ func (m *PracticeSession) removeCurrentItemFromSession(item string) {
 			m.practiceOrder[i]--
 		}
 	}
+	m.resetItemTracking()
 
 	if len(m.practiceOrder) == 0 || m.completedCount >= len(m.practiceOrder) {
 		m.finishSession()
@@ -661,9 +905,22 @@ func (m *PracticeSession) advanceToNextItem() {
 	}
 
 	m.completedCount++
+	m.resetItemTracking()
 	if m.completedCount >= len(m.practiceOrder) {
 		m.finishSession()
+		return
 	}
+	// 每完成一项保存一次进度，终端被直接关闭时也能继续
+	m.saveCheckpoint()
+}
+
+// resetItemTracking 重置当前项目的作答计时与错误次数
+func (m *PracticeSession) resetItemTracking() {
+	m.itemStartTime = time.Now()
+	m.itemWrongAttempts = 0
+	m.hintLevel = hintNone
+	m.typing.skip()
+	m.prepareCloze()
 }
 
 func (m *PracticeSession) finishSession() {
@@ -676,6 +933,7 @@ func (m *PracticeSession) finishSession() {
 	m.result = m.calculateResult()
 	sound.StopAllSounds()
 	m.logStatistics(true)
+	m.clearCheckpoint()
 }
 
 func (m *PracticeSession) logStatistics(completed bool) {
@@ -683,7 +941,10 @@ func (m *PracticeSession) logStatistics(completed bool) {
 		return
 	}
 
-	total := m.correct + m.incorrect
+	// 继续练习时，之前的答题数在上次退出时已经记录过
+	correct := m.correct - m.resumeBase.Correct
+	incorrect := m.incorrect - m.resumeBase.Incorrect
+	total := correct + incorrect
 	if total == 0 && !completed {
 		return
 	}
@@ -698,7 +959,7 @@ func (m *PracticeSession) logStatistics(completed bool) {
 
 	accuracy := 0.0
 	if total > 0 {
-		accuracy = float64(m.correct) / float64(total) * 100
+		accuracy = float64(correct) / float64(total) * 100
 	}
 
 	record := statistics.SessionRecord{
@@ -706,12 +967,13 @@ func (m *PracticeSession) logStatistics(completed bool) {
 		ResourceType:    m.resourceType,
 		FileName:        m.fileName,
 		Total:           total,
-		Correct:         m.correct,
-		Incorrect:       m.incorrect,
+		Correct:         correct,
+		Incorrect:       incorrect,
 		Accuracy:        accuracy,
 		DurationSeconds: int64(duration.Seconds()),
 		OrderMode:       m.orderMode,
 		Completed:       completed,
+		Typing:          m.typing.stats(),
 	}
 
 	if err := statistics.LogSession(record); err != nil {
@@ -719,6 +981,14 @@ func (m *PracticeSession) logStatistics(completed bool) {
 		m.commandFeedback = fmt.Sprintf("记录统计数据失败: %v", err)
 		m.commandFeedbackIsError = true
 	}
+	if !m.isCrossFile() && !m.isReview() {
+		outcome, err := course.RecordSession(courseRecord(record, m.resumeBase))
+		if err != nil {
+			m.commandFeedback = fmt.Sprintf("更新课程进度失败: %v", err)
+			m.commandFeedbackIsError = true
+		}
+		m.courseOutcome = outcome
+	}
 
 	m.statsLogged = true
 }
@@ -733,6 +1003,9 @@ func (m PracticeSession) View() string {
 
 	// 标题
 	title := fmt.Sprintf("%s练习 - %s", getResourceTypeTitle(m.resourceType), m.displayFileName)
+	if m.isReview() {
+		title = m.displayFileName
+	}
 	s.WriteString(RenderTitle(title) + "\n\n")
 
 	if m.state == "practicing" {
@@ -752,18 +1025,65 @@ func (m PracticeSession) View() string {
 
 		progressText := fmt.Sprintf("进度: %d/%d", currentPosition, total)
 		s.WriteString(RenderText(progressText) + "\n")
-		s.WriteString(m.progress.ViewAs(progressValue) + "\n\n")
+		s.WriteString(m.progress.ViewAs(progressValue) + "\n")
+		if m.goalBase.Enabled() {
+			goalProgress := m.goalBase.Add(m.correct-m.resumeBase.Correct, time.Since(m.startTime))
+			if goalProgress.Reached() {
+				s.WriteString(RenderSuccess(formatGoalProgress(goalProgress)) + "\n")
+			} else {
+				s.WriteString(RenderText(formatGoalProgress(goalProgress)) + "\n")
+			}
+		}
+		s.WriteString("\n")
 
 		currentItem := m.getCurrentItem()
-		if currentItem != "" {
+		if currentItem != "" && m.isCrossFile() {
+			source := m.currentSource()
+			s.WriteString(RenderText(fmt.Sprintf("来源: %s · %s", getResourceTypeTitle(source.resourceType),
+				practice.FormatResourceDisplayName(source.fileName))) + "\n")
+		}
+		if currentItem != "" && m.hasClozeBlanks() {
+			s.WriteString(m.renderClozePrompt(m.getCurrentRawItem()) + "\n")
+		} else if currentItem != "" && m.isDictation() {
+			s.WriteString(m.renderDictationPrompt(m.getCurrentRawItem()) + "\n")
+		} else if currentItem != "" {
 			s.WriteString(RenderHighlight("当前项目:") + "\n")
-			wrappedText := m.wrapText(currentItem, m.width-4)
-			s.WriteString(RenderText(wrappedText) + "\n\n")
+			if entryView := m.renderCurrentEntry(); entryView != "" {
+				s.WriteString(entryView + "\n")
+			} else {
+				wrappedText := m.wrapText(currentItem, m.width-4)
+				s.WriteString(RenderText(wrappedText) + "\n\n")
+			}
 		} else {
 			s.WriteString(RenderText("暂无可练习内容") + "\n\n")
 		}
 
-		s.WriteString(RenderHighlight("请输入:") + "\n")
+		if m.awaitingGrade {
+			s.WriteString(RenderSuccess("✔ 输入正确！") + "\n")
+			s.WriteString(RenderHighlight("请为本题评分:") + "\n")
+			s.WriteString(RenderText(fmt.Sprintf("1 %s · 2 %s · 3 %s · 4 %s（按 Enter 采用建议：%s）",
+				srs.GradeAgain.Label(), srs.GradeHard.Label(), srs.GradeGood.Label(), srs.GradeEasy.Label(),
+				m.suggestedGrade.Label())) + "\n\n")
+			return s.String()
+		}
+
+		if m.srsEnabled && m.lastGrade.Valid() {
+			s.WriteString(RenderText("上一题评分: "+m.lastGrade.Label()) + "\n\n")
+		}
+
+		if hint := config.AppConfig.CurrentProfile().IMEHint; hint != "" {
+			s.WriteString(RenderText("输入法: "+hint) + "\n")
+		}
+		if m.kanaInput {
+			s.WriteString(RenderText("假名输入: 小写罗马字输入平假名，大写输入片假名，n' 输入\"ん\"") + "\n")
+		}
+		if m.expectsTranslation(m.getCurrentRawItem()) {
+			s.WriteString(RenderHighlight("请输入翻译:") + "\n")
+		} else if m.hasClozeBlanks() {
+			s.WriteString(RenderHighlight(m.clozeInputLabel()) + "\n")
+		} else {
+			s.WriteString(RenderHighlight("请输入:") + "\n")
+		}
 		m.applyInputHighlight()
 		s.WriteString(m.textInput.View() + "\n")
 		dropdown := m.renderCommandDropdown()
@@ -800,7 +1120,12 @@ func (m PracticeSession) View() string {
 			s.WriteString(RenderSuccess("练习完成！") + "\n\n")
 		}
 		s.WriteString(RenderText(m.result) + "\n\n")
-		s.WriteString(RenderText("按 Enter 或 Esc 返回练习菜单") + "\n")
+		s.WriteString(renderCourseOutcome(m.courseOutcome))
+		if m.isReview() {
+			s.WriteString(RenderText("按 Enter 或 Esc 返回主菜单") + "\n")
+		} else {
+			s.WriteString(RenderText("按 Enter 或 Esc 返回练习菜单") + "\n")
+		}
 	}
 
 	return s.String()
@@ -856,7 +1181,8 @@ func (m PracticeSession) renderCommandHelpDetail() string {
 }
 
 func (m *PracticeSession) applyInputHighlight() {
-	if m.state != "practicing" {
+	// 默写模式下不实时提示输入是否偏离原文；反向练习的翻译可能有多种写法，也不实时提示
+	if m.state != "practicing" || m.isDictation() || m.expectsTranslation(m.getCurrentRawItem()) {
 		m.resetInputHighlight()
 		return
 	}
@@ -904,18 +1230,27 @@ func (m *PracticeSession) shouldHighlightInputMismatch(value, expected string) b
 		return false
 	}
 
-	matchMode := strings.ToLower(config.AppConfig.CorrectnessMatchMode)
-	switch matchMode {
-	case "word_match":
-		expectedPrefix := m.normalizeForWordMatch(expected)
-		inputPrefix := m.normalizeForWordMatch(value)
-		if inputPrefix == "" {
-			return false
+	// 假名输入时末尾还未转换的罗马字不参与比较
+	value = m.pendingKanaTrimmed(value)
+	if strings.TrimSpace(value) == "" {
+		return false
+	}
+
+	matchMode := strings.ToLower(m.matchMode())
+	for _, candidate := range m.answerCandidates(expected) {
+		switch matchMode {
+		case "word_match":
+			inputPrefix := m.normalizeForWordMatch(value)
+			if inputPrefix == "" || strings.HasPrefix(m.normalizeForWordMatch(candidate), inputPrefix) {
+				return false
+			}
+		default:
+			if strings.HasPrefix(candidate, value) {
+				return false
+			}
 		}
-		return !strings.HasPrefix(expectedPrefix, inputPrefix)
-	default:
-		return !strings.HasPrefix(expected, value)
 	}
+	return true
 }
 
 // 获取当前项目
@@ -929,7 +1264,8 @@ func (m PracticeSession) getCurrentItem() string {
 	primary = strings.TrimSpace(primary)
 	translation = strings.TrimSpace(translation)
 
-	if !m.getShowTranslationConfig() || translation == "" {
+	// 反向练习时翻译就是答案，不能显示
+	if !m.getShowTranslationConfig() || translation == "" || m.expectsTranslation(item) {
 		if primary != "" {
 			return primary
 		}
@@ -943,6 +1279,34 @@ func (m PracticeSession) getCurrentItem() string {
 	return primary + "\n" + translation
 }
 
+// renderCurrentEntry 显示翻译时按字段分行渲染当前条目：原文、音标、词性和释义、例句。
+// 条目中没有识别出音标、词性或例句时返回空，按原来的方式显示。
+func (m PracticeSession) renderCurrentEntry() string {
+	item := m.getCurrentRawItem()
+	if item == "" || !m.getShowTranslationConfig() || m.expectsTranslation(item) {
+		return ""
+	}
+
+	entry := practice.ParseEntry(item)
+	if entry.Term == "" || (entry.Phonetic == "" && entry.PartOfSpeech == "" && len(entry.Examples) == 0) {
+		return ""
+	}
+
+	width := m.width - 4
+	var s strings.Builder
+	s.WriteString(RenderText(m.wrapText(entry.Term, width)) + "\n")
+	if entry.Phonetic != "" {
+		s.WriteString(PhoneticStyle.Render(entry.Phonetic) + "\n")
+	}
+	if definition := entry.Definition(); definition != "" {
+		s.WriteString(RenderText(m.wrapText(definition, width)) + "\n")
+	}
+	for _, example := range entry.Examples {
+		s.WriteString(ExampleStyle.Render(m.wrapText("例: "+example, width)) + "\n")
+	}
+	return s.String()
+}
+
 func (m PracticeSession) getCurrentRawItem() string {
 	if m.completedCount < 0 || m.completedCount >= len(m.practiceOrder) {
 		return ""
@@ -958,12 +1322,28 @@ func (m PracticeSession) getCurrentRawItem() string {
 
 // 获取翻译显示配置
 func (m PracticeSession) getShowTranslationConfig() bool {
-	// v0.3 优化：使用全局翻译设置
-	return config.AppConfig.ShowTranslation
+	// 按资源类型 > 语言 > 全局的顺序取生效的翻译设置
+	return config.AppConfig.ShowTranslationFor(m.currentResourceType())
+}
+
+// matchMode 返回当前项目生效的正确性匹配模式
+func (m PracticeSession) matchMode() string {
+	return config.AppConfig.MatchModeFor(m.currentResourceType())
 }
 
 // 获取期望输入
 func (m PracticeSession) getExpectedInput(item string) string {
+	// 反向练习时期望输入翻译
+	if m.expectsTranslation(item) {
+		_, translation := practice.ParseLine(item)
+		return strings.TrimSpace(translation)
+	}
+
+	// 填空模式只需输入还没有填对的空
+	if m.hasClozeBlanks() && item == m.getCurrentRawItem() {
+		return strings.Join(m.remainingClozeAnswers(), " ")
+	}
+
 	// 对于所有资源类型，使用ParseLine函数正确解析多种分隔符，只返回正文部分
 	content, _ := practice.ParseLine(item)
 	if content != "" {
@@ -975,36 +1355,48 @@ func (m PracticeSession) getExpectedInput(item string) string {
 // 检查输入是否正确
 func (m PracticeSession) isInputCorrect(userInput, expectedInput string) bool {
 	// 获取匹配模式
-	matchMode := config.AppConfig.CorrectnessMatchMode
+	matchMode := m.matchMode()
 	if matchMode == "" {
 		matchMode = "exact_match" // 默认值
 	}
 
+	// 反向练习时按翻译规则比较，支持多个释义和中日文输入
+	if m.expectsTranslation(m.getCurrentRawItem()) {
+		return practice.MatchTranslation(userInput, expectedInput, matchMode == "exact_match")
+	}
+
+	// 条目同时给出汉字写法和假名读音时，按设置接受其中任一种
+	for _, candidate := range m.answerCandidates(expectedInput) {
+		if m.matchesAnswer(userInput, candidate, matchMode) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnswer 按匹配模式比较输入与一种正确写法
+func (m PracticeSession) matchesAnswer(userInput, expectedInput, matchMode string) bool {
 	switch matchMode {
 	case "exact_match":
 		// 完全匹配
 		return userInput == expectedInput
 	case "word_match":
 		// 单词匹配，忽略大小写和标点符号
-		return m.normalizeForWordMatch(userInput) == m.normalizeForWordMatch(expectedInput)
+		expected := m.normalizeForWordMatch(expectedInput)
+		if expected == "" {
+			// 答案只有标点或符号时，规范化后为空，退回完全匹配，避免任意输入都判为正确
+			return strings.TrimSpace(userInput) == strings.TrimSpace(expectedInput)
+		}
+		return m.normalizeForWordMatch(userInput) == expected
 	default:
 		// 默认使用完全匹配
 		return userInput == expectedInput
 	}
 }
 
-// 标准化文本用于单词匹配
+// 标准化文本用于单词匹配，规范化方式取决于当前语言的配置
 func (m PracticeSession) normalizeForWordMatch(text string) string {
-	// 转换为小写
-	text = strings.ToLower(text)
-	// 移除标点符号，只保留字母、数字和空格
-	reg := regexp.MustCompile(`[^a-zA-Z0-9\s]`)
-	text = reg.ReplaceAllString(text, "")
-	// 移除多余的空格
-	text = strings.TrimSpace(text)
-	reg = regexp.MustCompile(`\s+`)
-	text = reg.ReplaceAllString(text, " ")
-	return text
+	return textnorm.Normalize(text, textnorm.ForLanguage(config.AppConfig.CurrentLanguage))
 }
 
 // 渲染单词级别的错误高亮
@@ -1056,8 +1448,8 @@ func (m PracticeSession) renderWordLevelError() string {
 
 // 计算练习结果
 func (m PracticeSession) calculateResult() string {
-	// 计算练习时间
-	duration := m.endTime.Sub(m.startTime)
+	// 计算练习时间，继续练习时包括之前的用时
+	duration := m.resumeBase.Elapsed() + m.endTime.Sub(m.startTime)
 	durationStr := fmt.Sprintf("%d分%d秒", int(duration.Minutes()), int(duration.Seconds())%60)
 
 	// 计算正确率
@@ -1067,21 +1459,21 @@ func (m PracticeSession) calculateResult() string {
 		accuracy = float64(m.correct) / float64(total) * 100
 	}
 
-	// 计算速度（每分钟字符数）
-	totalChars := 0
-	for _, item := range m.items {
-		expectedInput := m.getExpectedInput(item)
-		totalChars += len(expectedInput)
+	// 计算速度（每分钟字符数），只统计实际答对内容的字符数
+	typing := m.typing.stats()
+	if typing == nil {
+		typing = &statistics.TypingStats{}
 	}
 
 	cpm := 0.0
-	if duration.Minutes() > 0 {
-		cpm = float64(totalChars) / duration.Minutes()
+	if minutes := m.endTime.Sub(m.startTime).Minutes(); minutes > 0 {
+		cpm = float64(typing.CorrectChars) / minutes
 	}
 
 	return fmt.Sprintf(
-		"练习时间: %s\n正确数量: %d\n错误数量: %d\n正确率: %.1f%%\n打字速度: %.1f CPM (每分钟字符数)",
+		"练习时间: %s\n正确数量: %d\n错误数量: %d\n正确率: %.1f%%\n打字速度: %.1f CPM (每分钟字符数)\n净速度: %.1f WPM · 毛速度: %.1f WPM\n按键准确率: %.1f%% · 退格 %d 次",
 		durationStr, m.correct, m.incorrect, accuracy, cpm,
+		typing.NetWPM, typing.GrossWPM, typing.CharAccuracy(), typing.Backspaces,
 	)
 }
 
@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ajilisiwei/mllt-cli/internal/config"
 	"github.com/ajilisiwei/mllt-cli/internal/practice"
@@ -15,6 +17,13 @@ func setupPracticeSessionTest(t *testing.T) {
 	}
 }
 
+// setupSessionTestDir 加载配置并切换到临时目录，练习写入的统计数据、记忆计划和进度都留在该目录中
+func setupSessionTestDir(t *testing.T) {
+	t.Helper()
+	setupPracticeSessionTest(t)
+	t.Chdir(t.TempDir())
+}
+
 // 测试getShowTranslationConfig方法
 func TestGetShowTranslationConfig(t *testing.T) {
 	setupPracticeSessionTest(t)
@@ -227,6 +236,11 @@ func TestNormalizeForWordMatch(t *testing.T) {
 			input:    "I have 5 apples.",
 			expected: "i have 5 apples",
 		},
+		{
+			name:     "保留非拉丁字母",
+			input:    "Café, こんにちは！",
+			expected: "café こんにちは",
+		},
 	}
 
 	for _, tt := range tests {
@@ -357,4 +371,229 @@ func TestGetCurrentItem(t *testing.T) {
 	if result != "" {
 		t.Errorf("超出范围 getCurrentItem() = %v, want empty string", result)
 	}
-}
\ No newline at end of file
+}
+
+// 测试跨文件复习会话按项目来源区分资源类型
+func TestCurrentSourceCrossFile(t *testing.T) {
+	session := &PracticeSession{
+		resourceType:  reviewResourceType,
+		fileName:      reviewFileName,
+		items:         []string{"apple ->> 苹果", "good morning ->> 早上好"},
+		practiceOrder: []int{1, 0},
+		sources: []itemSource{
+			{resourceType: practice.Words, fileName: "fruits"},
+			{resourceType: practice.Phrases, fileName: "greetings"},
+		},
+	}
+
+	if got := session.currentResourceType(); got != practice.Phrases {
+		t.Errorf("第一题应来自短语资源，实际 %s", got)
+	}
+
+	session.completedCount = 1
+	if got := session.currentSource(); got.fileName != "fruits" || got.resourceType != practice.Words {
+		t.Errorf("第二题来源不正确: %+v", got)
+	}
+
+	single := &PracticeSession{resourceType: practice.Words, fileName: "fruits", items: []string{"apple"}, practiceOrder: []int{0}}
+	if single.isCrossFile() || single.currentResourceType() != practice.Words {
+		t.Error("普通练习会话应使用会话自身的资源类型")
+	}
+}
+
+// 测试按键统计：错误字符、退格和净速度
+func TestTypingTrackerObserve(t *testing.T) {
+	tracker := newTypingTracker(false)
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	expected := "cat"
+
+	steps := []string{"c", "co", "c", "ca", "cat"}
+	before := ""
+	for i, after := range steps {
+		tracker.observe(before, after, expected, start.Add(time.Duration(i)*time.Second))
+		before = after
+	}
+	tracker.commit(expected, start.Add(6*time.Second))
+
+	stats := tracker.stats()
+	if stats == nil {
+		t.Fatal("有按键时应生成统计数据")
+	}
+	if stats.Keystrokes != 4 || stats.Backspaces != 1 || stats.WrongChars != 1 {
+		t.Errorf("按键统计不正确: %+v", stats)
+	}
+	if stats.KeyErrors["a"] != 1 {
+		t.Errorf("应记录期望字符 a 输错一次，实际 %v", stats.KeyErrors)
+	}
+	if stats.CorrectChars != 3 || stats.ActiveSeconds != 6 {
+		t.Errorf("正确字符数或用时不正确: %+v", stats)
+	}
+	// 3 个字符 / 5 / 0.1 分钟 = 6 WPM
+	if stats.NetWPM != 6 {
+		t.Errorf("净速度应为 6 WPM，实际 %v", stats.NetWPM)
+	}
+}
+
+// 测试中文内容按字符而非字节统计
+func TestTypingTrackerCountsRunes(t *testing.T) {
+	tracker := newTypingTracker(false)
+	now := time.Now()
+	tracker.observe("", "早上好", "早上好", now)
+	tracker.commit("早上好", now.Add(time.Second))
+
+	stats := tracker.stats()
+	if stats.Keystrokes != 3 || stats.CorrectChars != 3 || stats.WrongChars != 0 {
+		t.Errorf("中文字符统计不正确: %+v", stats)
+	}
+}
+
+// 测试反向练习：期望输入翻译，按翻译规则判断正误
+func TestReversePracticeExpectsTranslation(t *testing.T) {
+	setupPracticeSessionTest(t)
+	config.AppConfig.CorrectnessMatchMode = "word_match"
+
+	session := &PracticeSession{
+		resourceType:  practice.Phrases,
+		items:         []string{"good morning ->> 早上好；早安", "hello"},
+		practiceOrder: []int{0, 1},
+		reverse:       true,
+	}
+
+	if got := session.getExpectedInput(session.getCurrentRawItem()); got != "早上好；早安" {
+		t.Errorf("反向练习应期望输入翻译，实际 %q", got)
+	}
+	if got := session.getCurrentItem(); got != "good morning" {
+		t.Errorf("反向练习不应显示翻译，实际 %q", got)
+	}
+	if !session.isInputCorrect("早安", "早上好；早安") {
+		t.Error("输入任一释义都应判为正确")
+	}
+	if session.isInputCorrect("晚安", "早上好；早安") {
+		t.Error("错误的翻译不应判为正确")
+	}
+
+	// 没有翻译的条目仍输入原文
+	session.completedCount = 1
+	if got := session.getExpectedInput(session.getCurrentRawItem()); got != "hello" {
+		t.Errorf("没有翻译的条目应期望输入原文，实际 %q", got)
+	}
+}
+
+// 测试 --reverse 对应的会话选项只切换本次练习的方向，不修改配置
+func TestSessionOptionsReverse(t *testing.T) {
+	setupSessionTestDir(t)
+	original := config.AppConfig.PracticeDirection
+	defer func() { config.AppConfig.PracticeDirection = original }()
+	config.AppConfig.PracticeDirection = practice.DirectionForward
+
+	items := []string{"good morning ->> 早上好"}
+	session := SessionOptions{Reverse: true}.apply(newSessionModel(practice.Phrases, "greetings", items, []int{0}, "sequential")).(*PracticeSession)
+	if !session.reverse {
+		t.Error("会话选项应开启反向练习")
+	}
+	if config.AppConfig.PracticeDirection != practice.DirectionForward {
+		t.Errorf("会话选项不应修改配置中的练习方向，实际 %q", config.AppConfig.PracticeDirection)
+	}
+
+	article := SessionOptions{Reverse: true}.apply(newSessionModel(practice.Articles, "lesson", items, []int{0}, "sequential")).(*PracticeSession)
+	if article.reverse {
+		t.Error("文章不支持反向练习")
+	}
+}
+
+// 测试显示翻译时按字段分行显示音标、释义和例句
+func TestRenderCurrentEntry(t *testing.T) {
+	setupPracticeSessionTest(t)
+	config.AppConfig.ShowTranslation = true
+	config.AppConfig.Words.ShowTranslation = nil
+
+	session := &PracticeSession{
+		resourceType:  practice.Words,
+		items:         []string{"computer ->> /kəmˈpjuːtər/ n. 计算机 例：I use a computer.", "apple ->> 苹果"},
+		practiceOrder: []int{0, 1},
+		width:         80,
+	}
+
+	view := session.renderCurrentEntry()
+	for _, want := range []string{"computer", "/kəmˈpjuːtər/", "n. 计算机", "例: I use a computer."} {
+		if !strings.Contains(view, want) {
+			t.Errorf("条目显示应包含 %q，实际 %q", want, view)
+		}
+	}
+
+	// 反向练习时翻译是答案，不能显示
+	session.reverse = true
+	if view := session.renderCurrentEntry(); view != "" {
+		t.Errorf("反向练习不应分行显示翻译，实际 %q", view)
+	}
+
+	// 没有音标、词性和例句的条目按原来的方式显示
+	session.reverse = false
+	session.completedCount = 1
+	if view := session.renderCurrentEntry(); view != "" {
+		t.Errorf("普通条目不应分行显示，实际 %q", view)
+	}
+}
+
+// 测试日语在单词匹配模式下不会因规范化后为空而误判为正确
+func TestIsInputCorrectJapaneseWordMatch(t *testing.T) {
+	setupPracticeSessionTest(t)
+	originalLanguage := config.AppConfig.CurrentLanguage
+	defer func() { config.AppConfig.CurrentLanguage = originalLanguage }()
+
+	config.AppConfig.CurrentLanguage = "japanese"
+	config.AppConfig.CorrectnessMatchMode = "word_match"
+	session := &PracticeSession{}
+
+	if session.isInputCorrect("いぬ", "ねこ") {
+		t.Error("不同的日语输入不应判为正确")
+	}
+	if !session.isInputCorrect("ねこ　です", "ねこです。") {
+		t.Error("忽略空格和标点后相同的日语输入应判为正确")
+	}
+	if session.isInputCorrect("x", "……") {
+		t.Error("答案只有标点时应退回完全匹配")
+	}
+}
+
+// 测试假名输入：提交时转换罗马字，并接受汉字写法或假名读音
+func TestKanaInputAcceptsReading(t *testing.T) {
+	setupPracticeSessionTest(t)
+	originalLanguage := config.AppConfig.CurrentLanguage
+	defer func() { config.AppConfig.CurrentLanguage = originalLanguage }()
+
+	config.AppConfig.CurrentLanguage = "japanese"
+	config.AppConfig.CorrectnessMatchMode = "exact_match"
+	session := newSessionModel(practice.Words, "verbs", []string{"食べる（たべる） ->> to eat"}, []int{0}, "sequential")
+	session.kanaInput = true
+
+	session.textInput.SetValue("taberu")
+	session.convertKanaInput()
+	if got := session.textInput.Value(); got != "たべる" {
+		t.Fatalf("罗马字应实时转换为假名，实际 %q", got)
+	}
+
+	// 回到前面修改时，转换后光标停在修改处，不跳到末尾
+	session.textInput.SetValue("たべkaるa")
+	session.textInput.SetCursor(4)
+	session.convertKanaInput()
+	if got, pos := session.textInput.Value(), session.textInput.Position(); got != "たべかるa" || pos != 3 {
+		t.Fatalf("在中间输入后应为 %q、光标 3，实际 %q、光标 %d", "たべかるa", got, pos)
+	}
+
+	expected := session.getExpectedInput(session.getCurrentRawItem())
+	for _, input := range []string{"たべる", "食べる", "食べる（たべる）"} {
+		if !session.isInputCorrect(input, expected) {
+			t.Errorf("假名输入时 %q 应判为正确", input)
+		}
+	}
+	if session.isInputCorrect("たべ", expected) {
+		t.Error("不完整的读音不应判为正确")
+	}
+
+	// 关闭假名输入且未开启 accept_reading 时只接受完整写法
+	session.kanaInput = false
+	if session.isInputCorrect("たべる", expected) {
+		t.Error("未开启接受读音时只输入读音不应判为正确")
+	}
+}
@@ -0,0 +1,394 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+)
+
+// 设置项和来源的中文名称
+var (
+	overrideKeyTitles = map[string]string{
+		config.KeyMatchMode:       "匹配模式",
+		config.KeyOrder:           "练习顺序",
+		config.KeyShowTranslation: "显示翻译",
+		config.KeyKanaInput:       "假名输入",
+		config.KeyAcceptReading:   "接受汉字或读音",
+	}
+	languageToggleDescriptions = map[string]string{
+		config.KeyKanaInput:     "日语练习时将罗马字实时转换为假名，按 Enter 切换",
+		config.KeyAcceptReading: "条目写成\"食べる（たべる）\"时，只输入汉字写法或假名读音也算正确，按 Enter 切换",
+	}
+	settingSourceTitles = map[string]string{
+		config.SourceGlobal:   "全局",
+		config.SourceLanguage: "语言",
+		config.SourceType:     "类型",
+	}
+)
+
+// ProfileMenu 语言与类型设置菜单：选择要设置的语言或资源类型
+type ProfileMenu struct {
+	list     list.Model
+	quitting bool
+}
+
+// ProfileScopeMenuItem 语言与类型设置菜单中的作用范围
+type ProfileScopeMenuItem struct {
+	scope       string
+	title       string
+	description string
+}
+
+// 实现list.Item接口
+func (i ProfileScopeMenuItem) Title() string       { return i.title }
+func (i ProfileScopeMenuItem) Description() string { return i.description }
+func (i ProfileScopeMenuItem) FilterValue() string { return i.title }
+
+// NewProfileMenu 创建语言与类型设置菜单
+func NewProfileMenu() *ProfileMenu {
+	language := config.AppConfig.CurrentLanguage
+	items := []list.Item{
+		ProfileScopeMenuItem{
+			scope:       language,
+			title:       fmt.Sprintf("当前语言（%s）", language),
+			description: "覆盖全局设置，只对当前语言生效",
+		},
+	}
+	for _, resourceType := range []string{practice.Words, practice.Phrases, practice.Sentences, practice.Articles} {
+		items = append(items, ProfileScopeMenuItem{
+			scope:       resourceType,
+			title:       getResourceTypeTitle(resourceType),
+			description: fmt.Sprintf("覆盖语言和全局设置，只对%s练习生效", getResourceTypeTitle(resourceType)),
+		})
+	}
+	items = append(items, MenuItem{
+		title:       "返回设置菜单",
+		description: "返回到设置菜单",
+		action:      func() (tea.Model, error) { return NewSettingMenu(), nil },
+	})
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "语言与类型设置（优先级：类型 > 语言 > 全局）"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = TitleStyle
+
+	return &ProfileMenu{
+		list: l,
+	}
+}
+
+// Init 初始化模型
+func (m ProfileMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m ProfileMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 4)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			return m.navigate(NewSettingMenu())
+
+		case "enter":
+			switch i := m.list.SelectedItem().(type) {
+			case ProfileScopeMenuItem:
+				return m.navigate(NewProfileScopeMenu(i.scope))
+			case MenuItem:
+				if i.action != nil {
+					newModel, err := i.action()
+					if err != nil {
+						return m, nil
+					}
+					return m.navigate(newModel)
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// navigate 切换到新的界面并传递当前窗口大小
+func (m ProfileMenu) navigate(model tea.Model) (tea.Model, tea.Cmd) {
+	width, height := m.list.Width(), m.list.Height()+4
+	if width > 0 && height > 4 {
+		updatedModel, _ := model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+		return updatedModel, nil
+	}
+	return model, nil
+}
+
+// View 渲染视图
+func (m ProfileMenu) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	return m.list.View()
+}
+
+// ProfileScopeMenu 某个语言或资源类型的设置菜单，按 Enter 在可选值之间切换
+type ProfileScopeMenu struct {
+	list     list.Model
+	scope    string
+	err      string
+	quitting bool
+}
+
+// ProfileOverrideMenuItem 一项覆盖设置
+type ProfileOverrideMenuItem struct {
+	key         string
+	title       string
+	description string
+}
+
+// 实现list.Item接口
+func (i ProfileOverrideMenuItem) Title() string       { return i.title }
+func (i ProfileOverrideMenuItem) Description() string { return i.description }
+func (i ProfileOverrideMenuItem) FilterValue() string { return i.title }
+
+// NewProfileScopeMenu 创建语言或资源类型的设置菜单
+func NewProfileScopeMenu(scope string) *ProfileScopeMenu {
+	overrides := scopeOverrides(scope)
+
+	// 资源类型显示该类型实际生效的值；语言对各类型可能不同，只说明覆盖值本身
+	effectiveType := ""
+	if config.IsResourceType(scope) {
+		effectiveType = scope
+	}
+
+	items := make([]list.Item, 0, len(config.OverrideKeys)+1)
+	for _, key := range config.OverrideKeys {
+		value := overrides.Get(key)
+		title := fmt.Sprintf("%s: %s", overrideKeyTitles[key], formatOverrideValue(value))
+		description := "按 Enter 切换，可选: 沿用上一级"
+		for _, choice := range config.OverrideChoices(key) {
+			description += " / " + formatOverrideValue(choice)
+		}
+		if effectiveType != "" {
+			setting := config.AppConfig.Resolve(effectiveType, key)
+			description = fmt.Sprintf("当前生效: %s（%s） · %s", formatOverrideValue(setting.Value),
+				settingSourceTitles[setting.Source], description)
+		}
+		items = append(items, ProfileOverrideMenuItem{key: key, title: title, description: description})
+	}
+	if !config.IsResourceType(scope) {
+		profile := config.AppConfig.LanguageProfiles[scope]
+		for _, key := range config.LanguageToggleKeys {
+			items = append(items, ProfileOverrideMenuItem{
+				key:         key,
+				title:       fmt.Sprintf("%s: %s", overrideKeyTitles[key], formatToggle(languageToggle(profile, key))),
+				description: languageToggleDescriptions[key],
+			})
+		}
+		hint := profile.IMEHint
+		if hint == "" {
+			hint = "未设置"
+		}
+		items = append(items, ProfileOverrideMenuItem{
+			key:         config.KeyIMEHint,
+			title:       "输入法提示: " + hint,
+			description: `请使用 mllt-cli setting profile <语言> ime_hint "提示内容" 设置`,
+		})
+	}
+	items = append(items, MenuItem{
+		title:       "返回",
+		description: "返回到语言与类型设置",
+		action:      func() (tea.Model, error) { return NewProfileMenu(), nil },
+	})
+
+	title := getResourceTypeTitle(scope)
+	if !config.IsResourceType(scope) {
+		title = "语言 " + scope
+	}
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = title + "设置"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = TitleStyle
+
+	return &ProfileScopeMenu{
+		list:  l,
+		scope: scope,
+	}
+}
+
+// scopeOverrides 返回语言或资源类型上的覆盖设置
+func scopeOverrides(scope string) config.PracticeOverrides {
+	if config.IsResourceType(scope) {
+		return config.AppConfig.TypeOverrides(scope)
+	}
+	return config.AppConfig.LanguageProfiles[scope].PracticeOverrides
+}
+
+// nextOverrideValue 返回设置项的下一个可选值，最后一个值之后回到"沿用上一级"
+func nextOverrideValue(key, current string) string {
+	choices := config.OverrideChoices(key)
+	if current == "" {
+		return choices[0]
+	}
+	for i, choice := range choices {
+		if choice == current && i+1 < len(choices) {
+			return choices[i+1]
+		}
+	}
+	return config.Inherit
+}
+
+// isLanguageToggle 判断设置项是否为只能按语言设置的开关
+func isLanguageToggle(key string) bool {
+	for _, toggle := range config.LanguageToggleKeys {
+		if toggle == key {
+			return true
+		}
+	}
+	return false
+}
+
+// languageToggle 返回语言配置中开关项的当前值
+func languageToggle(profile config.LanguageProfile, key string) bool {
+	switch key {
+	case config.KeyKanaInput:
+		return profile.KanaInput
+	case config.KeyAcceptReading:
+		return profile.AcceptReading
+	}
+	return false
+}
+
+func formatToggle(enabled bool) string {
+	if enabled {
+		return "开启"
+	}
+	return "关闭"
+}
+
+// formatOverrideValue 将设置值转换为中文显示
+func formatOverrideValue(value string) string {
+	switch value {
+	case "", config.Inherit:
+		return "沿用上一级"
+	case "exact_match":
+		return "完全匹配"
+	case "word_match":
+		return "单词匹配"
+	case "random":
+		return "随机"
+	case "sequential":
+		return "顺序"
+	case "ebbinghaus":
+		return "艾宾浩斯"
+	case "true":
+		return "显示"
+	case "false":
+		return "隐藏"
+	}
+	return value
+}
+
+// Init 初始化模型
+func (m ProfileScopeMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m ProfileScopeMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 4)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			return m.navigate(NewProfileMenu())
+
+		case "enter":
+			switch i := m.list.SelectedItem().(type) {
+			case ProfileOverrideMenuItem:
+				if i.key == config.KeyIMEHint {
+					return m, nil
+				}
+				var value string
+				if isLanguageToggle(i.key) {
+					value = "true"
+					if languageToggle(config.AppConfig.LanguageProfiles[m.scope], i.key) {
+						value = "false"
+					}
+				} else {
+					value = nextOverrideValue(i.key, scopeOverrides(m.scope).Get(i.key))
+				}
+				if err := config.AppConfig.SetOverride(m.scope, i.key, value); err != nil {
+					m.err = err.Error()
+					return m, nil
+				}
+				if err := config.SaveConfig(); err != nil {
+					m.err = err.Error()
+					return m, nil
+				}
+				// 刷新菜单并保持光标位置
+				index := m.list.Index()
+				newModel := NewProfileScopeMenu(m.scope)
+				newModel.list.Select(index)
+				return m.navigate(newModel)
+			case MenuItem:
+				if i.action != nil {
+					newModel, err := i.action()
+					if err != nil {
+						return m, nil
+					}
+					return m.navigate(newModel)
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// navigate 切换到新的界面并传递当前窗口大小
+func (m ProfileScopeMenu) navigate(model tea.Model) (tea.Model, tea.Cmd) {
+	width, height := m.list.Width(), m.list.Height()+4
+	if width > 0 && height > 4 {
+		updatedModel, _ := model.Update(tea.WindowSizeMsg{Width: width, Height: height})
+		return updatedModel, nil
+	}
+	return model, nil
+}
+
+// View 渲染视图
+func (m ProfileScopeMenu) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	view := m.list.View()
+	if m.err != "" {
+		view += "\n" + RenderError(m.err)
+	}
+	return view
+}
@@ -0,0 +1,285 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/resume"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// newPracticeEntry 进入资源文件的练习：有中途退出时保存的进度时，先询问继续还是重新开始
+func newPracticeEntry(resourceType string, folder practice.ResourceFolder, fileName string) tea.Model {
+	if checkpoint, err := resume.Load(resourceType, fileName); err == nil && checkpoint != nil {
+		return NewResumeMenu(resourceType, folder, fileName, checkpoint)
+	}
+	return newPracticeModel(resourceType, fileName)
+}
+
+// NewResumedPracticeModel 从上次中途退出的位置继续练习资源文件，没有可继续的进度时从头开始，ok 为 false
+func NewResumedPracticeModel(resourceType, fileName string, options SessionOptions) (tea.Model, bool) {
+	model := options.apply(newPracticeModel(resourceType, fileName))
+	checkpoint, err := resume.Load(resourceType, fileName)
+	if err != nil || checkpoint == nil {
+		return model, false
+	}
+	return model, resumeModel(model, checkpoint)
+}
+
+// resumePracticeModel 按保存的进度创建练习界面，进度无法使用时从头开始
+func resumePracticeModel(resourceType, fileName string, checkpoint *resume.Checkpoint) (tea.Model, bool) {
+	model := newPracticeModel(resourceType, fileName)
+	return model, resumeModel(model, checkpoint)
+}
+
+// resumeModel 让练习界面从保存的进度继续，进度无法使用时返回 false
+func resumeModel(model tea.Model, checkpoint *resume.Checkpoint) bool {
+	switch session := model.(type) {
+	case *ArticleSession:
+		return session.resume(checkpoint)
+	case *PracticeSession:
+		return session.resume(checkpoint)
+	}
+	return false
+}
+
+// checkpointPosition 返回继续练习的位置说明，如"从第 37 行继续"
+func checkpointPosition(resourceType string, checkpoint *resume.Checkpoint) string {
+	if resourceType == practice.Articles {
+		return fmt.Sprintf("从第 %d 行继续", checkpoint.Completed+1)
+	}
+	return fmt.Sprintf("从第 %d 项继续", checkpoint.Completed+1)
+}
+
+// checkpointSummary 返回保存的进度概要
+func checkpointSummary(checkpoint *resume.Checkpoint) string {
+	elapsed := checkpoint.Elapsed()
+	return fmt.Sprintf("已完成 %d/%d，正确 %d、错误 %d，用时 %d分%d秒，保存于 %s",
+		checkpoint.Completed, checkpoint.Completed+len(checkpoint.Remaining),
+		checkpoint.Correct, checkpoint.Incorrect, int(elapsed.Minutes()), int(elapsed.Seconds())%60,
+		checkpoint.SavedAt.Local().Format("01-02 15:04"))
+}
+
+// ResumeMenu 继续练习或重新开始的选择菜单
+type ResumeMenu struct {
+	list         list.Model
+	resourceType string
+	folder       practice.ResourceFolder
+	quitting     bool
+}
+
+// NewResumeMenu 创建继续练习菜单
+func NewResumeMenu(resourceType string, folder practice.ResourceFolder, fileName string, checkpoint *resume.Checkpoint) *ResumeMenu {
+	items := []list.Item{
+		MenuItem{
+			title:       checkpointPosition(resourceType, checkpoint),
+			description: checkpointSummary(checkpoint),
+			action: func() (tea.Model, error) {
+				model, _ := resumePracticeModel(resourceType, fileName, checkpoint)
+				return model, nil
+			},
+		},
+		MenuItem{
+			title:       "重新开始",
+			description: "清除保存的进度，从头开始练习",
+			action: func() (tea.Model, error) {
+				if err := resume.Remove(resourceType, fileName); err != nil {
+					return nil, err
+				}
+				return newPracticeModel(resourceType, fileName), nil
+			},
+		},
+		MenuItem{
+			title:       "返回文件列表",
+			description: "保留进度，返回上一层",
+			action: func() (tea.Model, error) {
+				return NewResourceFilesMenu(resourceType, folder), nil
+			},
+		},
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = fmt.Sprintf("%s - %s", getResourceTypeTitle(resourceType), practice.FormatResourceDisplayName(fileName))
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = TitleStyle
+
+	return &ResumeMenu{
+		list:         l,
+		resourceType: resourceType,
+		folder:       folder,
+	}
+}
+
+func (m ResumeMenu) Init() tea.Cmd {
+	return nil
+}
+
+func (m ResumeMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 4)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "esc":
+			filesMenu := NewResourceFilesMenu(m.resourceType, m.folder)
+			width, height := m.list.Width(), m.list.Height()+4
+			if width > 0 && height > 4 {
+				updatedModel, _ := filesMenu.Update(tea.WindowSizeMsg{Width: width, Height: height})
+				return updatedModel, nil
+			}
+			return filesMenu, nil
+		case "enter":
+			item, ok := m.list.SelectedItem().(MenuItem)
+			if ok && item.action != nil {
+				newModel, err := item.action()
+				if err != nil {
+					return m, nil
+				}
+				width, height := m.list.Width(), m.list.Height()+4
+				if width > 0 && height > 4 {
+					updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+					return updatedModel, nil
+				}
+				return newModel, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+func (m ResumeMenu) View() string {
+	if m.quitting {
+		return "再见！"
+	}
+	return m.list.View()
+}
+
+// courseRecord 返回用于判断课程是否通过的记录：继续练习时，本次的记录只包含继续后的部分，
+// 需要合并保存的进度中累计的答题数、用时和按键统计，按整课的成绩判断
+func courseRecord(record statistics.SessionRecord, base resume.Checkpoint) statistics.SessionRecord {
+	if base.FileName == "" {
+		return record
+	}
+	record.Correct += base.Correct
+	record.Incorrect += base.Incorrect
+	record.Total = record.Correct + record.Incorrect
+	record.Accuracy = 0
+	if record.Total > 0 {
+		record.Accuracy = float64(record.Correct) / float64(record.Total) * 100
+	}
+	record.DurationSeconds += base.ElapsedSeconds
+	record.Typing = statistics.CombineTyping(base.Typing, record.Typing)
+	return record
+}
+
+// checkpointable 判断会话是否保存进度：今日复习、错题本等跨文件会话不保存。
+// 艾宾浩斯顺序的会话保存按记忆计划排好的剩余队列，继续时沿用该顺序，已完成项目的评分在练习时已写入记忆计划。
+func (m PracticeSession) checkpointable() bool {
+	return !m.isCrossFile() && !m.isReview() && len(m.items) > 0
+}
+
+// saveCheckpoint 保存当前的练习进度，还没有完成任何项目时不保存
+func (m *PracticeSession) saveCheckpoint() {
+	if !m.checkpointable() || m.state == "finished" || m.completedCount == 0 {
+		return
+	}
+
+	remaining := make([]string, 0, len(m.practiceOrder)-m.completedCount)
+	for _, index := range m.practiceOrder[m.completedCount:] {
+		if index >= 0 && index < len(m.items) {
+			remaining = append(remaining, m.items[index])
+		}
+	}
+	_ = resume.Save(&resume.Checkpoint{
+		ResourceType:   m.resourceType,
+		FileName:       m.fileName,
+		Remaining:      remaining,
+		Completed:      m.completedCount,
+		Correct:        m.correct,
+		Incorrect:      m.incorrect,
+		ElapsedSeconds: int64((m.resumeBase.Elapsed() + time.Since(m.startTime)).Seconds()),
+		OrderMode:      m.orderMode,
+		SavedAt:        time.Now(),
+		Typing:         statistics.CombineTyping(m.resumeBase.Typing, m.typing.stats()),
+	})
+}
+
+// clearCheckpoint 练习完成后删除保存的进度
+func (m *PracticeSession) clearCheckpoint() {
+	if m.checkpointable() {
+		_ = resume.Remove(m.resourceType, m.fileName)
+	}
+}
+
+// resume 按保存的进度恢复练习顺序和累计的答题数，之前的答题数已经记录过统计，不再重复记录
+func (m *PracticeSession) resume(checkpoint *resume.Checkpoint) bool {
+	if !m.checkpointable() || m.state == "finished" {
+		return false
+	}
+	order, completed, ok := checkpoint.Restore(m.items)
+	if !ok {
+		return false
+	}
+
+	m.practiceOrder = order
+	m.completedCount = completed
+	m.initialItemCount = len(order)
+	m.correct = checkpoint.Correct
+	m.incorrect = checkpoint.Incorrect
+	m.resumeBase = *checkpoint
+	m.resetItemTracking()
+	return true
+}
+
+// saveCheckpoint 保存文章的练习进度，记录光标所在行及之后的各行
+func (m *ArticleSession) saveCheckpoint() {
+	line := m.currentLine()
+	if m.state == "finished" || line == 0 {
+		return
+	}
+
+	_, correct := m.lineResults()
+	finished := line - m.resumeLine
+	_ = resume.Save(&resume.Checkpoint{
+		ResourceType:   practice.Articles,
+		FileName:       m.fileName,
+		Remaining:      append([]string(nil), m.items[line:]...),
+		Completed:      line,
+		Correct:        m.resumeBase.Correct + correct,
+		Incorrect:      m.resumeBase.Incorrect + finished - correct,
+		ElapsedSeconds: int64((m.resumeBase.Elapsed() + time.Since(m.startTime)).Seconds()),
+		OrderMode:      "sequential",
+		SavedAt:        time.Now(),
+		Typing:         statistics.CombineTyping(m.resumeBase.Typing, m.typing.stats()),
+	})
+}
+
+// resume 从保存的进度中第一个未完成的行继续，之前的行显示为已输入
+func (m *ArticleSession) resume(checkpoint *resume.Checkpoint) bool {
+	if m.state == "finished" {
+		return false
+	}
+	order, completed, ok := checkpoint.Restore(m.items)
+	if !ok {
+		return false
+	}
+
+	m.resumeLine = order[completed]
+	m.typed = append([]rune(nil), m.target[:m.lineStarts[m.resumeLine]]...)
+	m.resumeBase = *checkpoint
+	return true
+}
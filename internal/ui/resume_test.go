@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/resume"
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+)
+
+// 测试逐项练习中途退出后从保存的位置继续，之前的答题数不重复计入统计
+func TestPracticeSessionResume(t *testing.T) {
+	setupSessionTestDir(t)
+
+	items := []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃"}
+	session := newSessionModel(practice.Words, "fruits", items, []int{2, 0, 1}, "random")
+	session.correct++
+	session.advanceToNextItem()
+
+	checkpoint, err := resume.Load(practice.Words, "fruits")
+	if err != nil || checkpoint == nil {
+		t.Fatalf("完成一项后应保存进度: %v", err)
+	}
+	if checkpoint.Completed != 1 || strings.Join(checkpoint.Remaining, ",") != "apple ->> 苹果,banana ->> 香蕉" {
+		t.Errorf("保存的进度 = %+v", checkpoint)
+	}
+
+	resumed := newSessionModel(practice.Words, "fruits", items, []int{0, 1, 2}, "random")
+	if !resumed.resume(checkpoint) {
+		t.Fatal("应可以从保存的进度继续")
+	}
+	if resumed.completedCount != 1 || resumed.getCurrentRawItem() != "apple ->> 苹果" || resumed.correct != 1 {
+		t.Errorf("继续后当前项目 = %q（已完成 %d，答对 %d）", resumed.getCurrentRawItem(), resumed.completedCount, resumed.correct)
+	}
+
+	resumed.advanceToNextItem()
+	resumed.advanceToNextItem()
+	if resumed.state != "finished" {
+		t.Fatalf("完成全部项目后状态 = %s", resumed.state)
+	}
+	if checkpoint, _ := resume.Load(practice.Words, "fruits"); checkpoint != nil {
+		t.Error("练习完成后应删除进度")
+	}
+}
+
+// 测试艾宾浩斯顺序的练习同样保存进度，继续时沿用按记忆计划排好的剩余队列
+func TestPracticeSessionResumeSRS(t *testing.T) {
+	setupSessionTestDir(t)
+
+	items := []string{"apple ->> 苹果", "banana ->> 香蕉", "cherry ->> 樱桃"}
+	schedule, err := srs.Load(practice.Words, "fruits", items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := newSessionModel(practice.Words, "fruits", items, []int{1, 2, 0}, "ebbinghaus")
+	session.srsEnabled = true
+	session.srsSchedule = schedule
+	session.correct++
+	session.advanceToNextItem()
+
+	checkpoint, err := resume.Load(practice.Words, "fruits")
+	if err != nil || checkpoint == nil {
+		t.Fatalf("艾宾浩斯顺序的练习完成一项后应保存进度: %v", err)
+	}
+	if strings.Join(checkpoint.Remaining, ",") != "cherry ->> 樱桃,apple ->> 苹果" {
+		t.Errorf("保存的剩余队列 = %v", checkpoint.Remaining)
+	}
+
+	resumed := newSessionModel(practice.Words, "fruits", items, []int{0, 1, 2}, "ebbinghaus")
+	resumed.srsEnabled = true
+	resumed.srsSchedule = schedule
+	if !resumed.resume(checkpoint) || resumed.getCurrentRawItem() != "cherry ->> 樱桃" || resumed.completedCount != 1 {
+		t.Errorf("继续后当前项目 = %q（已完成 %d）", resumed.getCurrentRawItem(), resumed.completedCount)
+	}
+}
+
+// 测试文章连续输入按行保存进度，继续时之前的行显示为已输入且不能退格删除
+func TestArticleSessionResume(t *testing.T) {
+	m := newTestArticleSession(t, "Excuse me! ->> 对不起！", "Yes? ->> 什么事？", "Pardon?")
+	m.foldCase = false
+	typeText(m, "Excuse me!\nYas")
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	checkpoint, err := resume.Load(practice.Articles, m.fileName)
+	if err != nil || checkpoint == nil {
+		t.Fatalf("中途退出时应保存进度: %v", err)
+	}
+	if checkpoint.Completed != 1 || checkpoint.Correct != 1 || len(checkpoint.Remaining) != 2 {
+		t.Errorf("保存的进度 = %+v", checkpoint)
+	}
+	if got := checkpointPosition(practice.Articles, checkpoint); got != "从第 2 行继续" {
+		t.Errorf("继续位置 = %q", got)
+	}
+
+	resumed := newArticleSessionModel(m.fileName, m.items)
+	if !resumed.resume(checkpoint) {
+		t.Fatal("应可以从保存的进度继续")
+	}
+	if resumed.currentLine() != 1 || string(resumed.typed) != "Excuse me!\n" {
+		t.Errorf("继续后已输入 %q，当前第 %d 行", string(resumed.typed), resumed.currentLine()+1)
+	}
+	resumed.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	if len(resumed.typed) != 11 {
+		t.Error("不应删除之前已完成的行")
+	}
+
+	typeText(resumed, "Yes?\nPardon?")
+	if !strings.Contains(resumed.result, "全对的行: 3/3") {
+		t.Errorf("结果应包括之前的行: %q", resumed.result)
+	}
+	if total, correct := resumed.lineResults(); total != 2 || correct != 2 {
+		t.Errorf("本次记录的行数 = %d/%d, want 2/2", correct, total)
+	}
+	if checkpoint, _ := resume.Load(practice.Articles, m.fileName); checkpoint != nil {
+		t.Error("练习完成后应删除进度")
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ajilisiwei/mllt-cli/internal/srs"
+)
+
+const (
+	reviewResourceType = "review"
+	reviewFileName     = "今日复习"
+)
+
+// NewReviewSession 创建"今日复习"练习会话：汇总当前语言下所有资源文件中已到期的条目，
+// 按到期先后集中练习，结果分别写回各条目所属文件的记忆计划。
+func NewReviewSession() *PracticeSession {
+	due, err := srs.CollectDue(time.Now())
+
+	// 已标记的内容不再参与复习
+	markedSets := make(map[string]map[string]struct{})
+	grouped := make(map[itemSource][]string)
+	for _, entry := range due {
+		marked, ok := markedSets[entry.ResourceType]
+		if !ok {
+			marked = markedItemSet(entry.ResourceType)
+			markedSets[entry.ResourceType] = marked
+		}
+		if _, skip := marked[entry.Item]; skip {
+			continue
+		}
+		source := itemSource{resourceType: entry.ResourceType, fileName: entry.FileName}
+		grouped[source] = append(grouped[source], entry.Item)
+	}
+
+	schedules := make(map[itemSource]*srs.Schedule, len(grouped))
+	for source, sourceItems := range grouped {
+		if schedule, loadErr := srs.Load(source.resourceType, source.fileName, sourceItems); loadErr == nil {
+			schedules[source] = schedule
+		}
+	}
+
+	// 保持到期先后顺序；无法加载记忆计划的条目无法写回结果，直接跳过
+	items := make([]string, 0, len(due))
+	sources := make([]itemSource, 0, len(due))
+	for _, entry := range due {
+		source := itemSource{resourceType: entry.ResourceType, fileName: entry.FileName}
+		if _, ok := schedules[source]; !ok {
+			continue
+		}
+		if _, skip := markedSets[entry.ResourceType][entry.Item]; skip {
+			continue
+		}
+		items = append(items, entry.Item)
+		sources = append(sources, source)
+	}
+
+	practiceOrder := sequentialOrder(len(items))
+
+	session := newSessionModel(reviewResourceType, reviewFileName, items, practiceOrder, "ebbinghaus")
+	session.displayFileName = reviewFileName
+	session.sources = sources
+	session.schedules = schedules
+	session.srsEnabled = len(items) > 0
+
+	if len(items) == 0 {
+		session.result = "今天没有需要复习的内容。按 Enter 或 Esc 返回主菜单。"
+		if err != nil {
+			session.result = fmt.Sprintf("汇总复习内容失败: %v。按 Enter 或 Esc 返回主菜单。", err)
+		}
+	}
+
+	return session
+}
+
+// reviewMenuDescription 返回主菜单中"今日复习"的说明，包含已到期条目数
+func reviewMenuDescription() string {
+	due, err := srs.CollectDue(time.Now())
+	if err != nil {
+		return "集中复习所有资源中已到期的内容"
+	}
+	if len(due) == 0 {
+		return "今天没有到期的复习内容"
+	}
+	counts := make(map[string]int)
+	for _, entry := range due {
+		counts[entry.ResourceType]++
+	}
+	parts := make([]string, 0, len(srs.ReviewTypes))
+	for _, resourceType := range srs.ReviewTypes {
+		if counts[resourceType] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", getResourceTypeTitle(resourceType), counts[resourceType]))
+		}
+	}
+	return fmt.Sprintf("%d 项已到期（%s），跨文件集中复习", len(due), strings.Join(parts, "，"))
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/ajilisiwei/mllt-cli/internal/config"
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
 )
 
 // SettingMenuItem 设置菜单项
@@ -56,6 +57,34 @@ func NewSettingMenu() *SettingMenu {
 				return NewShowTranslationMenu(), nil
 			},
 		},
+		SettingMenuItem{
+			title:       "练习模式设置",
+			description: "设置抄写、默写或填空模式（默写时只显示翻译或提示，填空时只输入挖空的单词）",
+			action: func() (tea.Model, error) {
+				return NewPracticeModeMenu(), nil
+			},
+		},
+		SettingMenuItem{
+			title:       "练习方向设置",
+			description: "设置输入原文或输入翻译（反向练习仅适用于单词、短语、句子）",
+			action: func() (tea.Model, error) {
+				return NewPracticeDirectionMenu(), nil
+			},
+		},
+		SettingMenuItem{
+			title:       "文章练习方式设置",
+			description: "设置文章连续输入整篇或逐行输入",
+			action: func() (tea.Model, error) {
+				return NewArticleLayoutMenu(), nil
+			},
+		},
+		SettingMenuItem{
+			title:       "语言与类型设置",
+			description: "按语言或资源类型覆盖匹配模式、练习顺序和翻译显示",
+			action: func() (tea.Model, error) {
+				return NewProfileMenu(), nil
+			},
+		},
 		MenuItem{
 			title:       "返回主菜单",
 			description: "返回到主菜单",
@@ -711,3 +740,417 @@ func (m ShowTranslationMenu) View() string {
 
 	return m.list.View()
 }
+
+// PracticeModeMenu 练习模式设置菜单
+type PracticeModeMenu struct {
+	list     list.Model
+	quitting bool
+}
+
+// PracticeModeMenuItem 练习模式设置菜单项
+type PracticeModeMenuItem struct {
+	mode        string
+	title       string
+	description string
+	isCurrent   bool
+}
+
+// 实现list.Item接口
+func (i PracticeModeMenuItem) Title() string {
+	title := i.title
+	if i.isCurrent {
+		title = "✔ " + title
+	}
+	return title
+}
+func (i PracticeModeMenuItem) Description() string { return i.description }
+func (i PracticeModeMenuItem) FilterValue() string { return i.title }
+
+// 创建新的练习模式设置菜单
+func NewPracticeModeMenu() *PracticeModeMenu {
+	currentMode := normalizePracticeMode(config.AppConfig.PracticeMode)
+
+	// 创建菜单项
+	items := []list.Item{
+		PracticeModeMenuItem{
+			mode:        practiceModeCopy,
+			title:       "抄写模式",
+			description: "显示原文，照着输入",
+			isCurrent:   currentMode == practiceModeCopy,
+		},
+		PracticeModeMenuItem{
+			mode:        practiceModeDictation,
+			title:       "默写模式",
+			description: "只显示翻译或提示，凭记忆输入原文，可输入\"> hint\"查看提示",
+			isCurrent:   currentMode == practiceModeDictation,
+		},
+		PracticeModeMenuItem{
+			mode:        practiceModeCloze,
+			title:       "填空模式",
+			description: "挖去句子和文章中的单词，只输入空缺的单词（单词和短语仍按抄写练习）",
+			isCurrent:   currentMode == practiceModeCloze,
+		},
+		MenuItem{
+			title:       "返回设置菜单",
+			description: "返回到设置菜单",
+			action:      func() (tea.Model, error) { return NewSettingMenu(), nil },
+		},
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "练习模式设置"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = TitleStyle
+
+	return &PracticeModeMenu{
+		list: l,
+	}
+}
+
+// Init 初始化模型
+func (m PracticeModeMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m PracticeModeMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 4)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			settingMenu := NewSettingMenu()
+			width, height := m.list.Width(), m.list.Height()+4
+			if width > 0 && height > 4 {
+				updatedModel, _ := settingMenu.Update(tea.WindowSizeMsg{Width: width, Height: height})
+				return updatedModel, nil
+			}
+			return settingMenu, nil
+
+		case "enter":
+			switch i := m.list.SelectedItem().(type) {
+			case PracticeModeMenuItem:
+				// 更新配置
+				config.AppConfig.PracticeMode = i.mode
+				config.SaveConfig()
+				// 刷新菜单
+				newModel := NewPracticeModeMenu()
+				// 传递当前窗口大小
+				width, height := m.list.Width(), m.list.Height()+4
+				if width > 0 && height > 4 {
+					updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+					return updatedModel, nil
+				}
+				return newModel, nil
+			case MenuItem:
+				if i.action != nil {
+					newModel, err := i.action()
+					if err != nil {
+						return m, nil
+					}
+					// 传递当前窗口大小
+					width, height := m.list.Width(), m.list.Height()+4
+					if width > 0 && height > 4 {
+						updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+						return updatedModel, nil
+					}
+					return newModel, nil
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View 渲染视图
+func (m PracticeModeMenu) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	return m.list.View()
+}
+
+// PracticeDirectionMenu 练习方向设置菜单
+type PracticeDirectionMenu struct {
+	list     list.Model
+	quitting bool
+}
+
+// PracticeDirectionMenuItem 练习方向设置菜单项
+type PracticeDirectionMenuItem struct {
+	direction   string
+	title       string
+	description string
+	isCurrent   bool
+}
+
+// 实现list.Item接口
+func (i PracticeDirectionMenuItem) Title() string {
+	title := i.title
+	if i.isCurrent {
+		title = "✔ " + title
+	}
+	return title
+}
+func (i PracticeDirectionMenuItem) Description() string { return i.description }
+func (i PracticeDirectionMenuItem) FilterValue() string { return i.title }
+
+// 创建新的练习方向设置菜单
+func NewPracticeDirectionMenu() *PracticeDirectionMenu {
+	currentDirection := practice.NormalizeDirection(config.AppConfig.PracticeDirection)
+
+	// 创建菜单项
+	items := []list.Item{
+		PracticeDirectionMenuItem{
+			direction:   practice.DirectionForward,
+			title:       "正向练习",
+			description: "看原文，输入原文",
+			isCurrent:   currentDirection == practice.DirectionForward,
+		},
+		PracticeDirectionMenuItem{
+			direction:   practice.DirectionReverse,
+			title:       "反向练习",
+			description: "看原文，输入翻译，用于检验理解；没有翻译的条目仍输入原文",
+			isCurrent:   currentDirection == practice.DirectionReverse,
+		},
+		MenuItem{
+			title:       "返回设置菜单",
+			description: "返回到设置菜单",
+			action:      func() (tea.Model, error) { return NewSettingMenu(), nil },
+		},
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "练习方向设置"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = TitleStyle
+
+	return &PracticeDirectionMenu{
+		list: l,
+	}
+}
+
+// Init 初始化模型
+func (m PracticeDirectionMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m PracticeDirectionMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 4)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			settingMenu := NewSettingMenu()
+			width, height := m.list.Width(), m.list.Height()+4
+			if width > 0 && height > 4 {
+				updatedModel, _ := settingMenu.Update(tea.WindowSizeMsg{Width: width, Height: height})
+				return updatedModel, nil
+			}
+			return settingMenu, nil
+
+		case "enter":
+			switch i := m.list.SelectedItem().(type) {
+			case PracticeDirectionMenuItem:
+				// 更新配置
+				config.AppConfig.PracticeDirection = i.direction
+				config.SaveConfig()
+				// 刷新菜单
+				newModel := NewPracticeDirectionMenu()
+				// 传递当前窗口大小
+				width, height := m.list.Width(), m.list.Height()+4
+				if width > 0 && height > 4 {
+					updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+					return updatedModel, nil
+				}
+				return newModel, nil
+			case MenuItem:
+				if i.action != nil {
+					newModel, err := i.action()
+					if err != nil {
+						return m, nil
+					}
+					// 传递当前窗口大小
+					width, height := m.list.Width(), m.list.Height()+4
+					if width > 0 && height > 4 {
+						updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+						return updatedModel, nil
+					}
+					return newModel, nil
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View 渲染视图
+func (m PracticeDirectionMenu) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	return m.list.View()
+}
+
+// ArticleLayoutMenu 文章练习方式设置菜单
+type ArticleLayoutMenu struct {
+	list     list.Model
+	quitting bool
+}
+
+// ArticleLayoutMenuItem 文章练习方式设置菜单项
+type ArticleLayoutMenuItem struct {
+	layout      string
+	title       string
+	description string
+	isCurrent   bool
+}
+
+// 实现list.Item接口
+func (i ArticleLayoutMenuItem) Title() string {
+	title := i.title
+	if i.isCurrent {
+		title = "✔ " + title
+	}
+	return title
+}
+func (i ArticleLayoutMenuItem) Description() string { return i.description }
+func (i ArticleLayoutMenuItem) FilterValue() string { return i.title }
+
+// 创建新的文章练习方式设置菜单
+func NewArticleLayoutMenu() *ArticleLayoutMenu {
+	currentLayout := config.AppConfig.Articles.ArticleLayout()
+
+	// 创建菜单项
+	items := []list.Item{
+		ArticleLayoutMenuItem{
+			layout:      config.ArticleLayoutFlow,
+			title:       "连续输入",
+			description: "显示整篇文章，光标逐字前进，输错的字符就地标出，可以退格修改",
+			isCurrent:   currentLayout == config.ArticleLayoutFlow,
+		},
+		ArticleLayoutMenuItem{
+			layout:      config.ArticleLayoutLines,
+			title:       "逐行输入",
+			description: "每行作为一个练习项目，按 Enter 提交，输错后重新输入整行",
+			isCurrent:   currentLayout == config.ArticleLayoutLines,
+		},
+		MenuItem{
+			title:       "返回设置菜单",
+			description: "返回到设置菜单",
+			action:      func() (tea.Model, error) { return NewSettingMenu(), nil },
+		},
+	}
+
+	l := list.New(items, list.NewDefaultDelegate(), 0, 0)
+	l.Title = "文章练习方式设置"
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(false)
+	l.Styles.Title = TitleStyle
+
+	return &ArticleLayoutMenu{
+		list: l,
+	}
+}
+
+// Init 初始化模型
+func (m ArticleLayoutMenu) Init() tea.Cmd {
+	return nil
+}
+
+// Update 更新模型
+func (m ArticleLayoutMenu) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.list.SetWidth(msg.Width)
+		m.list.SetHeight(msg.Height - 4)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+
+		case "esc":
+			settingMenu := NewSettingMenu()
+			width, height := m.list.Width(), m.list.Height()+4
+			if width > 0 && height > 4 {
+				updatedModel, _ := settingMenu.Update(tea.WindowSizeMsg{Width: width, Height: height})
+				return updatedModel, nil
+			}
+			return settingMenu, nil
+
+		case "enter":
+			switch i := m.list.SelectedItem().(type) {
+			case ArticleLayoutMenuItem:
+				// 更新配置
+				config.AppConfig.Articles.Layout = i.layout
+				config.SaveConfig()
+				// 刷新菜单
+				newModel := NewArticleLayoutMenu()
+				// 传递当前窗口大小
+				width, height := m.list.Width(), m.list.Height()+4
+				if width > 0 && height > 4 {
+					updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+					return updatedModel, nil
+				}
+				return newModel, nil
+			case MenuItem:
+				if i.action != nil {
+					newModel, err := i.action()
+					if err != nil {
+						return m, nil
+					}
+					// 传递当前窗口大小
+					width, height := m.list.Width(), m.list.Height()+4
+					if width > 0 && height > 4 {
+						updatedModel, _ := newModel.Update(tea.WindowSizeMsg{Width: width, Height: height})
+						return updatedModel, nil
+					}
+					return newModel, nil
+				}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View 渲染视图
+func (m ArticleLayoutMenu) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	return m.list.View()
+}
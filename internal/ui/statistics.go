@@ -26,7 +26,11 @@ func (i StatisticsSummaryItem) Title() string {
 }
 
 func (i StatisticsSummaryItem) Description() string {
-	return fmt.Sprintf("正确率 %.1f%% · 正确 %d · 错误 %d", i.summary.Accuracy, i.summary.Correct, i.summary.Incorrect)
+	description := fmt.Sprintf("正确率 %.1f%% · 正确 %d · 错误 %d", i.summary.Accuracy, i.summary.Correct, i.summary.Incorrect)
+	if i.summary.NetWPM > 0 {
+		description += fmt.Sprintf(" · %.1f WPM · 按键准确率 %.1f%%", i.summary.NetWPM, i.summary.CharAccuracy)
+	}
+	return description
 }
 
 func (i StatisticsSummaryItem) FilterValue() string { return i.summary.Date }
@@ -46,7 +50,11 @@ func (i StatisticsSessionItem) Title() string {
 }
 
 func (i StatisticsSessionItem) Description() string {
-	return fmt.Sprintf("%s · 正确 %d / %d · %.1f%% · 用时 %ds", i.record.FileName, i.record.Correct, i.record.Total, i.record.Accuracy, i.record.DurationSeconds)
+	description := fmt.Sprintf("%s · 正确 %d / %d · %.1f%% · 用时 %ds", i.record.FileName, i.record.Correct, i.record.Total, i.record.Accuracy, i.record.DurationSeconds)
+	if i.record.Typing != nil {
+		description += fmt.Sprintf(" · %.1f WPM", i.record.Typing.NetWPM)
+	}
+	return description
 }
 
 func (i StatisticsSessionItem) FilterValue() string { return i.record.FileName }
@@ -58,7 +66,18 @@ func NewStatisticsMenu() *StatisticsMenu {
 		summaries = []statistics.DailySummary{}
 	}
 
-	items := []list.Item{}
+	items := []list.Item{
+		MenuItem{
+			title:       "练习图表",
+			description: "查看最近 30/90 天的练习量、正确率趋势和各类型练习时长",
+			action:      func() (tea.Model, error) { return NewStatisticsChartView(defaultChartDay), nil },
+		},
+		MenuItem{
+			title:       "按键错误热力图",
+			description: "汇总所有练习，查看各按键的输错次数与打字速度",
+			action:      func() (tea.Model, error) { return NewStatisticsHeatmapView(), nil },
+		},
+	}
 	if len(summaries) == 0 {
 		items = append(items, MenuItem{
 			title:       "暂无统计数据",
@@ -0,0 +1,279 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ajilisiwei/mllt-cli/internal/practice"
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// 图表可选的统计天数
+var chartRanges = []int{30, 90}
+
+const (
+	chartHeight     = 6  // 练习量柱状图的行数
+	chartAxisWidth  = 6  // 纵轴刻度的宽度
+	chartBarWidth   = 30 // 时长条形图的最大宽度
+	defaultChartDay = 30
+)
+
+// StatisticsChartView 统计图表视图：每日练习量、正确率趋势和各类型练习时长
+type StatisticsChartView struct {
+	days      int
+	series    []statistics.DailySummary
+	durations []statistics.TypeDuration
+	loadErr   error
+	width     int
+	height    int
+	quitting  bool
+}
+
+// NewStatisticsChartView 创建统计图表视图，days 为统计的天数
+func NewStatisticsChartView(days int) *StatisticsChartView {
+	if days <= 0 {
+		days = defaultChartDay
+	}
+	view := &StatisticsChartView{days: days}
+	view.load()
+	return view
+}
+
+func (m *StatisticsChartView) load() {
+	now := time.Now()
+	series, err := statistics.GetDailySeries(m.days, now)
+	if err != nil {
+		m.loadErr = err
+		return
+	}
+
+	from := now.AddDate(0, 0, -(m.days - 1)).Format("2006-01-02")
+	durations, err := statistics.GetDurationByType(from, "",
+		[]string{practice.Words, practice.Phrases, practice.Sentences, practice.Articles})
+	if err != nil {
+		m.loadErr = err
+		return
+	}
+
+	m.series = series
+	m.durations = durations
+	m.loadErr = nil
+}
+
+func (m StatisticsChartView) Init() tea.Cmd {
+	return nil
+}
+
+func (m StatisticsChartView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "tab", "left", "right":
+			m.days = nextChartRange(m.days)
+			m.load()
+			return m, nil
+		case "esc", "enter", "q":
+			menu := NewStatisticsMenu()
+			if m.width > 0 {
+				if updated, cmd := menu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height}); updated != nil {
+					return updated, cmd
+				}
+			}
+			return menu, nil
+		}
+	}
+	return m, nil
+}
+
+// nextChartRange 在可选的统计天数之间切换
+func nextChartRange(current int) int {
+	for i, days := range chartRanges {
+		if days == current {
+			return chartRanges[(i+1)%len(chartRanges)]
+		}
+	}
+	return chartRanges[0]
+}
+
+func (m StatisticsChartView) View() string {
+	if m.quitting {
+		return "再见！"
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderTitle(fmt.Sprintf("练习图表 - 最近 %d 天", m.days)) + "\n\n")
+
+	if m.loadErr != nil {
+		s.WriteString(RenderError(fmt.Sprintf("读取统计数据失败: %v", m.loadErr)) + "\n\n")
+		s.WriteString(RenderText("按 Esc 返回统计概览") + "\n")
+		return s.String()
+	}
+
+	s.WriteString(m.renderVolume() + "\n\n")
+	s.WriteString(m.renderAccuracy() + "\n\n")
+	s.WriteString(m.renderDurations() + "\n\n")
+
+	s.WriteString(RenderText("按 Tab 切换 30/90 天，按 Esc 返回统计概览") + "\n")
+	return s.String()
+}
+
+// bucketSize 根据终端宽度计算每列合并的天数
+func (m StatisticsChartView) bucketSize() int {
+	available := m.width - chartAxisWidth - 4
+	if m.width <= 0 || available >= len(m.series) {
+		return 1
+	}
+	if available < 10 {
+		available = 10
+	}
+	return int(math.Ceil(float64(len(m.series)) / float64(available)))
+}
+
+func (m StatisticsChartView) renderVolume() string {
+	values := make([]float64, len(m.series))
+	total := 0
+	for i, summary := range m.series {
+		values[i] = float64(summary.Total)
+		total += summary.Total
+	}
+
+	var s strings.Builder
+	size := m.bucketSize()
+	title := "每日练习量（题）"
+	if size > 1 {
+		title = fmt.Sprintf("练习量（题，每列 %d 天）", size)
+	}
+	s.WriteString(RenderHighlight(title) + "\n")
+
+	if total == 0 {
+		s.WriteString(RenderText("暂无练习记录"))
+		return s.String()
+	}
+
+	values = bucketValues(values, size, false)
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = math.Max(maxValue, value)
+	}
+
+	rows := renderColumnChart(values, chartHeight)
+	for i, row := range rows {
+		axis := strings.Repeat(" ", chartAxisWidth)
+		switch i {
+		case 0:
+			axis = fmt.Sprintf("%*d ", chartAxisWidth-1, int(maxValue))
+		case len(rows) - 1:
+			axis = fmt.Sprintf("%*d ", chartAxisWidth-1, 0)
+		}
+		s.WriteString(RenderText(axis+"│") + HighlightStyle.Render(row) + "\n")
+	}
+	s.WriteString(RenderText(strings.Repeat(" ", chartAxisWidth)+"└"+strings.Repeat("─", len(values))) + "\n")
+	s.WriteString(RenderText(m.dateAxis(len(values))) + "\n")
+	s.WriteString(RenderText(fmt.Sprintf("合计 %d 题 · 日均 %.1f 题", total, float64(total)/float64(len(m.series)))))
+	return s.String()
+}
+
+// dateAxis 在图表下方标出起止日期
+func (m StatisticsChartView) dateAxis(columns int) string {
+	if len(m.series) == 0 {
+		return ""
+	}
+	first := m.series[0].Date[5:]
+	last := m.series[len(m.series)-1].Date[5:]
+	gap := columns - len(first) - len(last)
+	if gap < 1 {
+		gap = 1
+	}
+	return strings.Repeat(" ", chartAxisWidth+1) + first + strings.Repeat(" ", gap) + last
+}
+
+func (m StatisticsChartView) renderAccuracy() string {
+	values := make([]float64, len(m.series))
+	minValue, maxValue, sum := 100.0, 0.0, 0.0
+	count := 0
+	for i, summary := range m.series {
+		if summary.Total == 0 {
+			values[i] = -1
+			continue
+		}
+		values[i] = summary.Accuracy
+		minValue = math.Min(minValue, summary.Accuracy)
+		maxValue = math.Max(maxValue, summary.Accuracy)
+		sum += summary.Accuracy
+		count++
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderHighlight("正确率趋势") + "\n")
+	if count == 0 {
+		s.WriteString(RenderText("暂无练习记录"))
+		return s.String()
+	}
+
+	// 纵轴下限取最低值向下取整到 10%，让小幅波动也能看得出来
+	floor := math.Floor(minValue/10) * 10
+	values = bucketValues(values, m.bucketSize(), true)
+	s.WriteString(RenderText(fmt.Sprintf("%*s│", chartAxisWidth, fmt.Sprintf("%.0f%%", floor))) +
+		SuccessStyle.Render(renderSparkline(values, floor, 100)) + "\n")
+	s.WriteString(RenderText(m.dateAxis(len(values))) + "\n")
+	s.WriteString(RenderText(fmt.Sprintf("最低 %.1f%% · 平均 %.1f%% · 最高 %.1f%%（· 表示当天未练习）",
+		minValue, sum/float64(count), maxValue)))
+	return s.String()
+}
+
+func (m StatisticsChartView) renderDurations() string {
+	var s strings.Builder
+	s.WriteString(RenderHighlight("各类型练习时长") + "\n")
+
+	var total int64
+	for _, entry := range m.durations {
+		total += entry.Seconds
+	}
+	if total == 0 {
+		s.WriteString(RenderText("暂无练习记录"))
+		return s.String()
+	}
+
+	rows := make([]barRow, 0, len(m.durations))
+	for _, entry := range m.durations {
+		label := getResourceTypeTitle(entry.ResourceType)
+		if entry.ResourceType == reviewResourceType {
+			label = reviewFileName
+		}
+		rows = append(rows, barRow{
+			label: label,
+			value: float64(entry.Seconds),
+			text: fmt.Sprintf("%s (%.0f%%)", formatDuration(entry.Seconds),
+				float64(entry.Seconds)/float64(total)*100),
+		})
+	}
+
+	lines := renderBarChart(rows, chartBarWidth)
+	for i, line := range lines {
+		s.WriteString(RenderText(line))
+		if i < len(lines)-1 {
+			s.WriteString("\n")
+		}
+	}
+	return s.String()
+}
+
+// formatDuration 将秒数格式化为"X小时Y分"或"Y分Z秒"
+func formatDuration(seconds int64) string {
+	duration := time.Duration(seconds) * time.Second
+	if duration >= time.Hour {
+		return fmt.Sprintf("%d小时%d分", int(duration.Hours()), int(duration.Minutes())%60)
+	}
+	return fmt.Sprintf("%d分%d秒", int(duration.Minutes()), int(duration.Seconds())%60)
+}
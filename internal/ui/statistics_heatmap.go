@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// 键盘布局（QWERTY），每行前的缩进模拟真实键盘的错位
+var keyboardRows = []struct {
+	indent int
+	keys   []string
+}{
+	{0, []string{"`", "1", "2", "3", "4", "5", "6", "7", "8", "9", "0", "-", "="}},
+	{2, []string{"q", "w", "e", "r", "t", "y", "u", "i", "o", "p", "[", "]", "\\"}},
+	{3, []string{"a", "s", "d", "f", "g", "h", "j", "k", "l", ";", "'"}},
+	{4, []string{"z", "x", "c", "v", "b", "n", "m", ",", ".", "/"}},
+}
+
+// shiftedKeys 将需要 Shift 输入的符号归到对应的按键上
+var shiftedKeys = map[string]string{
+	"~": "`", "!": "1", "@": "2", "#": "3", "$": "4", "%": "5", "^": "6", "&": "7", "*": "8",
+	"(": "9", ")": "0", "_": "-", "+": "=", "{": "[", "}": "]", "|": "\\", ":": ";", "\"": "'",
+	"<": ",", ">": ".", "?": "/",
+}
+
+// 热力图颜色，从无错误到错误最多
+var heatColors = []string{"#3A3A3A", "#2E7D32", "#9E9D24", "#F9A825", "#EF6C00", "#C62828"}
+
+// StatisticsHeatmapView 按键错误热力图视图
+type StatisticsHeatmapView struct {
+	keyCounts map[string]int             // 键盘按键 -> 输错次数
+	topErrors []statistics.KeyErrorCount // 输错最多的字符（含非键盘字符）
+	sessions  int                        // 含按键数据的练习次数
+	typing    statistics.TypingStats     // 所有练习的按键数据汇总
+	loadErr   error
+	width     int
+	height    int
+	quitting  bool
+}
+
+// NewStatisticsHeatmapView 汇总所有练习记录，创建按键错误热力图
+func NewStatisticsHeatmapView() *StatisticsHeatmapView {
+	view := &StatisticsHeatmapView{keyCounts: make(map[string]int)}
+
+	records, err := statistics.GetAllSessions()
+	if err != nil {
+		view.loadErr = err
+		return view
+	}
+
+	for _, record := range records {
+		if record.Typing == nil {
+			continue
+		}
+		view.sessions++
+		view.typing.Keystrokes += record.Typing.Keystrokes
+		view.typing.Backspaces += record.Typing.Backspaces
+		view.typing.CorrectChars += record.Typing.CorrectChars
+		view.typing.WrongChars += record.Typing.WrongChars
+		view.typing.ActiveSeconds += record.Typing.ActiveSeconds
+	}
+	view.typing.ComputeSpeed()
+
+	errors := statistics.AggregateKeyErrors(records)
+	for key, count := range errors {
+		view.keyCounts[keyboardKeyFor(key)] += count
+	}
+	view.topErrors = statistics.TopKeyErrors(errors, 10)
+
+	return view
+}
+
+// keyboardKeyFor 返回字符所在的键盘按键
+func keyboardKeyFor(char string) string {
+	if base, ok := shiftedKeys[char]; ok {
+		return base
+	}
+	return strings.ToLower(char)
+}
+
+func (m StatisticsHeatmapView) Init() tea.Cmd {
+	return nil
+}
+
+func (m StatisticsHeatmapView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.quitting = true
+			return m, tea.Quit
+		case "esc", "enter", "q":
+			menu := NewStatisticsMenu()
+			if m.width > 0 {
+				if updated, cmd := menu.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height}); updated != nil {
+					return updated, cmd
+				}
+			}
+			return menu, nil
+		}
+	}
+	return m, nil
+}
+
+func (m StatisticsHeatmapView) View() string {
+	if m.quitting {
+		return "再见！"
+	}
+
+	var s strings.Builder
+	s.WriteString(RenderTitle("按键错误热力图") + "\n\n")
+
+	if m.loadErr != nil {
+		s.WriteString(RenderError(fmt.Sprintf("读取统计数据失败: %v", m.loadErr)) + "\n\n")
+		s.WriteString(RenderText("按 Esc 返回统计概览") + "\n")
+		return s.String()
+	}
+
+	if m.sessions == 0 {
+		s.WriteString(RenderText("暂无按键数据，完成一次练习后即可查看。") + "\n\n")
+		s.WriteString(RenderText("按 Esc 返回统计概览") + "\n")
+		return s.String()
+	}
+
+	s.WriteString(RenderText(fmt.Sprintf("共 %d 次练习 · 按键 %d 次 · 输错 %d 次 · 退格 %d 次",
+		m.sessions, m.typing.Keystrokes, m.typing.WrongChars, m.typing.Backspaces)) + "\n")
+	s.WriteString(RenderText(fmt.Sprintf("按键准确率 %.1f%% · 净速度 %.1f WPM · 毛速度 %.1f WPM",
+		m.typing.CharAccuracy(), m.typing.NetWPM, m.typing.GrossWPM)) + "\n\n")
+
+	s.WriteString(m.renderKeyboard() + "\n\n")
+	s.WriteString(m.renderLegend() + "\n\n")
+
+	if len(m.topErrors) > 0 {
+		s.WriteString(RenderHighlight("输错最多的字符:") + "\n")
+		parts := make([]string, 0, len(m.topErrors))
+		for _, entry := range m.topErrors {
+			parts = append(parts, fmt.Sprintf("%s ×%d", entry.Key, entry.Count))
+		}
+		s.WriteString(RenderText(strings.Join(parts, "  ")) + "\n\n")
+	}
+
+	s.WriteString(RenderText("按 Esc 返回统计概览") + "\n")
+	return s.String()
+}
+
+// maxKeyCount 返回键盘上单个按键的最大输错次数
+func (m StatisticsHeatmapView) maxKeyCount() int {
+	maxCount := 0
+	for _, count := range m.keyCounts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+	return maxCount
+}
+
+func (m StatisticsHeatmapView) renderKeyboard() string {
+	maxCount := m.maxKeyCount()
+
+	rows := make([]string, 0, len(keyboardRows)+1)
+	for _, row := range keyboardRows {
+		keys := make([]string, 0, len(row.keys))
+		for _, key := range row.keys {
+			keys = append(keys, renderHeatKey(" "+key+" ", m.keyCounts[key], maxCount))
+		}
+		rows = append(rows, strings.Repeat(" ", row.indent)+strings.Join(keys, " "))
+	}
+	rows = append(rows, strings.Repeat(" ", 10)+renderHeatKey(strings.Repeat(" ", 12)+"space"+strings.Repeat(" ", 12), m.keyCounts["space"], maxCount))
+
+	return strings.Join(rows, "\n")
+}
+
+func (m StatisticsHeatmapView) renderLegend() string {
+	parts := []string{RenderText("少")}
+	for level := range heatColors {
+		parts = append(parts, heatStyle(level).Render("  "))
+	}
+	parts = append(parts, RenderText("多"))
+	return strings.Join(parts, " ")
+}
+
+// renderHeatKey 按输错次数的相对多少为按键着色
+func renderHeatKey(label string, count, maxCount int) string {
+	return heatStyle(heatLevel(count, maxCount)).Render(label)
+}
+
+// heatLevel 将输错次数映射到颜色等级，0 表示没有输错
+func heatLevel(count, maxCount int) int {
+	if count <= 0 || maxCount <= 0 {
+		return 0
+	}
+	levels := len(heatColors) - 1
+	level := (count*levels + maxCount - 1) / maxCount
+	if level < 1 {
+		level = 1
+	}
+	if level > levels {
+		level = levels
+	}
+	return level
+}
+
+func heatStyle(level int) lipgloss.Style {
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color(heatColors[level]))
+}
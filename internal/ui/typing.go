@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ajilisiwei/mllt-cli/internal/statistics"
+)
+
+// typingTracker 记录练习过程中的按键事件，用于计算打字速度和按键错误分布
+type typingTracker struct {
+	keystrokes   int
+	backspaces   int
+	correctChars int
+	wrongChars   int
+	activeTime   time.Duration
+	itemFirstKey time.Time // 当前项目第一次按键的时间
+	keyErrors    map[string]int
+	foldCase     bool // 比较字符时是否忽略大小写
+}
+
+func newTypingTracker(foldCase bool) *typingTracker {
+	return &typingTracker{
+		keyErrors: make(map[string]int),
+		foldCase:  foldCase,
+	}
+}
+
+// observe 比较一次按键前后的输入内容：新增的字符计入按键数，
+// 与期望文本对应位置不一致的字符计入错误，内容变短视为退格。
+func (t *typingTracker) observe(before, after, expected string, now time.Time) {
+	if t == nil || before == after {
+		return
+	}
+	if strings.HasPrefix(strings.TrimSpace(after), ">") {
+		// 命令输入不计入打字统计
+		return
+	}
+
+	beforeRunes := []rune(before)
+	afterRunes := []rune(after)
+	expectedRunes := []rune(expected)
+
+	if len(afterRunes) < len(beforeRunes) {
+		t.backspaces++
+		return
+	}
+
+	// 找到本次新增字符的起始位置（光标可能不在末尾）
+	start := 0
+	for start < len(beforeRunes) && beforeRunes[start] == afterRunes[start] {
+		start++
+	}
+	added := len(afterRunes) - len(beforeRunes)
+	if added == 0 {
+		return
+	}
+
+	if t.itemFirstKey.IsZero() {
+		t.itemFirstKey = now
+	}
+
+	for i := start; i < start+added && i < len(afterRunes); i++ {
+		t.keystrokes++
+		if i >= len(expectedRunes) {
+			t.wrongChars++
+			continue
+		}
+		if !t.sameChar(afterRunes[i], expectedRunes[i]) {
+			t.wrongChars++
+			t.keyErrors[errorKey(expectedRunes[i])]++
+		}
+	}
+}
+
+// observeRune 记录一次逐字输入的按键，expected 为光标处期望的字符，用于文章连续输入
+func (t *typingTracker) observeRune(expected rune, correct bool, now time.Time) {
+	if t == nil {
+		return
+	}
+	if t.itemFirstKey.IsZero() {
+		t.itemFirstKey = now
+	}
+	t.keystrokes++
+	if !correct {
+		t.wrongChars++
+		t.keyErrors[errorKey(expected)]++
+	}
+}
+
+// observeBackspace 记录一次退格
+func (t *typingTracker) observeBackspace() {
+	if t == nil {
+		return
+	}
+	t.backspaces++
+}
+
+// commit 在答对一项后调用，累计正确字符数和该项的打字用时
+func (t *typingTracker) commit(expected string, now time.Time) {
+	t.commitChars(utf8.RuneCountInString(expected), now)
+}
+
+// commitChars 累计 n 个正确字符和从第一次按键到现在的打字用时
+func (t *typingTracker) commitChars(n int, now time.Time) {
+	if t == nil {
+		return
+	}
+	t.correctChars += n
+	if !t.itemFirstKey.IsZero() && now.After(t.itemFirstKey) {
+		t.activeTime += now.Sub(t.itemFirstKey)
+	}
+	t.itemFirstKey = time.Time{}
+}
+
+// skip 在跳过当前项目（标记、收藏等）时调用，丢弃该项的计时
+func (t *typingTracker) skip() {
+	if t == nil {
+		return
+	}
+	t.itemFirstKey = time.Time{}
+}
+
+// stats 生成写入统计记录的按键数据，没有任何按键时返回 nil
+func (t *typingTracker) stats() *statistics.TypingStats {
+	if t == nil || (t.keystrokes == 0 && t.backspaces == 0) {
+		return nil
+	}
+
+	result := &statistics.TypingStats{
+		Keystrokes:    t.keystrokes,
+		Backspaces:    t.backspaces,
+		CorrectChars:  t.correctChars,
+		WrongChars:    t.wrongChars,
+		ActiveSeconds: t.activeTime.Seconds(),
+	}
+	if len(t.keyErrors) > 0 {
+		result.KeyErrors = make(map[string]int, len(t.keyErrors))
+		for key, count := range t.keyErrors {
+			result.KeyErrors[key] = count
+		}
+	}
+	result.ComputeSpeed()
+	return result
+}
+
+func (t *typingTracker) sameChar(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	return t.foldCase && unicode.ToLower(a) == unicode.ToLower(b)
+}
+
+// errorKey 返回错误统计使用的键名：字母统一为小写，空格记为 "space"
+func errorKey(r rune) string {
+	if unicode.IsSpace(r) {
+		return "space"
+	}
+	return string(unicode.ToLower(r))
+}